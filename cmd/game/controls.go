@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/my2dgame/internal/i18n"
+	"example.com/my2dgame/internal/input"
+	"example.com/my2dgame/internal/ui"
+)
+
+// bindSlots — ячеек на действие: две для клавиатуры/мыши, одна для геймпада
+const bindSlots = 3
+
+// bindingName — подпись привязки: клавиши — ui.KeyName, мышь — из таблицы языка
+func bindingName(tr *i18n.Bundle, code int32) string {
+	b := input.Binding(code)
+	switch b.Device() {
+	case input.DeviceKey, input.DeviceNone:
+		return ui.KeyName(code)
+	case input.DeviceMouse:
+		return tr.T(fmt.Sprintf("mouse.%d", b.Index()))
+	}
+	return b.Name()
+}
+
+// profileName — имя профиля; у стандартного его нет, берём из таблицы языка
+func profileName(tr *i18n.Bundle, p *input.Profile) string {
+	if p.Name == "" {
+		return tr.T("controls.default")
+	}
+	return p.Name
+}
+
+// newControlsView — переназначение действий и настройки геймпада.
+// Изменения сразу попадают в активный профиль; onBack сохраняет файл.
+func newControlsView(theme *ui.Theme, tr *i18n.Bundle, profiles *input.Profiles, ctl *input.Controller, onBack func()) *ui.View {
+	var refresh []func() // перечитать значения виджетов из активного профиля
+	cur := profiles.Current
+
+	row := func(w ui.Widget) ui.Widget {
+		w.Base().Fill = true
+		return w
+	}
+	poll := func(in ui.Input) int32 {
+		if in.Key != 0 {
+			return in.Key
+		}
+		return int32(input.PollPressed(ctl.Pad))
+	}
+
+	var items []ui.Widget
+	for _, a := range input.Actions {
+		name := ui.NewLabel(tr.T("action." + string(a)))
+		name.Width = 100
+		line := ui.HBox(name)
+		for slot := 0; slot < bindSlots; slot++ {
+			f := ui.NewKeyField("", int32(cur().Slot(a, slot)), func(code int32) {
+				cur().SetSlot(a, slot, input.Binding(code))
+			})
+			f.Name = func(code int32) string { return bindingName(tr, code) }
+			f.Poll = poll
+			f.Width = 78
+			line.Add(f)
+			refresh = append(refresh, func() { f.Key = int32(cur().Slot(a, slot)) })
+		}
+		items = append(items, line)
+	}
+
+	deadzone := ui.NewSlider(tr.T("controls.deadzone"), cur().Deadzone, 0, 0.5, 0.05, func(v float32) {
+		cur().Deadzone = v
+	})
+	assist := ui.NewSlider(tr.T("controls.aim_assist"), cur().AimAssist, 0, 1, 0.1, func(v float32) {
+		cur().AimAssist = v
+	})
+	autoFire := ui.NewToggle(tr.T("controls.auto_fire"), cur().AutoFire, func(on bool) {
+		cur().AutoFire = on
+	})
+	refresh = append(refresh, func() {
+		deadzone.Value, assist.Value, autoFire.On = cur().Deadzone, cur().AimAssist, cur().AutoFire
+	})
+	items = append(items, row(deadzone), row(assist), row(autoFire))
+
+	list := ui.NewScrollList(200, items...)
+	list.Width = 380
+
+	names := func() []string {
+		out := make([]string, len(profiles.List))
+		for i := range profiles.List {
+			out[i] = profileName(tr, &profiles.List[i])
+		}
+		return out
+	}
+	var picker *ui.Dropdown
+	activate := func(i int) {
+		profiles.Active = i
+		ctl.Profile = cur()
+		picker.Options, picker.Index = names(), i
+		for _, f := range refresh {
+			f()
+		}
+	}
+	picker = ui.NewDropdown(tr.T("controls.profile"), names(), profiles.Active, activate)
+	picker.Fill = true
+
+	buttons := ui.HBox(
+		ui.NewButton(tr.T("controls.new_profile"), func() {
+			p := cur().Clone()
+			p.Name = tr.T("controls.profile_n", len(profiles.List)+1)
+			profiles.List = append(profiles.List, p)
+			activate(len(profiles.List) - 1)
+		}),
+		ui.NewButton(tr.T("controls.reset"), func() {
+			p := input.DefaultProfile()
+			p.Name = cur().Name
+			*cur() = p
+			activate(profiles.Active)
+		}),
+		ui.NewButton(tr.T("common.back"), onBack),
+	)
+
+	panel := ui.VBox(ui.NewTitle(tr.T("controls.title")), picker, list, buttons)
+	panel.Panel = true
+	panel.Padding = 10
+	panel.Gap = 6
+
+	v := ui.NewView(theme, panel)
+	v.OnBack = onBack
+	return v
+}
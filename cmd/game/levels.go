@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/my2dgame/internal/i18n"
+	"example.com/my2dgame/internal/level"
+	"example.com/my2dgame/internal/ui"
+)
+
+// clock — секунды как «м:сс»
+func clock(t float32) string {
+	s := int(t)
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// goalText — условие победы уровня словами
+func goalText(tr *i18n.Bundle, d *level.Def) string {
+	switch d.Win.Kind {
+	case level.WinSurvive:
+		return tr.T("goal.survive", clock(d.Win.Time))
+	case level.WinBoss:
+		return tr.T("goal.boss")
+	}
+	return tr.T("goal.none")
+}
+
+// newLevelsView — выбор уровня: закрытые и не купленные видны, но недоступны,
+// у пройденных — лучшее время
+func newLevelsView(theme *ui.Theme, tr *i18n.Bundle, defs []*level.Def, progress *level.Progress, bought func(*level.Def) bool, onPick func(*level.Def), onBack func()) *ui.View {
+	names := map[string]string{}
+	for _, d := range defs {
+		names[d.ID] = tr.T(d.Name)
+	}
+
+	var items []ui.Widget
+	for _, d := range defs {
+		b := ui.NewButton(names[d.ID], func() { onPick(d) })
+		b.Width = 130
+		status := ui.NewLabel(goalText(tr, d))
+		status.Size = theme.HintSize
+		switch r := progress.Levels[d.ID]; {
+		case !progress.Unlocked(d):
+			b.Disabled = true
+			status.Text = tr.T("levels.locked", names[d.Requires])
+		case !bought(d):
+			b.Disabled = true
+			status.Text = tr.T("levels.shop", d.Cost)
+		case r.Cleared:
+			status.Text += "  ·  " + tr.T("levels.best", clock(r.BestTime))
+		}
+		items = append(items, ui.HBox(b, status))
+	}
+
+	list := ui.NewScrollList(200, items...)
+	list.Width = 380
+
+	panel := ui.VBox(ui.NewTitle(tr.T("levels.title")), list, ui.NewButton(tr.T("common.back"), onBack))
+	panel.Panel = true
+	panel.Padding = 10
+	panel.Gap = 6
+
+	v := ui.NewView(theme, panel)
+	v.OnBack = onBack
+	return v
+}
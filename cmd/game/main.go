@@ -8,21 +8,36 @@ import (
 	"path/filepath"
 	"time"
 
+	"example.com/my2dgame/internal/audio"
+	"example.com/my2dgame/internal/audio/rlaudio"
+	"example.com/my2dgame/internal/camera"
+	"example.com/my2dgame/internal/canvas"
+	"example.com/my2dgame/internal/config"
 	"example.com/my2dgame/internal/entities"
+	"example.com/my2dgame/internal/fx"
+	"example.com/my2dgame/internal/fx/fxdraw"
+	"example.com/my2dgame/internal/i18n"
+	"example.com/my2dgame/internal/input"
+	"example.com/my2dgame/internal/level"
+	"example.com/my2dgame/internal/mapgen"
+	"example.com/my2dgame/internal/meta"
+	"example.com/my2dgame/internal/nav"
+	"example.com/my2dgame/internal/render"
+	"example.com/my2dgame/internal/stats"
 	"example.com/my2dgame/internal/ui"
 	"example.com/my2dgame/internal/world"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-func fitCameraToWorld(cam *rl.Camera2D, worldW, worldH float32) {
-	sw := float32(rl.GetScreenWidth())
-	sh := float32(rl.GetScreenHeight())
-	minZoomX := sw / worldW
-	minZoomY := sh / worldH
-	minZoom := float32(math.Max(float64(minZoomX), float64(minZoomY)))
-	if cam.Zoom < minZoom {
-		cam.Zoom = minZoom
+// rlCamera переводит контроллер камеры в rl.Camera2D для отрисовки
+func rlCamera(c *camera.Camera) rl.Camera2D {
+	x, y := c.Target()
+	return rl.Camera2D{
+		Target:   rl.NewVector2(x, y),
+		Offset:   rl.NewVector2(c.ViewW/2, c.ViewH/2),
+		Rotation: c.Roll(),
+		Zoom:     c.Zoom,
 	}
 }
 
@@ -50,6 +65,35 @@ func findAssets() string {
 	return "assets"
 }
 
+// worldTexel — мировых единиц на пиксель карты. Размеры и скорости сущностей
+// подобраны под эту метрику, а камера с зумом 1/worldTexel показывает карту 1:1.
+const worldTexel = 3.0
+
+// Окно сетки проходимости в бесконечном мире (клеток на сторону): сетка
+// следует за игроком и перестраивается, когда он отходит на четверть окна
+const navWindow = 128
+
+// Уровни детализации симуляции врагов: ближе simNear — полный ИИ,
+// до simFar — упрощённый шаг по прямой, дальше — исчезают (директор
+// пришлёт новых рядом с игроком)
+const (
+	simNear = 2400
+	simFar  = 4800
+)
+
+// Сцена рисует только то, что задевает кадр, расширенный на cullMargin:
+// спрайты и круги взрывов торчат из своей точки привязки
+const cullMargin = 200
+
+// Враги появляются на точках карты в этом кольце вокруг игрока
+const (
+	spawnMin = 500
+	spawnMax = 1400
+)
+
+// Для интенсивности музыки враги дальше intensityRadius не в счёт
+const intensityRadius = 900
+
 type AppState int
 
 const (
@@ -57,37 +101,22 @@ const (
 	StateGame
 	StatePause
 	StateDefeat
+	StateSettings
+	StateControls
+	StateLevels
+	StateVictory
+	StateShop
 )
 
 // -------- UI --------
 var uiFont rl.Font
 
-const uiSize float32 = 28
-const uiSpacing float32 = 1
+const uiHint float32 = 10
 
-type Button struct {
-	Bounds rl.Rectangle
-	Label  string
-	Hot    bool
-}
+const uiSpacing float32 = 1
 
 var cursorTexture rl.Texture2D
 
-func (b *Button) Draw() {
-	col := rl.NewColor(255, 255, 255, 200)
-	border := rl.Black
-	if b.Hot {
-		col = rl.NewColor(255, 255, 255, 240)
-		border = rl.DarkGray
-	}
-	rl.DrawRectangleRounded(b.Bounds, 0.2, 8, col)
-	rl.DrawRectangleRoundedLines(b.Bounds, 0.2, 8, border)
-	ts := rl.MeasureTextEx(uiFont, b.Label, uiSize, uiSpacing)
-	x := b.Bounds.X + b.Bounds.Width/2 - ts.X/2
-	y := b.Bounds.Y + b.Bounds.Height/2 - ts.Y/2
-	rl.DrawTextEx(uiFont, b.Label, rl.NewVector2(x, y), uiSize, uiSpacing, rl.Black)
-}
-
 func segmentCircleHit(ax, ay, bx, by, cx, cy, r float32) bool {
 	abx, aby := bx-ax, by-ay
 	acx, acy := cx-ax, cy-ay
@@ -189,62 +218,317 @@ func segSegDistSq(ax, ay, bx, by, cx, cy, dx, dy float32) float32 {
 	return dx_*dx_ + dy_*dy_
 }
 
-func DrawCursor() {
-	mousePos := rl.GetMousePosition()
-	offset := rl.NewVector2(16, 16) // смещение "горячей точки" курсора
-	rl.DrawTextureV(cursorTexture, rl.Vector2Subtract(mousePos, offset), rl.White)
+// DrawCursor — mousePos в координатах виртуального экрана
+func DrawCursor(mousePos rl.Vector2) {
+	offset := rl.NewVector2(8, 8) // смещение "горячей точки" курсора
+	rl.DrawTextureEx(cursorTexture, rl.Vector2Subtract(mousePos, offset), 0, 0.5, rl.White)
 }
 
 func main() {
-	// Полноэкранный старт
-	mon := rl.GetCurrentMonitor()
-	W := int32(rl.GetMonitorWidth(mon))
-	H := int32(rl.GetMonitorHeight(mon))
-	rl.SetConfigFlags(rl.FlagVsyncHint)
-	rl.InitWindow(W, H, "My 2D Game — Menu + Game + Music")
-	rl.ToggleFullscreen()
+	// Настройки: битый файл — не повод не запускаться
+	cfgPath, err := config.Path()
+	if err != nil {
+		fmt.Println("config:", err)
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		fmt.Println("warning:", err)
+	}
+	saveConfig := func() {
+		if cfgPath == "" {
+			return
+		}
+		if err := config.Save(cfgPath, cfg); err != nil {
+			fmt.Println("config save:", err)
+		}
+	}
+
+	// Раскладки управления — рядом с config.json
+	controlsPath := ""
+	if cfgPath != "" {
+		controlsPath = filepath.Join(filepath.Dir(cfgPath), "controls.json")
+	}
+	profiles, err := input.LoadProfiles(controlsPath)
+	if err != nil {
+		fmt.Println("warning:", err)
+	}
+	ctl := input.NewController(profiles.Current())
+	saveControls := func() {
+		if controlsPath == "" {
+			return
+		}
+		if err := input.SaveProfiles(controlsPath, profiles); err != nil {
+			fmt.Println("controls save:", err)
+		}
+	}
+
+	flags := uint32(rl.FlagWindowResizable)
+	if cfg.VSync {
+		flags |= rl.FlagVsyncHint
+	}
+	rl.SetConfigFlags(flags)
+	rl.InitWindow(int32(cfg.Width), int32(cfg.Height), "My 2D Game — Menu + Game + Music")
+	applyDisplay(cfg)
+
+	// Виртуальный экран: игра и UI рисуются в кадр из настроек, окно любого
+	// размера получает его целым масштабом с полями или дробным «вписыванием»
+	cnv := canvas.New(int32(cfg.VirtualW), int32(cfg.VirtualH), cfg.Scaling == config.ScaleInteger)
+	defer cnv.Unload()
+	ui.SetScreen(cnv.Size())
 
 	rl.SetExitKey(rl.KeyNull)
 
 	defer rl.CloseWindow()
-	rl.SetTargetFPS(60)
 
 	rand.Seed(time.Now().UnixNano())
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	bg := rl.NewColor(240, 243, 248, 255)
 	assetsRoot := findAssets()
 
-	// Мир (фон-карта). Если мылится — уменьшай scale.
-	wrld, err := world.LoadBackdrop(assetsRoot, filepath.Join("textures", "maps", "village.png"), 3.0)
+	// Мир текущего уровня; пустой, пока уровень не выбран
+	wrld := &world.World{}
+	defer func() { wrld.Unload() }()
+
+	// Проходимость для поиска пути врагов; поле потока всегда ведёт к игроку.
+	// Клетка — 16 пикселей карты (как у сгенерированных арен) в масштабе уровня.
+	// У бесконечного мира сетка — окно navWindow клеток вокруг (cx, cy).
+	var (
+		navCell  float32 = world.GenTilePx * worldTexel
+		navGrid  *nav.Grid
+		navField *nav.Field
+		navVer   uint64
+		navX     float32
+		navY     float32
+
+		objects []*entities.Object // объекты мира: непроходимые тоже перекрывают сетку
+	)
+	blockObject := func(o *entities.Object, block bool) {
+		if !o.Def.Solid {
+			return
+		}
+		b := o.Bounds()
+		r := nav.Rect{X: b.X, Y: b.Y, W: b.Width, H: b.Height}
+		if block {
+			navGrid.Block(r)
+		} else {
+			navGrid.Unblock(r)
+		}
+	}
+	buildNav := func(cx, cy float32) {
+		solids := wrld.Solids
+		if wrld.Streamed() {
+			side := float32(navWindow * navCell)
+			r := rl.NewRectangle(cx-side/2, cy-side/2, side, side)
+			navGrid = nav.NewGridAt(r.X, r.Y, side, side, navCell)
+			solids = wrld.SolidsIn(r)
+		} else {
+			navGrid = nav.NewGrid(wrld.WidthPx, wrld.HeightPx, navCell)
+		}
+		for _, r := range solids {
+			navGrid.Block(nav.Rect{X: r.X, Y: r.Y, W: r.Width, H: r.Height})
+		}
+		for _, o := range objects {
+			blockObject(o, true)
+		}
+		navField = nav.NewField(navGrid)
+		entities.SetNav(navGrid, navField)
+		navVer, navX, navY = wrld.Version(), cx, cy
+	}
+
+	// Биомы процедурных карт
+	biomes, err := mapgen.LoadBiomes(filepath.Join(assetsRoot, "data", "biomes.json"))
+	if err != nil {
+		fmt.Println("biomes:", err)
+	}
+
+	// Уровни и прогресс (progress.json — рядом с config.json)
+	levels, err := level.LoadDefs(filepath.Join(assetsRoot, "data", "levels.json"), worldTexel)
+	if err != nil {
+		fmt.Println("levels:", err)
+	}
+	progressPath := ""
+	if cfgPath != "" {
+		progressPath = filepath.Join(filepath.Dir(cfgPath), "progress.json")
+	}
+	progress, err := level.LoadProgress(progressPath)
+	if err != nil {
+		fmt.Println("warning:", err)
+	}
+	saveProgress := func() {
+		if progressPath == "" {
+			return
+		}
+		if err := level.SaveProgress(progressPath, progress); err != nil {
+			fmt.Println("progress save:", err)
+		}
+	}
+
+	// Профиль между забегами (profile.json — рядом с config.json) и магазин
+	shop, err := meta.LoadShop(filepath.Join(assetsRoot, "data", "shop.json"))
+	if err != nil {
+		fmt.Println("shop:", err)
+		shop = &meta.Shop{}
+	}
+	for _, d := range levels {
+		if d.Cost > 0 {
+			if err := shop.AddUnlock(&meta.Unlock{Kind: meta.UnlockLevel, ID: d.ID, Name: d.Name, Cost: d.Cost}); err != nil {
+				fmt.Println("shop:", err)
+			}
+		}
+	}
+	weapons, err := entities.LoadWeapons(filepath.Join(assetsRoot, "data", "weapons.json"))
+	if err != nil {
+		fmt.Println("weapons:", err)
+	}
+	profilePath := ""
+	if cfgPath != "" {
+		profilePath = filepath.Join(filepath.Dir(cfgPath), "profile.json")
+	}
+	profile, err := meta.LoadProfile(profilePath)
+	if err != nil {
+		fmt.Println("warning:", err)
+	}
+	saveProfile := func() {
+		if profilePath == "" {
+			return
+		}
+		if err := meta.SaveProfile(profilePath, profile); err != nil {
+			fmt.Println("profile save:", err)
+		}
+	}
+	// bought — уровень с ценой куплен в магазине
+	bought := func(d *level.Def) bool {
+		u := shop.Unlock(meta.UnlockLevel, d.ID)
+		return u == nil || profile.Owns(u)
+	}
+	// applyProfile — улучшения и снаряжение из профиля новому игроку
+	applyProfile := func(p *entities.Player) {
+		p.MaxHP += int(profile.Bonus(shop, meta.UpgMaxHP))
+		p.HP = p.MaxHP
+		p.CrookCooldown = max(p.CrookCooldown+profile.Bonus(shop, meta.UpgCrook), 0.5)
+		p.Magnet += profile.Bonus(shop, meta.UpgMagnet)
+		p.Ult.Charge = min(int(profile.Bonus(shop, meta.UpgUltCharge)), p.Ult.MaxCharge)
+		weapon, ult := profile.Loadout(shop)
+		if w := weapons[weapon]; w != nil {
+			p.SetWeapon(w)
+		}
+		if ult != "" {
+			p.Ult.Kind = ult
+		}
+	}
+
+	// Биом и сид последней сгенерированной карты уходят в статистику забега,
+	// чтобы карту можно было воспроизвести
+	var (
+		mapBiome string
+		mapSeed  int64
+	)
+
+	// loadWorld строит мир уровня и заменяет им прежний (тот выгружается целиком).
+	// Сгенерированные карты — со своим сидом на каждый забег; у бесконечного мира
+	// чанки, нарисованные вручную (assets/chunks/<биом>/<x>_<y>.json), важнее генератора.
+	loadWorld := func(d *level.Def) error {
+		var (
+			w   *world.World
+			err error
+		)
+		mapBiome, mapSeed = "", 0
+		if d.Map.Kind == level.MapBackdrop {
+			w, err = world.LoadBackdrop(assetsRoot, filepath.FromSlash(d.Map.Image), d.Map.Scale)
+		} else {
+			name := d.Map.Biome
+			if name == "" {
+				names := mapgen.Names(biomes)
+				if len(names) == 0 {
+					return fmt.Errorf("уровень %q: нет биомов", d.ID)
+				}
+				name = names[rng.Intn(len(names))]
+			}
+			b, ok := biomes[name]
+			if !ok {
+				return fmt.Errorf("уровень %q: нет биома %q", d.ID, name)
+			}
+			seed := rng.Int63()
+			mapBiome, mapSeed = name, seed
+			if d.Map.Kind == level.MapArena {
+				w, err = world.FromMap(mapgen.Generate(b, seed), d.Map.Scale)
+			} else {
+				w = world.NewStreamed(mapgen.Files{
+					Dir:      filepath.Join(assetsRoot, "chunks", name),
+					Biome:    b,
+					Fallback: mapgen.Procedural{Biome: b, Seed: seed},
+				}, d.Map.Scale)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		wrld.Unload()
+		wrld = w
+		navCell = world.GenTilePx * d.Map.Scale
+		buildNav(wrld.Spawn.X, wrld.Spawn.Y)
+		return nil
+	}
+
+	// Подбираемые предметы и таблицы добычи
+	loot, err := entities.LoadLoot(filepath.Join(assetsRoot, "data", "pickups.json"))
 	if err != nil {
-		fmt.Println("world:", err)
-		return
+		fmt.Println("pickups:", err)
+		loot = &entities.Loot{}
 	}
-	defer wrld.Unload()
 
+	// Объекты мира: ящики, бочки, шипы, святилища, телепорты
+	objectDefs, err := entities.LoadObjectDefs(filepath.Join(assetsRoot, "data", "objects.json"))
+	if err != nil {
+		fmt.Println("objects:", err)
+	}
 	// Проектайлы
 	if err := entities.LoadProjectileAssets(assetsRoot); err != nil {
 		fmt.Println("projectiles:", err)
 	}
 
+	// Поведение врагов
+	if err := entities.LoadBrains(filepath.Join(assetsRoot, "data", "ai.json")); err != nil {
+		fmt.Println("ai:", err)
+	}
+
+	entities.SetDifficulty(cfg.Difficulty)
+	if err := entities.LoadAimProfiles(filepath.Join(assetsRoot, "data", "aim.json")); err != nil {
+		fmt.Println("aim:", err)
+	}
+
+	// Частицы
+	fxDefs, err := fx.LoadDefs(filepath.Join(assetsRoot, "data", "fx.json"))
+	if err != nil {
+		fmt.Println("fx:", err)
+	}
+	fxSys := fx.NewSystem(fxDefs, 4096, time.Now().UnixNano())
+	fxRender := fxdraw.NewRenderer(assetsRoot)
+	defer fxRender.Unload()
+
+	// Боссы
+	bossDefs, err := entities.LoadBossDefs(filepath.Join(assetsRoot, "data", "bosses.json"))
+	if err != nil {
+		fmt.Println("bosses:", err)
+	}
+
 	rl.HideCursor()
 
-	// Шрифт
-	charset := func() []int32 {
-		cps := make([]int32, 0, 1024)
-		add := func(a, b int32) {
-			for cp := a; cp <= b; cp++ {
-				cps = append(cps, cp)
-			}
-		}
-		add(0x0020, 0x007E)
-		add(0x0400, 0x04FF)
-		add(0x0500, 0x052F)
-		add(0x2DE0, 0x2DFF)
-		add(0xA640, 0xA69F)
-		add(0x2010, 0x205E)
-		return cps
-	}()
-	hud, err := ui.LoadHealthHUD(assetsRoot, 20, 60, 1.9) // позиция (20,60), масштаб 1.0
+	// Языки: русский — исходный, им закрываются дыры в остальных
+	tr, err := i18n.Load(filepath.Join(assetsRoot, "lang"), "ru")
+	if err != nil {
+		fmt.Println("i18n:", err)
+	}
+	for code, keys := range tr.Missing() {
+		fmt.Printf("i18n: в %s нет ключей: %v\n", code, keys)
+	}
+	if err := tr.SetLanguage(cfg.Language); err != nil {
+		fmt.Println("i18n:", err)
+	}
+
+	// Шрифт: глифы — из всех языков и значков подписей клавиш
+	charset := tr.Glyphs("←→↑↓—∞×")
+	hud, err := ui.LoadHealthHUD(assetsRoot, 6, 6, 0.5)
 	if err != nil {
 		fmt.Println("health hud:", err)
 	} // не фейлим игру, просто лог
@@ -254,7 +538,7 @@ func main() {
 		}
 	}()
 
-	ultHUD, err := ui.LoadUltHUD(assetsRoot, 20, 20, 1.5)
+	ultHUD, err := ui.LoadUltHUD(assetsRoot, 6, 6, 0.5) // от правого края
 	if err != nil {
 		fmt.Println("ult hud:", err)
 	}
@@ -265,10 +549,20 @@ func main() {
 	}()
 
 	fontPath := filepath.Join(assetsRoot, "fonts", "NotoSans-Regular.ttf")
-	uiFont = rl.LoadFontEx(fontPath, int32(48), charset) // если нужно: , int32(len(charset))
+	uiFont = rl.LoadFontEx(fontPath, int32(48), charset)
 	rl.SetTextureFilter(uiFont.Texture, rl.FilterBilinear)
 	defer rl.UnloadFont(uiFont)
 
+	bossBar := ui.NewBossBar(uiFont)
+
+	baseTheme, err := ui.LoadTheme(filepath.Join(assetsRoot, "data", "theme.json"))
+	if err != nil {
+		fmt.Println("theme:", err)
+	}
+	baseTheme.Font = uiFont
+	// экраны держат указатель на theme, масштаб UI подменяет её содержимое
+	theme := baseTheme.Scaled(cfg.UIScale)
+
 	// Фон меню
 	var menuBG rl.Texture2D
 	if img := rl.LoadImage(filepath.Join(assetsRoot, "ui", "menu_bg.png")); img.Data != nil {
@@ -305,81 +599,155 @@ func main() {
 	// Аудио
 	rl.InitAudioDevice()
 	defer rl.CloseAudioDevice()
-	var (
-		menuMusic, gameMusic       rl.Music
-		hasMenuMusic, hasGameMusic bool
-	)
-	if _, err := os.Stat(filepath.Join(assetsRoot, "music", "menu.mp3")); err == nil {
-		menuMusic = rl.LoadMusicStream(filepath.Join(assetsRoot, "music", "menu.mp3"))
-		menuMusic.Looping = true
-		rl.SetMusicVolume(menuMusic, 0.7)
-		rl.PlayMusicStream(menuMusic)
-		hasMenuMusic = true
-	}
-	if _, err := os.Stat(filepath.Join(assetsRoot, "music", "game.mp3")); err == nil {
-		gameMusic = rl.LoadMusicStream(filepath.Join(assetsRoot, "music", "game.mp3"))
-		gameMusic.Looping = true
-		rl.SetMusicVolume(gameMusic, 0.6)
-		hasGameMusic = true
+	sound := audio.New(rlaudio.New(), time.Now().UnixNano())
+	if defs, err := audio.LoadDefs(filepath.Join(assetsRoot, "data", "audio.json")); err != nil {
+		fmt.Println("audio:", err)
+	} else if err := sound.Load(assetsRoot, defs); err != nil {
+		fmt.Println("audio:", err) // чего-то не хватает — оно просто молчит
 	}
-	defer func() {
-		if hasMenuMusic {
-			rl.UnloadMusicStream(menuMusic)
-		}
-		if hasGameMusic {
-			rl.UnloadMusicStream(gameMusic)
-		}
-	}()
-
-	// Кнопки
-	btnPlay := Button{Label: "Играть"}
-	btnExit := Button{Label: "Выйти"}
-	btnResume := Button{Label: "Продолжить"}
-	btnToMenu := Button{Label: "Выйти в меню"}
-	btnRestart := Button{Label: "Начать заново"}
-	btnQuit := Button{Label: "Выйти из игры"}
+	defer sound.Unload()
+	entities.SetAudio(sound)
+	ui.OnSound = func(name string) { sound.Play(name) }
+	sound.PlayMusic("menu", 0)
+
+	applyAudio := func() {
+		sound.SetBus(audio.BusMaster, cfg.MasterVolume)
+		sound.SetBus(audio.BusMusic, cfg.MusicVolume)
+		sound.SetBus(audio.BusSFX, cfg.SFXVolume)
+		sound.SetBus(audio.BusUI, cfg.UIVolume)
+	}
+	applyAudio()
 
 	state := StateMenu
+	quit := false
 
 	// --- ИГРА ---
 	var (
-		player     *entities.Player
-		enemies    []*entities.Enemy
-		souls      []*entities.Soul
-		spawnT     float32
-		spawnEvery = float32(2.0)
-		cam        rl.Camera2D
+		player  *entities.Player
+		enemies []*entities.Enemy
+		pickups []*entities.Pickup
+		cam     rl.Camera2D
+		view    *camera.Camera
+
+		curLevel    *level.Def
+		director    *level.Director // волны врагов текущего уровня
+		bossDef     *entities.BossDef
+		runTime     float32
+		run         *stats.Run // статистика забега для экрана итогов
+		banked      bool       // забег уже зачислен в профиль
+		boss        *entities.Boss
+		bossSpawned bool
+		bossDead    bool
+		hazards     []*entities.Telegraph // взрывы элитных врагов
+		hitStop     float32               // стоп-кадр после сильного удара
+		trail       *fx.Emitter
+
+		lastHP    int     // HP в прошлом кадре — чтобы ловить любой урон
+		recentDmg float32 // недавний урон для интенсивности музыки, тает со временем
 	)
 
-	startGame := func() {
+	shake := func(trauma float32) {
+		if cfg.ScreenShake {
+			view.AddTrauma(trauma)
+		}
+	}
+
+	// spawnObjects забирает новые объекты из слоя объектов мира и связывает телепорты.
+	// objKeys — откуда объект в бесконечном мире: туда пишем, что он сломан
+	// или перезаряжается, чтобы при возврате чанка не появился заново.
+	objKeys := map[*entities.Object]world.ObjectKey{}
+	spawnObjects := func() {
+		spawns := wrld.TakeObjects()
+		if len(spawns) == 0 {
+			return
+		}
+		for _, sp := range spawns {
+			def, ok := objectDefs[sp.Type]
+			if !ok {
+				fmt.Printf("objects: неизвестный объект %q\n", sp.Type)
+				continue
+			}
+			o := entities.NewObject(def, sp.X, sp.Y)
+			o.ID, o.To = sp.ID, sp.To
+			o.Recharge(sp.State.Ready - runTime)
+			if wrld.Streamed() {
+				objKeys[o] = sp.Key
+			}
+			objects = append(objects, o)
+			blockObject(o, true)
+		}
+		byID := map[string]*entities.Object{}
+		for _, o := range objects {
+			if o.ID != "" {
+				byID[o.ID] = o
+			}
+		}
+		for _, o := range objects {
+			if o.To != "" && o.Link == nil {
+				o.Link = byID[o.To]
+			}
+		}
+	}
+
+	// startLevel — забег на уровне d: мир, враги и всё от прошлого забега —
+	// заново; при ошибке остаёмся, где были
+	startLevel := func(d *level.Def) {
 		p, err := entities.NewPlayer(assetsRoot)
 		if err != nil {
 			fmt.Println("player load:", err)
 			return
 		}
-
-		// центр спавна
-		wpx, hpx := wrld.SizePx()
-		p.X, p.Y = wpx*0.5, hpx*0.5
-
-		player = p
-		enemies = make([]*entities.Enemy, 0, 64)
-		spawnT = 0
-		cam = rl.Camera2D{
-			Target: rl.NewVector2(player.X, player.Y),
-			Offset: rl.NewVector2(float32(rl.GetScreenWidth())/2, float32(rl.GetScreenHeight())/2),
-			Zoom:   1.0,
+		prevObjects := objects
+		objects = nil
+		if err := loadWorld(d); err != nil {
+			objects = prevObjects
+			fmt.Println("level:", err)
+			return
 		}
-		// гарантируем, что экран помещается в мире
-		fitCameraToWorld(&cam, wpx, hpx)
-
-		// музыка
-		if hasMenuMusic {
-			rl.StopMusicStream(menuMusic)
+		clear(objKeys)
+		spawnObjects()
+		p.X, p.Y, _ = navGrid.Nearest(wrld.Spawn.X, wrld.Spawn.Y)
+		applyProfile(p)
+
+		curLevel = d
+		director = level.NewDirector(d.Waves)
+		bossDef = nil
+		for _, b := range bossDefs {
+			if b.ID == d.Boss {
+				bossDef = b
+			}
 		}
-		if hasGameMusic {
-			rl.PlayMusicStream(gameMusic)
+		if d.Boss != "" && bossDef == nil {
+			fmt.Printf("level %s: нет босса %q\n", d.ID, d.Boss)
 		}
+
+		player = p
+		enemies = make([]*entities.Enemy, 0, 64)
+		pickups = nil
+		runTime, banked = 0, false
+		run = stats.New(d.ID)
+		run.Biome, run.Seed = mapBiome, mapSeed
+		boss = nil
+		bossSpawned, bossDead = false, false
+		hazards = nil
+		hitStop = 0
+		lastHP, recentDmg = p.HP, 0
+		sound.SetIntensity(0)
+		fxSys.Clear()
+		trail = fxSys.Attach("dash_trail", func() (float32, float32, bool) {
+			return player.X, player.Y, true
+		})
+		view = camera.New(cnv.Size())
+		// экран не должен выходить за мир; зум 1 — пиксель карты к пикселю экрана
+		view.Cover = true
+		view.MinZoom, view.MaxZoom = 0.3/worldTexel, 3.0/worldTexel
+		view.SetZoom(1 / worldTexel)
+		view.SetBounds(wrld.SizePx())
+		view.Follow(player.X, player.Y)
+		view.Snap()
+		cam = rlCamera(view)
+
+		sound.PlayMusic(d.Music, 1)
 		state = StateGame
 	}
 
@@ -388,162 +756,528 @@ func main() {
 		ang := rand.Float64() * 2 * math.Pi
 		sx := player.X + r*float32(math.Cos(ang))
 		sy := player.Y + r*float32(math.Sin(ang))
-
-		kind := "melee"
-		speed := float32(80)
-		scale := float32(1.2)
-		if rand.Intn(2) == 0 {
-			kind = "slime"
-			speed = 70
-			scale = 1.2
+		sx, sy, _ = navGrid.Nearest(wrld.Clamp(sx, sy))
+		// у сгенерированной карты есть свои точки: берём случайную за краем экрана
+		var near []rl.Vector2
+		for _, p := range wrld.EnemySpawns {
+			if d := rl.Vector2Distance(p, rl.NewVector2(player.X, player.Y)); d >= spawnMin && d <= spawnMax {
+				near = append(near, p)
+			}
+		}
+		if len(near) > 0 {
+			p := near[rand.Intn(len(near))]
+			sx, sy = p.X, p.Y
 		}
 
-		if e, err := entities.NewEnemyKind(assetsRoot, kind, sx, sy, speed, scale); err == nil {
+		k := curLevel.Pick(rng)
+		if e, err := entities.NewEnemyKind(assetsRoot, k.Kind, sx, sy, k.Speed, k.Scale); err == nil {
+			if rng.Float32() < entities.EliteChance(runTime) {
+				e.MakeElite(entities.RollAffixes(rng, runTime))
+			}
 			enemies = append(enemies, e)
 		} else {
 			fmt.Println("enemy load:", err)
 		}
 	}
 
-	for !rl.WindowShouldClose() {
-		dt := float32(rl.GetFrameTime())
+	// dropPickups — предметы кольцом вокруг (cx, cy)
+	dropPickups := func(defs []*entities.PickupDef, cx, cy float32) {
+		for i, def := range defs {
+			sx, sy := cx, cy
+			if i > 0 {
+				a := float64(i) * 2 * math.Pi / float64(len(defs))
+				sx += 50 * float32(math.Cos(a))
+				sy += 50 * float32(math.Sin(a))
+			}
+			p, err := entities.NewPickup(assetsRoot, def, sx, sy)
+			if err != nil {
+				// лог в консоль, но не фэйлим игру
+				fmt.Println("pickup spawn:", err)
+				continue
+			}
+			pickups = append(pickups, p)
+			if def.Trail != "" {
+				fxSys.Attach(def.Trail, func() (float32, float32, bool) { return p.X, p.Y, p.Alive })
+			}
+		}
+	}
+	// dropSouls — n душ кольцом вокруг (cx, cy)
+	dropSouls := func(n int, cx, cy float32) {
+		soul := loot.Items[entities.PickupSoul]
+		if soul == nil || n <= 0 {
+			return
+		}
+		defs := make([]*entities.PickupDef, n)
+		for i := range defs {
+			defs[i] = soul
+		}
+		dropPickups(defs, cx, cy)
+	}
+	// dropLoot — добыча по таблицам (вид врага, elite, объект)
+	dropLoot := func(cx, cy float32, tables ...string) {
+		var defs []*entities.PickupDef
+		for _, t := range tables {
+			defs = append(defs, loot.Roll(rng, t)...)
+		}
+		dropPickups(defs, cx, cy)
+	}
 
-		if rl.IsKeyPressed(rl.KeyF11) {
-			rl.ToggleFullscreen()
+	// hurt наносит урон цели и записывает его в статистику забега:
+	// врагам — нанесённый, игроку — полученный (src — источник)
+	hurt := func(t entities.Damageable, dmg int, src string) {
+		switch v := t.(type) {
+		case *entities.Enemy:
+			// щит элиты принимает удар первым — он тоже нанесённый урон
+			hp := v.HP + v.Shield
+			v.TakeDamage(dmg)
+			run.Deal(src, hp-v.HP-v.Shield)
+		case *entities.Player:
+			hp := v.HP
+			v.TakeDamage(dmg)
+			run.Take(src, hp-v.HP)
+		default:
+			t.TakeDamage(dmg)
 		}
+	}
 
-		if hasMenuMusic {
-			rl.UpdateMusicStream(menuMusic)
+	// forTargets — всё, что можно ранить, с точкой ног и радиусом: игрок,
+	// живые враги и ломаемые объекты
+	forTargets := func(f func(t entities.Damageable, x, y, r float32)) {
+		fx, fy := player.Foot()
+		f(player, fx, fy, player.Radius)
+		for _, e := range enemies {
+			if e.Alive {
+				x, y := e.Foot()
+				f(e, x, y, 20*e.Scale)
+			}
 		}
-		if hasGameMusic {
-			rl.UpdateMusicStream(gameMusic)
+		for _, o := range objects {
+			if o.Alive && o.Breakable() {
+				_, _, r := o.Center()
+				f(o, o.X, o.Y, r)
+			}
 		}
+	}
 
-		rl.BeginDrawing()
+	// onEnemyDeath — души, добыча и посмертные эффекты аффиксов
+	onEnemyDeath := func(e *entities.Enemy, cx, cy float32) {
+		run.Kill(e.Kind, e.Elite)
+		dropSouls(e.SoulDrops, cx, cy)
+		if e.Elite {
+			dropLoot(cx, cy, e.Kind, "elite")
+		} else {
+			dropLoot(cx, cy, e.Kind)
+		}
+		fxSys.Emit("death_burst", cx, cy)
+		sound.PlayAt("enemy_death", cx, cy)
+		if e.Has(entities.AffixSplitting) {
+			for i := 0; i < 2; i++ {
+				x, y, _ := navGrid.Nearest(wrld.Clamp(e.X+float32(i*2-1)*30, e.Y))
+				if c, err := entities.NewEnemyKind(assetsRoot, e.Kind, x, y, e.BaseSpeed, e.Scale*0.7); err == nil {
+					c.HP, c.MaxHP = e.MaxHP/4, e.MaxHP/4
+					enemies = append(enemies, c)
+				} else {
+					fmt.Println("split:", err)
+				}
+			}
+		}
+		if e.Has(entities.AffixExplosive) {
+			hazards = append(hazards, &entities.Telegraph{X: cx, Y: cy, Radius: 110, Delay: 0.45, Damage: 20})
+		}
+	}
+
+	// endRun зачисляет забег в профиль: золото — в копилку, итоги —
+	// в статистику. Повторно (рестарт, выход в меню после победы) — ничего.
+	endRun := func(won bool) {
+		if player == nil || banked {
+			return
+		}
+		banked = true
+		run.ShotsFired = player.Fired
+		run.Finish(runTime, won)
+		r := meta.Run{Gold: player.Gold, Souls: player.Souls, Kills: stats.Total(run.Kills), Time: runTime, Won: won, Died: player.HP <= 0}
+		if bossDead {
+			r.Bosses = 1
+		}
+		profile.Bank(r)
+		saveProfile()
+	}
+
+	spawnBoss := func(def *entities.BossDef) {
+		ang := rand.Float64() * 2 * math.Pi
+		bx, by, _ := navGrid.Nearest(wrld.Clamp(player.X+500*float32(math.Cos(ang)), player.Y+500*float32(math.Sin(ang))))
+		b, err := entities.NewBoss(assetsRoot, def, bx, by)
+		if err != nil {
+			fmt.Println("boss load:", err)
+			return
+		}
+		boss = b
+		enemies = append(enemies, b.Enemy)
+		bossBar.Reset()
+		// показываем появление босса
+		view.Focus(bx, by, 1.2, 0.6)
+		shake(0.5)
+	}
+
+	// очередь отрисовки сцены: слои + сортировка по Y ног
+	var scene render.Queue
+	submitScene := func() {
+		vx, vy, vw, vh := view.Visible()
+		inView := func(x, y, r float32) bool {
+			r += cullMargin
+			return x >= vx-r && x <= vx+vw+r && y >= vy-r && y <= vy+vh+r
+		}
+		scene.Submit(render.LayerGround, render.Back, func() { wrld.Draw(cam) })
+		for _, h := range hazards {
+			if inView(h.X, h.Y, h.Radius) {
+				scene.Submit(render.LayerGround, h.Y, h.Draw)
+			}
+		}
+		for _, e := range enemies {
+			switch {
+			case boss != nil && e == boss.Enemy:
+				boss.Submit(&scene) // телеграфы босса бывают шире кадра
+			case inView(e.X, e.Y, 0):
+				e.Submit(&scene)
+			default:
+				e.SubmitShots(&scene)
+			}
+		}
+		for _, p := range pickups {
+			if inView(p.X, p.Y, 0) {
+				p.Submit(&scene)
+			}
+		}
+		for _, o := range objects {
+			if inView(o.X, o.Y, 0) {
+				o.Submit(&scene)
+			}
+		}
+		if player != nil {
+			player.Submit(&scene)
+		}
+		scene.Submit(render.LayerOverhead, 0, func() { fxRender.Draw(fxSys) })
+	}
+
+	// --- ЭКРАНЫ МЕНЮ ---
+	exitGame := func() {
+		quit = true
+	}
+	menuButton := func(text string, onClick func()) *ui.Button {
+		b := ui.NewButton(text, onClick)
+		b.Width = 130
+		return b
+	}
+	hint := func(text string) *ui.Label {
+		l := ui.NewLabel(text)
+		l.Size = theme.HintSize
+		l.Anchor, l.Margin = ui.AnchorBottomLeft, 6
+		return l
+	}
+
+	resume := func() {
+		sound.PlayMusic(curLevel.Music, 0.3)
+		state = StateGame
+	}
+
+	// Экраны собираются из строк текущего языка; смена языка пересобирает их
+	settingsFrom := StateMenu
+	var menuView, pauseView, settingsView, controlsView, levelsView, shopView *ui.View
+	var results *resultsScreen // итоги забега: поражение или победа
+	openLevels := func() {
+		levelsView = newLevelsView(theme, tr, levels, progress, bought, startLevel, func() {
+			menuView.Reset()
+			state = StateMenu
+		})
+		state = StateLevels
+	}
+	openShop := func() {
+		shopView = newShopView(theme, tr, shop, profile, saveProfile, func() {
+			menuView.Reset()
+			state = StateMenu
+		})
+		state = StateShop
+	}
+	toMenu := func() {
+		endRun(false)
+		sound.PlayMusic("menu", 1)
+		menuView.Reset()
+		state = StateMenu
+	}
+	// Итоги забега можно выгрузить в JSON (runs/ рядом с config.json)
+	exportDir := ""
+	if cfgPath != "" {
+		exportDir = filepath.Join(filepath.Dir(cfgPath), "runs")
+	}
+	showResults := func(won bool, sub string) {
+		title := tr.T("defeat.title")
+		first := menuButton(tr.T("defeat.restart"), func() { startLevel(curLevel) })
+		if won {
+			title = tr.T("victory.title")
+			first = menuButton(tr.T("victory.levels"), openLevels)
+		}
+		results = newResultsScreen(theme, tr, run, title, sub, exportDir, toMenu,
+			first, menuButton(tr.T("victory.menu"), toMenu))
+	}
+	var buildUI func()
+	openSettings := func() {
+		settingsFrom = state
+		settingsView.Reset()
+		state = StateSettings
+	}
+	buildUI = func() {
+		controlsView = newControlsView(theme, tr, &profiles, ctl, func() {
+			saveControls()
+			state = StateSettings
+		})
+		settingsView = newSettingsView(theme, tr, &cfg, func(what string) {
+			switch what {
+			case "audio":
+				applyAudio()
+			case "display":
+				applyDisplay(cfg)
+			case "canvas":
+				cnv.Resize(int32(cfg.VirtualW), int32(cfg.VirtualH))
+				cnv.Integer = cfg.Scaling == config.ScaleInteger
+				ui.SetScreen(cnv.Size())
+				if view != nil {
+					view.SetViewport(cnv.Size())
+				}
+			case "ui":
+				*theme = *baseTheme.Scaled(cfg.UIScale)
+			case "difficulty":
+				entities.SetDifficulty(cfg.Difficulty)
+			case "language":
+				if err := tr.SetLanguage(cfg.Language); err != nil {
+					fmt.Println("i18n:", err)
+				}
+				buildUI()
+			}
+		}, func() {
+			controlsView.Reset()
+			state = StateControls
+		}, func() {
+			saveConfig()
+			state = settingsFrom
+		})
+
+		menuButtons := ui.VBox(
+			ui.NewTitle("666adididas"),
+			ui.NewSpacer(0, 40),
+			menuButton(tr.T("menu.play"), openLevels),
+			menuButton(tr.T("menu.shop"), openShop),
+			menuButton(tr.T("menu.settings"), openSettings),
+			menuButton(tr.T("menu.quit"), exitGame),
+		)
+		menuRoot := ui.StackBox(menuButtons, hint(tr.T("menu.hint")))
+		menuRoot.Fill = true
+		menuView = ui.NewView(theme, menuRoot)
+		menuView.OnBack = exitGame
+
+		pauseRoot := ui.StackBox(
+			ui.VBox(
+				ui.NewTitle(tr.T("pause.title")),
+				ui.NewSpacer(0, 16),
+				menuButton(tr.T("pause.resume"), resume),
+				menuButton(tr.T("menu.settings"), openSettings),
+				menuButton(tr.T("pause.to_menu"), toMenu),
+			),
+			hint(tr.T("pause.hint")),
+		)
+		pauseRoot.Fill = true
+		pauseView = ui.NewView(theme, pauseRoot)
+		pauseView.OnBack = resume
+	}
+	buildUI()
+
+	for !rl.WindowShouldClose() && !quit {
+		dt := float32(rl.GetFrameTime())
+
+		sw, sh := cnv.Size()
+		mouse := cnv.Mouse()
+		ctl.Update(mouse)
+
+		if ctl.Pressed(input.Fullscreen) {
+			if cfg.WindowMode == config.Fullscreen {
+				cfg.WindowMode = config.Windowed
+			} else {
+				cfg.WindowMode = config.Fullscreen
+			}
+			applyDisplay(cfg)
+			saveConfig()
+		}
+
+		sound.Update(dt)
+
+		cnv.Begin()
 		rl.ClearBackground(bg)
 
 		switch state {
 		case StateMenu:
 			if menuBG.ID != 0 {
 				src := rl.NewRectangle(0, 0, float32(menuBG.Width), float32(menuBG.Height))
-				dst := rl.NewRectangle(0, 0, float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight()))
+				dst := rl.NewRectangle(0, 0, sw, sh)
 				rl.DrawTexturePro(menuBG, src, dst, rl.NewVector2(0, 0), 0, rl.White)
 			} else {
 				rl.ClearBackground(rl.DarkGreen)
 			}
 
-			bw, bh := float32(320), float32(70)
-			centerX := float32(rl.GetScreenWidth()) * 0.5
-			startY := float32(rl.GetScreenHeight())*0.6 - bh
-			spacing := float32(20)
-
-			btnPlay.Bounds = rl.NewRectangle(centerX-bw/2, startY, bw, bh)
-			btnExit.Bounds = rl.NewRectangle(centerX-bw/2, startY+bh+spacing, bw, bh)
+			menuView.Update(ui.PollInput(mouse))
+			menuView.Draw()
+			DrawCursor(mouse)
 
-			mx, my := float32(rl.GetMouseX()), float32(rl.GetMouseY())
-			btnPlay.Hot = rl.CheckCollisionPointRec(rl.NewVector2(mx, my), btnPlay.Bounds)
-			btnExit.Hot = rl.CheckCollisionPointRec(rl.NewVector2(mx, my), btnExit.Bounds)
+		case StateGame:
+			// стоп-кадр: мир стоит, кадр рисуется
+			if hitStop > 0 {
+				hitStop -= dt
+				dt = 0
+			}
 
-			if rl.IsMouseButtonPressed(rl.MouseLeftButton) || rl.IsKeyPressed(rl.KeyEnter) {
-				if btnPlay.Hot || rl.IsKeyPressed(rl.KeyEnter) {
-					startGame()
-				}
+			// Зум колесом (пределы и «мир не уже экрана» — внутри camera)
+			view.SetViewport(sw, sh)
+			if wheel := rl.GetMouseWheelMove(); wheel != 0 {
+				view.ZoomBy(wheel*0.05/worldTexel, 0.12)
 			}
-			if (rl.IsMouseButtonPressed(rl.MouseLeftButton) && btnExit.Hot) || rl.IsKeyPressed(rl.KeyEscape) {
-				if hasMenuMusic {
-					rl.StopMusicStream(menuMusic)
+
+			// Update
+			runTime += dt
+			if bossDef != nil && !bossSpawned {
+				at := bossDef.SpawnAt
+				if curLevel.BossAt > 0 {
+					at = curLevel.BossAt
 				}
-				if hasGameMusic {
-					rl.StopMusicStream(gameMusic)
+				if runTime >= at {
+					bossSpawned = true
+					spawnBoss(bossDef)
 				}
-				rl.EndDrawing()
-				return
 			}
 
-			title := "666adididas"
-			ts := rl.MeasureTextEx(uiFont, title, 48, uiSpacing)
-			tx := float32(rl.GetScreenWidth())*0.5 - ts.X*0.5
-			ty := float32(rl.GetScreenHeight()) * 0.25
-			rl.DrawTextEx(uiFont, title, rl.NewVector2(tx, ty), 48, uiSpacing, rl.White)
-
-			btnPlay.Draw()
-			btnExit.Draw()
-
-			hint := "Enter — Играть, Esc — Выйти"
-			hs := rl.MeasureTextEx(uiFont, hint, 20, uiSpacing)
-			rl.DrawTextEx(uiFont, hint, rl.NewVector2(20, float32(rl.GetScreenHeight())-hs.Y-20), 20, uiSpacing, rl.White)
-			DrawCursor()
-
-		case StateGame:
-			// Зум колесом + ограничение, чтобы мир не был уже экрана
-			cam.Zoom += rl.GetMouseWheelMove() * 0.05
-			if cam.Zoom < 0.3 {
-				cam.Zoom = 0.3
+			// прицел: курсор или правый стик — в обоих случаях точка на экране,
+			// которую переводим в мир одинаково
+			var targets []rl.Vector2
+			if ctl.UsingPad() {
+				for _, e := range enemies {
+					if e.Alive {
+						cx, cy := e.Center()
+						targets = append(targets, rl.GetWorldToScreen2D(rl.NewVector2(cx, cy), cam))
+					}
+				}
 			}
-			if cam.Zoom > 3.0 {
-				cam.Zoom = 3.0
+			aimScreen := ctl.Aim(rl.GetWorldToScreen2D(rl.NewVector2(player.X, player.Y), cam), targets)
+			aim := rl.GetScreenToWorld2D(aimScreen, cam)
+
+			px, py := player.X, player.Y
+			wasDashing := player.DashTimer > 0
+			moveX, moveY := ctl.Move()
+			player.Update(dt, entities.PlayerInput{
+				MoveX: moveX, MoveY: moveY,
+				AimX: aim.X, AimY: aim.Y,
+				Fire: ctl.Down(input.Fire),
+				Dash: ctl.Pressed(input.Dash),
+			})
+			if !wasDashing && player.DashTimer > 0 {
+				fxSys.Emit("dash_burst", px, py)
 			}
-			wpx, hpx := wrld.SizePx()
-			fitCameraToWorld(&cam, wpx, hpx)
-
-			// Update
-			player.Update(dt, &cam)
+			player.X, player.Y = navGrid.Slide(px, py, player.X, player.Y)
 			player.X, player.Y = wrld.Clamp(player.X, player.Y)
+			if trail != nil && dt > 0 && player.Speed > 0 {
+				// след тем гуще, чем быстрее бежит игрок
+				trail.RateScale = float32(math.Hypot(float64(player.X-px), float64(player.Y-py))) / dt / player.Speed
+			}
+
+			// подгружаем чанки вокруг камеры; окно навигации — за игроком
+			vx, vy, vw, vh := view.Visible()
+			wrld.Update(rl.NewRectangle(vx, vy, vw, vh))
+			spawnObjects()
+			if wrld.Streamed() {
+				quarter := float64(navWindow*navCell) / 4
+				if wrld.Version() != navVer || math.Abs(float64(player.X-navX)) > quarter || math.Abs(float64(player.Y-navY)) > quarter {
+					buildNav(player.X, player.Y)
+				}
+			}
 
 			for _, e := range enemies {
 				e.X, e.Y = wrld.Clamp(e.X, e.Y)
-				if e.CanShoot {
-					out := e.Shots[:0]
-					for _, p := range e.Shots {
-						if p.X < 0 || p.Y < 0 || p.X > wpx || p.Y > hpx {
+				out := e.Shots[:0]
+				for _, p := range e.Shots {
+					if !wrld.Contains(p.X, p.Y) {
+						p.Alive = false
+					}
+					// непроходимые объекты закрывают от пуль
+					for _, o := range objects {
+						if o.Alive && o.Def.Solid && rl.CheckCollisionPointRec(rl.NewVector2(p.X, p.Y), o.Bounds()) {
 							p.Alive = false
+							o.TakeDamage(p.Damage)
+							break
 						}
-						if p.Alive {
-							out = append(out, p)
-						}
 					}
-					e.Shots = out
+					if p.Alive {
+						out = append(out, p)
+					}
 				}
+				e.Shots = out
 			}
 
-			// === ПОДБОР ДУШ ===
-			outSouls := souls[:0]
-			for _, s := range souls {
-				if !s.Alive {
-					continue
+			// === ПОДБОР ПРЕДМЕТОВ ===
+			// в радиусе магнита предмет сам летит к игроку, а засчитывается,
+			// когда долетит
+			magnet := false
+			outPickups := pickups[:0]
+			for _, p := range pickups {
+				dx, dy := p.X-player.X, p.Y-player.Y
+				if !p.Absorbing && dx*dx+dy*dy <= player.Magnet*player.Magnet {
+					p.Attract()
 				}
-
-				dx := s.X - player.X
-				dy := s.Y - player.Y
-				distSq := dx*dx + dy*dy
-				pickupRadius := float32(80 * player.Scale)
-
-				// Если игрок касается души
-				if !s.IsAbsorbing && distSq <= pickupRadius*pickupRadius {
-					s.IsAbsorbing = true
-					// увеличим счётчик только когда душа долетит до центра:
-					// player.Souls++ ← добавим при исчезновении ниже
+				p.Update(dt, player.X, player.Y)
+				if p.TakeCollected() {
+					d := p.Def
+					switch d.Kind {
+					case entities.PickupSoul:
+						player.Souls += d.Amount
+						player.Ult.AddSouls(d.Amount)
+						run.SoulsCollected += d.Amount
+					case entities.PickupHealth:
+						player.Heal(d.Amount)
+					case entities.PickupGold:
+						player.Gold += d.Amount
+						run.Gold += d.Amount
+					case entities.PickupPower:
+						player.AddBuff(d.Power, d.Mult, d.Duration)
+					case entities.PickupMagnet:
+						magnet = true
+					case entities.PickupBomb:
+						hazards = append(hazards, &entities.Telegraph{X: player.X, Y: player.Y, Radius: d.Radius, Delay: 0.3, Damage: d.Damage, HitsAll: true, Friendly: true})
+					}
+					if d.Sound != "" {
+						sound.Play(d.Sound)
+					}
+					if d.FX != "" {
+						fxSys.Emit(d.FX, player.X, player.Y)
+					}
+				} else if !p.Alive && p.Def.Kind == entities.PickupSoul {
+					run.SoulsExpired += p.Def.Amount
 				}
-
-				// обновляем
-				s.Update(dt, player.X, player.Y)
-
-				// если душа исчезла и она притягивалась — засчитываем игроку
-				if !s.Alive && s.IsAbsorbing {
-					player.Souls++
-					player.Ult.AddSouls(1)
-					continue
+				// у бесконечного мира брошенное вдали пропадает вместе с чанком
+				if p.Alive && (!wrld.Streamed() || wrld.Contains(p.X, p.Y)) {
+					outPickups = append(outPickups, p)
 				}
-
-				if s.Alive {
-					outSouls = append(outSouls, s)
+			}
+			pickups = outPickups
+			// магнит тянет к игроку все души на уровне
+			if magnet {
+				for _, p := range pickups {
+					if p.Def.Kind == entities.PickupSoul {
+						p.Attract()
+					}
 				}
 			}
-			souls = outSouls
 
 			// === обновление крюка ===
 			if player.Crook != nil && player.Crook.Active {
-				player.Crook.Update(dt, player.X, player.Y, souls)
+				player.Crook.Update(dt, player.X, player.Y, pickups)
+				if player.Crook.TakeHit() {
+					run.CrookHits++
+				}
 			} else {
 				// если крюк завершил — начинаем откат
 				if player.Crook != nil && !player.Crook.Active && !player.CrookReady {
@@ -555,13 +1289,11 @@ func main() {
 				}
 			}
 
-			if rl.IsKeyPressed(rl.KeyQ) && player.CrookReady {
-				mouse := rl.GetMousePosition()
-				world := rl.GetScreenToWorld2D(mouse, cam)
-
-				player.Crook = entities.NewCrook(assetsRoot, player.X, player.Y, world.X, world.Y)
+			if ctl.Pressed(input.Crook) && player.CrookReady {
+				player.Crook = entities.NewCrook(assetsRoot, player.X, player.Y, aim.X, aim.Y)
 				player.CrookReady = false
 				player.CrookTimer = 0
+				run.CrookThrows++
 
 				imgPath := filepath.Join(assetsRoot, "pictures", "headshot.png")
 				img := rl.LoadImage(imgPath)
@@ -571,11 +1303,30 @@ func main() {
 
 				// проигрываем анимацию броска
 				player.A.Play(player.CrookThrow, false)
-				rl.PlaySound(player.Crook.SndThrow)
+				sound.PlayAt("crook_throw", player.X, player.Y)
 			}
 
-			if rl.IsKeyPressed(rl.KeyE) {
-				player.Ult.TryActivate(player, enemies)
+			if ctl.Pressed(input.Ult) {
+				// урон ульты (nova) — по разнице здоровья врагов
+				hpBefore := 0
+				for _, e := range enemies {
+					hpBefore += e.HP
+				}
+				if player.Ult.TryActivate(player, enemies) {
+					run.Ults++
+					hpAfter := 0
+					for _, e := range enemies {
+						hpAfter += e.HP
+					}
+					run.Deal("ult", hpBefore-hpAfter)
+					fxSys.Emit("ult_"+player.Ult.Kind, player.X, player.Y)
+					for _, e := range enemies {
+						if e.Alive && e.FreezeTimer > 0 {
+							cx, cy := e.Center()
+							fxSys.Emit("frost", cx, cy)
+						}
+					}
+				}
 			}
 			player.Ult.Update(dt, enemies)
 
@@ -610,7 +1361,14 @@ func main() {
 						player.PrevX, player.PrevY, player.X, player.Y,
 					)
 					if d2 <= r*r {
-						player.TakeDamage(shot.Damage)
+						hp := player.HP
+						hurt(player, shot.Damage, e.Kind)
+						if player.HP < hp {
+							e.OnDealtDamage(hp - player.HP)
+							player.Impulse(shot.VX*150, shot.VY*150)
+							fxSys.EmitDir("player_hit", shot.X, shot.Y, shot.VX, shot.VY)
+							shake(0.2)
+						}
 						shot.Alive = false
 					}
 				}
@@ -618,15 +1376,24 @@ func main() {
 
 			// 2) Контактный урон ближника
 			for _, e := range enemies {
-				if e.Kind == "melee" {
+				if e.Alive && (e.Kind == "melee" || e.Kind == entities.BossKind) {
 					dx := e.X - player.X
 					dy := e.Y - player.Y
 					r := player.Radius + e.MeleeRange
 					if dx*dx+dy*dy <= r*r {
 						// удар, если таймер атаки врага готов и игрок не в инвулне
 						if e.AttackTimer <= 0 && player.InvulnTimer <= 0 {
-							player.TakeDamage(e.ContactDamage)
+							hp := player.HP
+							hurt(player, e.ContactDamage, e.Kind)
+							e.OnDealtDamage(hp - player.HP)
 							e.AttackTimer = e.AttackCD
+
+							// отбрасываем игрока от врага
+							if d := float32(math.Hypot(float64(dx), float64(dy))); d > 0.001 {
+								player.Impulse(-dx/d*420, -dy/d*420)
+							}
+							hitStop = max(hitStop, 0.06)
+							shake(0.3)
 						}
 					}
 				}
@@ -634,22 +1401,23 @@ func main() {
 
 			// 3) Если здоровье закончилось — простая «смерть» -> выход в меню
 			if player.HP <= 0 {
-				if hasGameMusic {
-					rl.StopMusicStream(gameMusic)
-				}
-				if hasMenuMusic {
-					rl.PlayMusicStream(menuMusic)
-				}
+				endRun(false)
+				sound.PlayMusic("menu", 1.5)
+				showResults(false, tr.T("results.time", clock(runTime)))
 				state = StateDefeat
+				// кадр не показываем, но канву закрываем: итоги рисуются со следующего
+				cnv.End()
 				continue
 			}
 
 			// 4) Попадание пуль игрока во врагов
+			dmgMult := player.BuffMult(entities.BuffDamage)
 			outShots := player.Shots[:0]
 			for _, shot := range player.Shots {
 				if !shot.Alive {
 					continue
 				}
+				dmg := int(float32(shot.Damage) * dmgMult)
 				hit := false
 
 				for _, e := range enemies {
@@ -684,18 +1452,26 @@ func main() {
 					// --- проверка пересечения сегмента пули с окружностью врага
 					if segmentCircleHit(shot.PrevX, shot.PrevY, shot.X, shot.Y, cx, cy, enemyRadius+shot.HitRadius) {
 						// попадание
+						if rng.Float32() < e.ReflectChance() {
+							e.Reflect(shot, player.X, player.Y)
+							hit = true
+							break
+						}
 						shot.Alive = false
 
 						wasAlive := e.Alive
-						e.TakeDamage(20) // <- подбери урон по вкусу / по типу оружия
+						hurt(e, dmg, "shot")
+						run.ShotsHit++
+						e.Impulse(shot.VX*shot.Knockback, shot.VY*shot.Knockback)
+						fxSys.EmitDir("hit_spark", shot.X, shot.Y, -shot.VX, -shot.VY)
+						sound.PlayAt("enemy_hit", shot.X, shot.Y)
 
-						// если ты НЕ делал вызов NewSoul внутри TakeDamage, создаём душу здесь
 						if wasAlive && !e.Alive {
-							if s, err := entities.NewSoul(assetsRoot, cx, cy); err == nil {
-								souls = append(souls, s)
+							if e.Elite || e.Kind == entities.BossKind {
+								hitStop = max(hitStop, 0.12)
+								shake(0.4)
 							} else {
-								// лог в консоль, но не фэйлим игру
-								fmt.Println("soul spawn:", err)
+								hitStop = max(hitStop, 0.03)
 							}
 						}
 
@@ -704,197 +1480,375 @@ func main() {
 					}
 				}
 
+				// ломаем ящики и бочки; прочие непроходимые объекты просто ловят пулю
+				for _, o := range objects {
+					if hit || !o.Alive || (!o.Def.Solid && !o.Breakable()) {
+						continue
+					}
+					cx, cy, r := o.Center()
+					if segmentCircleHit(shot.PrevX, shot.PrevY, shot.X, shot.Y, cx, cy, r+shot.HitRadius) {
+						shot.Alive = false
+						hit = true
+						if o.Breakable() {
+							o.TakeDamage(dmg)
+							fxSys.EmitDir("hit_spark", shot.X, shot.Y, -shot.VX, -shot.VY)
+						}
+					}
+				}
+
 				if !hit && shot.Alive {
 					outShots = append(outShots, shot)
 				}
 			}
 			player.Shots = outShots
 
-			spawnT -= dt
-			if spawnT <= 0 {
+			for n := director.Update(dt); n > 0; n-- {
 				spawnEnemy()
-				spawnT = spawnEvery
 			}
+			pvx, pvy := player.Velocity(dt)
+			target := entities.Target{X: player.X, Y: player.Y, VX: pvx, VY: pvy}
+			navField.Update(player.X, player.Y)
 			for _, e := range enemies {
-				e.Update(dt, player.X, player.Y)
-				outEnemies := enemies[:0]
-				for _, e := range enemies {
-					if e.Alive {
-						outEnemies = append(outEnemies, e)
-					}
+				switch d := rl.Vector2Distance(rl.NewVector2(e.X, e.Y), rl.NewVector2(player.X, player.Y)); {
+				case d <= simNear && navGrid.Contains(e.X, e.Y):
+					e.Update(dt, target)
+				case d <= simFar:
+					e.UpdateFar(dt, target)
+				}
+				e.X, e.Y = wrld.Clamp(e.X, e.Y)
+			}
+			// души и посмертные эффекты — по событию в анимации смерти
+			for _, e := range enemies {
+				if e.TakeDeathDrop() {
+					cx, cy := e.Center()
+					onEnemyDeath(e, cx, cy)
 				}
-				enemies = outEnemies
 			}
-			for _, s := range souls {
-				s.Update(dt, player.X, player.Y)
+			// убираем исчезнувших и ушедших дальше simFar (кроме босса)
+			outEnemies := enemies[:0]
+			for _, e := range enemies {
+				far := rl.Vector2Distance(rl.NewVector2(e.X, e.Y), rl.NewVector2(player.X, player.Y)) > simFar
+				if !e.Removed() && (!far || boss != nil && e == boss.Enemy) {
+					outEnemies = append(outEnemies, e)
+				}
+			}
+			clear(enemies[len(outEnemies):])
+			enemies = outEnemies
+
+			// Босс: призывы, взрывы по области, смерть
+			if boss != nil {
+				for _, sm := range boss.DrainSummons() {
+					// скорость и масштаб — из призыва, иначе как у этого вида в уровне
+					def, _ := curLevel.Enemy(sm.Kind)
+					if sm.Speed > 0 {
+						def.Speed = sm.Speed
+					}
+					if sm.Scale > 0 {
+						def.Scale = sm.Scale
+					}
+					if def.Speed <= 0 {
+						fmt.Printf("summon: %q нет в уровне %s, а у призыва нет speed\n", sm.Kind, curLevel.ID)
+						continue
+					}
+					if def.Scale <= 0 {
+						def.Scale = 1
+					}
+					x, y, _ := navGrid.Nearest(wrld.Clamp(sm.X, sm.Y))
+					if e, err := entities.NewEnemyKind(assetsRoot, sm.Kind, x, y, def.Speed, def.Scale); err == nil {
+						enemies = append(enemies, e)
+					} else {
+						fmt.Println("summon:", err)
+					}
+				}
+				for _, bl := range boss.DrainBlasts() {
+					fxSys.Emit("explosion", bl.X, bl.Y)
+					shake(0.35)
+					dx := player.X - bl.X
+					dy := player.Y - bl.Y
+					r := bl.Radius + player.Radius
+					if dx*dx+dy*dy <= r*r {
+						hurt(player, bl.Damage, entities.BossKind)
+						if d := float32(math.Hypot(float64(dx), float64(dy))); d > 0.001 {
+							player.Impulse(dx/d*600, dy/d*600)
+						}
+						hitStop = max(hitStop, 0.08)
+					}
+				}
+				if !boss.Alive {
+					boss = nil
+					bossDead = true
+				}
 			}
 
-			// Камера
-			cam.Target = rl.NewVector2(player.X, player.Y)
-			halfW := (float32(rl.GetScreenWidth()) / 2) / cam.Zoom
-			halfH := (float32(rl.GetScreenHeight()) / 2) / cam.Zoom
-			cx, cy := cam.Target.X, cam.Target.Y
-			if cx < halfW {
-				cx = halfW
+			// Посмертные взрывы элит
+			outHaz := hazards[:0]
+			for _, h := range hazards {
+				if h.Update(dt) {
+					fxSys.Emit("explosion", h.X, h.Y)
+					shake(0.3)
+					src := "explosion"
+					switch {
+					case h.Friendly:
+						src = "bomb"
+					case h.HitsAll:
+						src = "barrel"
+					}
+					dx := player.X - h.X
+					dy := player.Y - h.Y
+					r := h.Radius + player.Radius
+					if !h.Friendly && dx*dx+dy*dy <= r*r {
+						hurt(player, h.Damage, src)
+						if d := float32(math.Hypot(float64(dx), float64(dy))); d > 0.001 {
+							player.Impulse(dx/d*500, dy/d*500)
+						}
+					}
+					if h.HitsAll {
+						forTargets(func(t entities.Damageable, x, y, r float32) {
+							if t == entities.Damageable(player) {
+								return
+							}
+							if dx, dy := x-h.X, y-h.Y; dx*dx+dy*dy <= (h.Radius+r)*(h.Radius+r) {
+								hurt(t, h.Damage, src)
+							}
+						})
+					}
+					continue
+				}
+				outHaz = append(outHaz, h)
 			}
-			if cy < halfH {
-				cy = halfH
+			hazards = outHaz
+
+			// Объекты мира
+			pfx, pfy := player.Foot()
+			// remember — сохранить состояние объекта бесконечного мира
+			remember := func(o *entities.Object, st world.ObjectState) {
+				if k, ok := objKeys[o]; ok {
+					wrld.SetObjectState(k, st)
+				}
 			}
-			if cx > wpx-halfW {
-				cx = wpx - halfW
+			outObj := objects[:0]
+			for _, o := range objects {
+				o.Update(dt)
+				switch o.Def.Behavior {
+				case entities.ObjSpikes:
+					if o.Striking() {
+						forTargets(func(t entities.Damageable, x, y, r float32) {
+							if o.Touches(x, y, 0) {
+								hurt(t, o.Def.Damage, "spikes")
+							}
+						})
+					}
+				case entities.ObjShrine:
+					if o.Ready() && player.HP < player.MaxHP && o.Touches(pfx, pfy, player.Radius) {
+						o.Use()
+						remember(o, world.ObjectState{Ready: runTime + o.Def.Cooldown})
+						player.Heal(o.Def.Heal)
+						fxSys.Emit("dash_burst", o.X, o.Y)
+						sound.PlayAt("shrine", o.X, o.Y)
+					}
+				case entities.ObjTeleport:
+					if l := o.Link; o.Enter(o.Touches(pfx, pfy, 0)) && l != nil && l.Alive {
+						o.Use()
+						l.Arrive()
+						fxSys.Emit("dash_burst", player.X, player.Y)
+						player.X, player.Y = l.X, l.Y-(pfy-player.Y)
+						player.PrevX, player.PrevY = player.X, player.Y
+						fxSys.Emit("dash_burst", player.X, player.Y)
+						sound.Play("teleport")
+						view.Follow(player.X, player.Y)
+						view.Snap()
+					}
+				}
+				if o.TakeBreak() {
+					remember(o, world.ObjectState{Broken: true})
+					blockObject(o, false)
+					cx, cy, _ := o.Center()
+					fxSys.Emit("death_burst", cx, cy)
+					dropLoot(cx, cy, o.Def.Loot)
+					if o.Def.Behavior == entities.ObjExplosive {
+						hazards = append(hazards, &entities.Telegraph{X: o.X, Y: o.Y, Radius: o.Def.Radius, Delay: 0.35, Damage: o.Def.Damage, HitsAll: true})
+					}
+				}
+				// у бесконечного мира объекты уходят вместе со своим чанком
+				if o.Alive && (!wrld.Streamed() || wrld.Contains(o.X, o.Y)) {
+					outObj = append(outObj, o)
+				} else {
+					delete(objKeys, o)
+				}
 			}
-			if cy > hpx-halfH {
-				cy = hpx - halfH
+			clear(objects[len(outObj):])
+			objects = outObj
+
+			// Победа: уровень пройден — в прогресс и на экран итогов
+			if curLevel.Win.Done(runTime, bossDead) {
+				key := "results.time"
+				if progress.Clear(curLevel.ID, runTime) {
+					key = "results.record"
+				}
+				saveProgress()
+				endRun(true)
+				showResults(true, tr.T(key, clock(runTime)))
+				sound.PlayMusic("menu", 1.5)
+				state = StateVictory
 			}
-			cam.Target = rl.NewVector2(cx, cy)
 
-			// Рисование мира и объектов
-			rl.BeginMode2D(cam)
-			wrld.Draw(cam)
-			for _, e := range enemies {
-				e.Draw()
-			}
-			for _, s := range souls {
-				s.Draw()
+			// Интенсивность для адаптивной музыки
+			if player.HP < lastHP {
+				recentDmg += float32(lastHP - player.HP)
 			}
-			player.Draw(cam)
-			rl.EndMode2D()
-			if ultHUD != nil {
-				ultHUD.Draw(player.Ult.Charge)
+			lastHP = player.HP
+			recentDmg = audio.FadeDamage(recentDmg, dt)
+			near := 0
+			for _, e := range enemies {
+				dx, dy := e.X-player.X, e.Y-player.Y
+				if e.Alive && dx*dx+dy*dy <= intensityRadius*intensityRadius {
+					near++
+				}
 			}
+			sound.SetIntensity(audio.Intensity(audio.Combat{
+				Enemies:      near,
+				RecentDamage: recentDmg,
+				HP:           player.HP,
+				MaxHP:        player.MaxHP,
+				Boss:         boss != nil,
+			}))
+
+			fxSys.Update(dt)
+
+			// Камера: следует за игроком с упреждением к курсору; трясётся и в стоп-кадре
+			view.Follow(player.X, player.Y)
+			view.Aim(aim.X, aim.Y)
+			view.Update(rl.GetFrameTime())
+			cam = rlCamera(view)
+			// слушаем из центра кадра; на краю экрана звук целиком в одном ухе
+			lx, ly := view.Target()
+			sound.SetListener(lx, ly, view.ViewW/2/view.Zoom)
 
+			// Рисование мира и объектов
+			rl.BeginMode2D(cam)
+			submitScene()
 			// HUD
+			if ultHUD != nil {
+				scene.Submit(render.LayerScreen, 0, func() { ultHUD.Draw(player.Ult.Charge) })
+			}
+			if boss != nil {
+				b := boss
+				scene.Submit(render.LayerScreen, 0, func() {
+					bossBar.Draw(tr.T(b.Def.Name), b.HP, b.MaxHP, b.Thresholds(), b.Phase, b.Invuln > 0)
+				})
+			}
 			if hud != nil {
-				hud.Draw(player.HP)
+				scene.Submit(render.LayerScreen, 0, func() { hud.Draw(player.HP) })
+			}
+			scene.Flush(rl.EndMode2D)
+
+			key := func(a input.Action) string { return bindingName(tr, int32(ctl.Profile.Slot(a, 0))) }
+			helpText := tr.T("hud.help",
+				key(input.Fire), key(input.Crook), key(input.Ult), key(input.Dash), key(input.Pause))
+			hs := rl.MeasureTextEx(uiFont, helpText, uiHint, uiSpacing)
+			rl.DrawTextEx(uiFont, helpText, rl.NewVector2(6, sh-hs.Y-6), uiHint, uiSpacing, rl.DarkGray)
+			// золото и действующие усиления — над подсказкой
+			purse := tr.T("hud.gold", player.Gold)
+			for _, b := range player.Buffs {
+				purse += "   " + tr.T("hud.buff", tr.T("buff."+b.Kind), int(math.Ceil(float64(b.Left))))
+			}
+			gs := rl.MeasureTextEx(uiFont, purse, uiHint*1.5, uiSpacing)
+			rl.DrawTextEx(uiFont, purse, rl.NewVector2(6, sh-hs.Y-gs.Y-8), uiHint*1.5, uiSpacing, rl.Gold)
+			fps := fmt.Sprintf("%d FPS", rl.GetFPS())
+			fs := rl.MeasureTextEx(uiFont, fps, uiHint, uiSpacing)
+			rl.DrawTextEx(uiFont, fps, rl.NewVector2(sw-fs.X-6, sh-fs.Y-6), uiHint, uiSpacing, rl.DarkGray)
+			// цель уровня: сколько ещё продержаться (над FPS — верх занят HUD и боссом)
+			if curLevel.Win.Kind == level.WinSurvive {
+				left := clock(max(curLevel.Win.Time-runTime, 0))
+				ls := rl.MeasureTextEx(uiFont, left, uiHint*1.5, uiSpacing)
+				rl.DrawTextEx(uiFont, left, rl.NewVector2(sw-ls.X-6, sh-fs.Y-ls.Y-8), uiHint*1.5, uiSpacing, rl.DarkGray)
 			}
-
-			helpText := "Move: WASD/Arrows  |  Fullscreen: F11  |  Zoom: Wheel  |  Esc: пауза"
-			rl.DrawTextEx(uiFont, helpText, rl.NewVector2(20, 20), 20, uiSpacing, rl.DarkGray)
-
-			rl.DrawTextEx(uiFont, helpText, rl.NewVector2(20, 20), 20, uiSpacing, rl.DarkGray)
-			rl.DrawFPS(int32(rl.GetScreenWidth())-90, 10)
 
 			// Пауза
-			if rl.IsKeyPressed(rl.KeyEscape) {
-				if hasGameMusic {
-					rl.PauseMusicStream(gameMusic)
-				}
+			if ctl.Pressed(input.Pause) {
+				sound.PlayMusic("pause", 0.3)
+				pauseView.Reset()
 				state = StatePause
 			}
 
-			DrawCursor()
+			DrawCursor(aimScreen)
 
 		case StatePause:
 			// Фон замороженной игры
 
 			rl.BeginMode2D(cam)
-			wrld.Draw(cam)
-			for _, e := range enemies {
-				e.Draw()
-			}
-			if player != nil {
-				player.Draw(cam)
-			}
-			rl.EndMode2D()
+			submitScene()
+			scene.Flush(rl.EndMode2D)
 
 			// Вуаль
-			rl.DrawRectangle(0, 0, int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()),
+			rl.DrawRectangle(0, 0, int32(sw), int32(sh),
 				rl.NewColor(0, 0, 0, 160))
-			// Заголовок
-			title := "Пауза"
-			ts := rl.MeasureTextEx(uiFont, title, 48, uiSpacing)
-			tx := float32(rl.GetScreenWidth())*0.5 - ts.X*0.5
-			ty := float32(rl.GetScreenHeight()) * 0.28
-			rl.DrawTextEx(uiFont, title, rl.NewVector2(tx, ty), 48, uiSpacing, rl.White)
-
-			// Кнопки
-			bw, bh := float32(360), float32(74)
-			centerX := float32(rl.GetScreenWidth()) * 0.5
-			startY := float32(rl.GetScreenHeight())*0.45 - bh
-			spacing := float32(22)
-			btnResume.Bounds = rl.NewRectangle(centerX-bw/2, startY, bw, bh)
-			btnToMenu.Bounds = rl.NewRectangle(centerX-bw/2, startY+bh+spacing, bw, bh)
-
-			mx, my := float32(rl.GetMouseX()), float32(rl.GetMouseY())
-			btnResume.Hot = rl.CheckCollisionPointRec(rl.NewVector2(mx, my), btnResume.Bounds)
-			btnToMenu.Hot = rl.CheckCollisionPointRec(rl.NewVector2(mx, my), btnToMenu.Bounds)
-
-			btnResume.Draw()
-			btnToMenu.Draw()
-
-			// Управление
-			if rl.IsKeyPressed(rl.KeyEscape) || rl.IsKeyPressed(rl.KeyEnter) ||
-				(rl.IsMouseButtonPressed(rl.MouseLeftButton) && btnResume.Hot) {
-				if hasGameMusic {
-					rl.ResumeMusicStream(gameMusic)
-				}
-				state = StateGame
-			}
-			if rl.IsMouseButtonPressed(rl.MouseLeftButton) && btnToMenu.Hot {
-				if hasGameMusic {
-					rl.StopMusicStream(gameMusic)
-				}
-				if hasMenuMusic {
-					rl.PlayMusicStream(menuMusic)
-				}
-				state = StateMenu
-			}
-
-			hint := "Enter/Esc — продолжить, ЛКМ — выбрать"
-			hs := rl.MeasureTextEx(uiFont, hint, 20, uiSpacing)
-			rl.DrawTextEx(uiFont, hint, rl.NewVector2(20, float32(rl.GetScreenHeight())-hs.Y-20), 20, uiSpacing, rl.White)
-			DrawCursor()
+			pauseView.Update(ui.PollInput(mouse))
+			pauseView.Draw()
+			DrawCursor(mouse)
 
 		case StateDefeat:
 			// фон
 			if defeatBG.ID != 0 {
 				src := rl.NewRectangle(0, 0, float32(defeatBG.Width), float32(defeatBG.Height))
-				dst := rl.NewRectangle(0, 0, float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight()))
+				dst := rl.NewRectangle(0, 0, sw, sh)
 				rl.DrawTexturePro(defeatBG, src, dst, rl.NewVector2(0, 0), 0, rl.White)
 			} else {
 				rl.ClearBackground(rl.DarkGreen)
 			}
 
-			// кнопки
-			bw, bh := float32(380), float32(78)
-			cx := float32(rl.GetScreenWidth()) * 0.5
-			y0 := float32(rl.GetScreenHeight())*0.60 - bh
-			sp := float32(24)
-
-			btnRestart.Bounds = rl.NewRectangle(cx-bw/2, y0, bw, bh)
-			btnQuit.Bounds = rl.NewRectangle(cx-bw/2, y0+bh+sp, bw, bh)
-
-			mx, my := float32(rl.GetMouseX()), float32(rl.GetMouseY())
-			btnRestart.Hot = rl.CheckCollisionPointRec(rl.NewVector2(mx, my), btnRestart.Bounds)
-			btnQuit.Hot = rl.CheckCollisionPointRec(rl.NewVector2(mx, my), btnQuit.Bounds)
+			results.Update(dt)
+			results.View.Update(ui.PollInput(mouse))
+			results.View.Draw()
+			DrawCursor(mouse)
 
-			btnRestart.Draw()
-			btnQuit.Draw()
-
-			if rl.IsKeyPressed(rl.KeyEnter) || (rl.IsMouseButtonPressed(rl.MouseLeftButton) && btnRestart.Hot) {
-				if hasMenuMusic {
-					rl.StopMusicStream(menuMusic)
-				}
-				if hasGameMusic {
-					rl.PlayMusicStream(gameMusic)
-				}
-				startGame()
-				break
+		case StateLevels, StateVictory, StateShop:
+			if menuBG.ID != 0 {
+				src := rl.NewRectangle(0, 0, float32(menuBG.Width), float32(menuBG.Height))
+				dst := rl.NewRectangle(0, 0, sw, sh)
+				rl.DrawTexturePro(menuBG, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+			} else {
+				rl.ClearBackground(rl.DarkGreen)
 			}
-			if rl.IsKeyPressed(rl.KeyEscape) || (rl.IsMouseButtonPressed(rl.MouseLeftButton) && btnQuit.Hot) {
-				if hasMenuMusic {
-					rl.StopMusicStream(menuMusic)
-				}
-				if hasGameMusic {
-					rl.StopMusicStream(gameMusic)
-				}
-				rl.EndDrawing()
-				return
+			v := levelsView
+			switch state {
+			case StateVictory:
+				results.Update(dt)
+				v = results.View
+			case StateShop:
+				v = shopView
 			}
-			DrawCursor()
+			v.Update(ui.PollInput(mouse))
+			v.Draw()
+			DrawCursor(mouse)
+
+		case StateSettings, StateControls:
+			// фон того экрана, откуда пришли
+			if settingsFrom == StatePause {
+				rl.BeginMode2D(cam)
+				submitScene()
+				scene.Flush(rl.EndMode2D)
+			} else if menuBG.ID != 0 {
+				src := rl.NewRectangle(0, 0, float32(menuBG.Width), float32(menuBG.Height))
+				dst := rl.NewRectangle(0, 0, sw, sh)
+				rl.DrawTexturePro(menuBG, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+			}
+			rl.DrawRectangle(0, 0, int32(sw), int32(sh),
+				rl.NewColor(0, 0, 0, 160))
 
+			v := settingsView
+			if state == StateControls {
+				v = controlsView
+			}
+			v.Update(ui.PollInput(mouse))
+			v.Draw()
+			DrawCursor(mouse)
 		}
 
+		cnv.End()
+
+		rl.BeginDrawing()
+		cnv.Present(rl.Black)
 		rl.EndDrawing()
 	}
 }
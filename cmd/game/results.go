@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"example.com/my2dgame/internal/i18n"
+	"example.com/my2dgame/internal/stats"
+	"example.com/my2dgame/internal/ui"
+)
+
+// Анимация итогов: строки проявляются по очереди, числа в них набегают
+const (
+	resultsRowDelay = 0.15 // секунд между строками
+	resultsCount    = 0.6  // секунд набегания чисел
+)
+
+// resultRow — строка итогов; text получает долю k ∈ [0, 1] набегания
+type resultRow struct {
+	label *ui.Label
+	text  func(k float32) string
+}
+
+// resultsScreen — экран итогов забега (поражение или победа)
+type resultsScreen struct {
+	View *ui.View
+	rows []resultRow
+	t    float32
+}
+
+// newResultsScreen — итоги забега run: заголовок, время (sub), разбивка
+// и кнопки; exportDir "" — выгрузки в JSON нет
+func newResultsScreen(theme *ui.Theme, tr *i18n.Bundle, run *stats.Run, title, sub, exportDir string, onBack func(), buttons ...*ui.Button) *resultsScreen {
+	r := &resultsScreen{}
+	n := func(v int, k float32) int { return int(float32(v)*k + 0.5) }
+	// breakdown — «источник 120 · источник 40»; имена — из перевода, если есть
+	breakdown := func(m map[string]int, k float32) string {
+		var parts []string
+		for _, e := range stats.Sorted(m) {
+			parts = append(parts, fmt.Sprintf("%s %d", sourceName(tr, e.Key), n(e.N, k)))
+		}
+		return strings.Join(parts, " · ")
+	}
+	row := func(hint bool, text func(k float32) string) {
+		l := ui.NewLabel(text(1))
+		if hint {
+			l.Size = theme.HintSize
+		}
+		r.rows = append(r.rows, resultRow{l, text})
+	}
+
+	row(false, func(k float32) string {
+		return tr.T("results.kills", n(stats.Total(run.Kills), k), n(run.Elites, k))
+	})
+	if len(run.Kills) > 0 {
+		row(true, func(k float32) string { return breakdown(run.Kills, k) })
+	}
+	row(false, func(k float32) string { return tr.T("results.dealt", n(stats.Total(run.Dealt), k)) })
+	if len(run.Dealt) > 0 {
+		row(true, func(k float32) string { return breakdown(run.Dealt, k) })
+	}
+	row(false, func(k float32) string { return tr.T("results.taken", n(stats.Total(run.Taken), k)) })
+	if len(run.Taken) > 0 {
+		row(true, func(k float32) string { return breakdown(run.Taken, k) })
+	}
+	row(false, func(k float32) string {
+		return tr.T("results.souls", n(run.SoulsCollected, k), n(run.SoulsExpired, k))
+	})
+	row(false, func(k float32) string { return tr.T("results.gold", n(run.Gold, k)) })
+	row(false, func(k float32) string {
+		return tr.T("results.crook", n(run.CrookHits, k), n(run.CrookThrows, k))
+	})
+	row(false, func(k float32) string { return tr.T("results.ults", n(run.Ults, k)) })
+	row(false, func(k float32) string {
+		return tr.T("results.accuracy", n(int(run.Accuracy*100), k), n(run.ShotsHit, k), n(run.ShotsFired, k))
+	})
+
+	var items []ui.Widget
+	for _, rw := range r.rows {
+		items = append(items, rw.label)
+	}
+	list := ui.NewScrollList(170, items...)
+	list.Width = 420
+
+	subLabel := ui.NewLabel(sub)
+	subLabel.Centered = true
+
+	status := ui.NewLabel("")
+	status.Size = theme.HintSize
+	status.Centered = true
+	if exportDir != "" {
+		exp := ui.NewButton(tr.T("results.export"), nil)
+		exp.OnClick = func() {
+			path, err := stats.Export(exportDir, run)
+			if err != nil {
+				fmt.Println("stats export:", err)
+				status.Text = tr.T("results.export_failed")
+				return
+			}
+			exp.Text, exp.Disabled = tr.T("results.exported"), true
+			status.Text = filepath.Base(path)
+		}
+		buttons = append(buttons, exp)
+	}
+
+	panel := ui.VBox(ui.NewTitle(title), subLabel, list, ui.HBox(asWidgets(buttons)...), status)
+	panel.Panel = true
+	panel.Padding = 10
+	panel.Gap = 6
+
+	r.View = ui.NewView(theme, panel)
+	r.View.OnBack = onBack
+	r.Update(0)
+	return r
+}
+
+// Update ведёт анимацию: строка i проявляется через i·resultsRowDelay
+func (r *resultsScreen) Update(dt float32) {
+	r.t += dt
+	for i, rw := range r.rows {
+		k := (r.t - float32(i)*resultsRowDelay) / resultsCount
+		k = min(max(k, 0), 1)
+		rw.label.Text = rw.text(k)
+		// прозрачность 0 у Label значит «цвет темы», поэтому минимум 1
+		rw.label.Color = r.View.Theme.TextDim.Color()
+		rw.label.Color.A = uint8(1 + 254*k)
+	}
+}
+
+// sourceName — имя вида врага или источника урона из перевода; нет перевода — как есть
+func sourceName(tr *i18n.Bundle, key string) string {
+	if s := tr.T("source." + key); s != "source."+key {
+		return s
+	}
+	return key
+}
+
+func asWidgets(bs []*ui.Button) []ui.Widget {
+	ws := make([]ui.Widget, len(bs))
+	for i, b := range bs {
+		ws[i] = b
+	}
+	return ws
+}
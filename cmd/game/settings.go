@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/my2dgame/internal/config"
+	"example.com/my2dgame/internal/entities"
+	"example.com/my2dgame/internal/i18n"
+	"example.com/my2dgame/internal/ui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+var (
+	windowModes = []string{config.Windowed, config.Fullscreen, config.Borderless}
+	resolutions = [][2]int{{960, 540}, {1280, 720}, {1600, 900}, {1920, 1080}, {2560, 1440}}
+	virtuals    = [][2]int{{480, 270}, {640, 360}, {960, 540}}
+	scalings    = []string{config.ScaleInteger, config.ScaleFit}
+	fpsCaps     = []int{30, 60, 120, 144, 0}
+	uiScales    = []float32{0.75, 1, 1.25, 1.5}
+
+	difficulties = []string{entities.DifficultyEasy, entities.DifficultyNormal, entities.DifficultyHard}
+)
+
+// applyDisplay приводит окно к режиму из настроек
+func applyDisplay(c config.Config) {
+	mon := rl.GetCurrentMonitor()
+	if rl.IsWindowFullscreen() && c.WindowMode != config.Fullscreen {
+		rl.ToggleFullscreen()
+	}
+	if rl.IsWindowState(rl.FlagBorderlessWindowedMode) && c.WindowMode != config.Borderless {
+		rl.ToggleBorderlessWindowed()
+	}
+	switch c.WindowMode {
+	case config.Fullscreen:
+		if !rl.IsWindowFullscreen() {
+			rl.SetWindowSize(rl.GetMonitorWidth(mon), rl.GetMonitorHeight(mon))
+			rl.ToggleFullscreen()
+		}
+	case config.Borderless:
+		if !rl.IsWindowState(rl.FlagBorderlessWindowedMode) {
+			rl.ToggleBorderlessWindowed()
+		}
+	default:
+		rl.SetWindowSize(c.Width, c.Height)
+		rl.SetWindowPosition((rl.GetMonitorWidth(mon)-c.Width)/2, (rl.GetMonitorHeight(mon)-c.Height)/2)
+	}
+	if c.VSync {
+		rl.SetWindowState(rl.FlagVsyncHint)
+	} else {
+		rl.ClearWindowState(rl.FlagVsyncHint)
+	}
+	rl.SetTargetFPS(int32(c.FPSCap))
+}
+
+func indexOf[T comparable](list []T, v T) int {
+	for i, x := range list {
+		if x == v {
+			return i
+		}
+	}
+	return 0
+}
+
+// newSettingsView — экран настроек. Изменения применяются сразу через apply(what),
+// где what — "audio", "display", "canvas", "ui", "language" или "difficulty"; onControls открывает экран управления;
+// onBack сохраняет и закрывает экран.
+func newSettingsView(theme *ui.Theme, tr *i18n.Bundle, cfg *config.Config, apply func(what string), onControls, onBack func()) *ui.View {
+	row := func(w ui.Widget) ui.Widget {
+		w.Base().Fill = true
+		return w
+	}
+	volume := func(label string, v *float32) ui.Widget {
+		return row(ui.NewSlider(label, *v, 0, 1, 0.05, func(x float32) {
+			*v = x
+			apply("audio")
+		}))
+	}
+
+	resNames := make([]string, len(resolutions))
+	resIdx := 1
+	for i, r := range resolutions {
+		resNames[i] = fmt.Sprintf("%d×%d", r[0], r[1])
+		if r[0] == cfg.Width && r[1] == cfg.Height {
+			resIdx = i
+		}
+	}
+	virtNames := make([]string, len(virtuals))
+	virtIdx := 1
+	for i, r := range virtuals {
+		virtNames[i] = fmt.Sprintf("%d×%d", r[0], r[1])
+		if r[0] == cfg.VirtualW && r[1] == cfg.VirtualH {
+			virtIdx = i
+		}
+	}
+	scalingNames := make([]string, len(scalings))
+	for i, m := range scalings {
+		scalingNames[i] = tr.T("scaling." + m)
+	}
+	fpsNames := make([]string, len(fpsCaps))
+	for i, f := range fpsCaps {
+		fpsNames[i] = fmt.Sprint(f)
+		if f == 0 {
+			fpsNames[i] = "∞"
+		}
+	}
+	modeNames := make([]string, len(windowModes))
+	for i, m := range windowModes {
+		modeNames[i] = tr.T("window." + m)
+	}
+	languages := tr.Languages()
+	langNames := make([]string, len(languages))
+	for i, code := range languages {
+		langNames[i] = tr.Name(code)
+	}
+	diffNames := make([]string, len(difficulties))
+	for i, d := range difficulties {
+		diffNames[i] = tr.T("difficulty." + d)
+	}
+	scaleNames := make([]string, len(uiScales))
+	for i, s := range uiScales {
+		scaleNames[i] = fmt.Sprintf("%d%%", int(s*100))
+	}
+
+	list := ui.NewScrollList(220,
+		volume(tr.T("settings.master"), &cfg.MasterVolume),
+		volume(tr.T("settings.music"), &cfg.MusicVolume),
+		volume(tr.T("settings.sfx"), &cfg.SFXVolume),
+		volume(tr.T("settings.ui_volume"), &cfg.UIVolume),
+		row(ui.NewDropdown(tr.T("settings.window_mode"), modeNames, indexOf(windowModes, cfg.WindowMode), func(i int) {
+			cfg.WindowMode = windowModes[i]
+			apply("display")
+		})),
+		row(ui.NewDropdown(tr.T("settings.resolution"), resNames, resIdx, func(i int) {
+			cfg.Width, cfg.Height = resolutions[i][0], resolutions[i][1]
+			apply("display")
+		})),
+		row(ui.NewDropdown(tr.T("settings.virtual"), virtNames, virtIdx, func(i int) {
+			cfg.VirtualW, cfg.VirtualH = virtuals[i][0], virtuals[i][1]
+			apply("canvas")
+		})),
+		row(ui.NewDropdown(tr.T("settings.scaling"), scalingNames, indexOf(scalings, cfg.Scaling), func(i int) {
+			cfg.Scaling = scalings[i]
+			apply("canvas")
+		})),
+		row(ui.NewToggle(tr.T("settings.vsync"), cfg.VSync, func(on bool) {
+			cfg.VSync = on
+			apply("display")
+		})),
+		row(ui.NewDropdown(tr.T("settings.fps_cap"), fpsNames, indexOf(fpsCaps, cfg.FPSCap), func(i int) {
+			cfg.FPSCap = fpsCaps[i]
+			apply("display")
+		})),
+		row(ui.NewDropdown(tr.T("settings.ui_scale"), scaleNames, indexOf(uiScales, cfg.UIScale), func(i int) {
+			cfg.UIScale = uiScales[i]
+			apply("ui")
+		})),
+		row(ui.NewDropdown(tr.T("settings.difficulty"), diffNames, indexOf(difficulties, cfg.Difficulty), func(i int) {
+			cfg.Difficulty = difficulties[i]
+			apply("difficulty")
+		})),
+		row(ui.NewToggle(tr.T("settings.shake"), cfg.ScreenShake, func(on bool) {
+			cfg.ScreenShake = on
+		})),
+		row(ui.NewDropdown(tr.T("settings.language"), langNames, indexOf(languages, cfg.Language), func(i int) {
+			cfg.Language = languages[i]
+			apply("language")
+		})),
+	)
+	list.Width = 340
+
+	panel := ui.VBox(ui.NewTitle(tr.T("settings.title")), list,
+		ui.HBox(ui.NewButton(tr.T("settings.controls"), onControls), ui.NewButton(tr.T("common.back"), onBack)))
+	panel.Panel = true
+	panel.Padding = 10
+	panel.Gap = 6
+
+	v := ui.NewView(theme, panel)
+	v.OnBack = onBack
+	return v
+}
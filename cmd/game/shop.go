@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/my2dgame/internal/i18n"
+	"example.com/my2dgame/internal/meta"
+	"example.com/my2dgame/internal/ui"
+)
+
+// newShopView — магазин между забегами: постоянные улучшения, открытия
+// и выбор снаряжения, ниже — статистика за всё время. onChange вызывается
+// после каждой покупки или смены снаряжения (сохранить профиль).
+func newShopView(theme *ui.Theme, tr *i18n.Bundle, shop *meta.Shop, prof *meta.Profile, onChange func(), onBack func()) *ui.View {
+	gold := ui.NewLabel("")
+	gold.Centered = true
+
+	// refresh обновляет надписи и доступность кнопок после покупки
+	var rows []func()
+	refresh := func() {
+		gold.Text = tr.T("shop.gold", prof.Gold)
+		for _, r := range rows {
+			r()
+		}
+	}
+	changed := func() {
+		refresh()
+		onChange()
+	}
+
+	heading := func(key string) ui.Widget {
+		l := ui.NewLabel(tr.T(key))
+		l.Size = theme.HintSize
+		return l
+	}
+
+	items := []ui.Widget{heading("shop.upgrades")}
+	for _, u := range shop.Upgrades {
+		b := ui.NewButton("", func() {
+			if prof.BuyUpgrade(u) {
+				changed()
+			}
+		})
+		b.Width = 110
+		l := ui.NewLabel("")
+		rows = append(rows, func() {
+			l.Text = fmt.Sprintf("%s  %d/%d", tr.T(u.Name), prof.Upgrades[u.ID], len(u.Costs))
+			cost, ok := prof.Next(u)
+			b.Text = tr.T("shop.max")
+			if ok {
+				b.Text = tr.T("shop.buy", cost)
+			}
+			b.Disabled = !ok || prof.Gold < cost
+		})
+		items = append(items, ui.HBox(b, l))
+	}
+
+	items = append(items, heading("shop.unlocks"))
+	for _, u := range shop.Unlocks {
+		b := ui.NewButton("", func() {
+			if prof.Owns(u) {
+				prof.Equip(u)
+			} else if !prof.BuyUnlock(u) {
+				return
+			}
+			changed()
+		})
+		b.Width = 110
+		l := ui.NewLabel(tr.T(u.Name))
+		rows = append(rows, func() {
+			switch {
+			case !prof.Owns(u):
+				b.Text, b.Disabled = tr.T("shop.buy", u.Cost), prof.Gold < u.Cost
+			case u.Kind == meta.UnlockLevel:
+				b.Text, b.Disabled = tr.T("shop.owned"), true
+			case prof.Equipped(shop, u):
+				b.Text, b.Disabled = tr.T("shop.equipped"), true
+			default:
+				b.Text, b.Disabled = tr.T("shop.equip"), false
+			}
+		})
+		items = append(items, ui.HBox(b, l))
+	}
+
+	s := prof.Stats
+	items = append(items, heading("shop.stats"))
+	for _, line := range []string{
+		tr.T("stats.runs", s.Runs, s.Wins, s.Deaths),
+		tr.T("stats.kills", s.Kills, s.Bosses),
+		tr.T("stats.souls", s.Souls, s.Gold),
+		tr.T("stats.time", clock(float32(s.PlayTime)), clock(s.BestTime)),
+	} {
+		l := ui.NewLabel(line)
+		l.Size = theme.HintSize
+		items = append(items, l)
+	}
+
+	refresh()
+	list := ui.NewScrollList(200, items...)
+	list.Width = 380
+
+	panel := ui.VBox(ui.NewTitle(tr.T("shop.title")), gold, list, ui.NewButton(tr.T("common.back"), onBack))
+	panel.Panel = true
+	panel.Padding = 10
+	panel.Gap = 6
+
+	v := ui.NewView(theme, panel)
+	v.OnBack = onBack
+	return v
+}
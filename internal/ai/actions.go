@@ -0,0 +1,291 @@
+package ai
+
+import "math"
+
+// ---------- условия ----------
+
+// InRange — цель на расстоянии [Min, Max]
+type InRange struct{ Min, Max float32 }
+
+func (n *InRange) Tick(c *Context) Status {
+	_, _, d := c.toTarget()
+	if d >= n.Min && (n.Max <= 0 || d <= n.Max) {
+		return Success
+	}
+	return Failure
+}
+
+func (n *InRange) Reset() {}
+
+// HealthBelow — доля здоровья меньше Value
+type HealthBelow struct{ Value float32 }
+
+func (n *HealthBelow) Tick(c *Context) Status {
+	hp, max := c.Agent.Health()
+	if max > 0 && float32(hp)/float32(max) < n.Value {
+		return Success
+	}
+	return Failure
+}
+
+func (n *HealthBelow) Reset() {}
+
+// Chance — успех с вероятностью Value (за тик)
+type Chance struct{ Value float32 }
+
+func (n *Chance) Tick(c *Context) Status {
+	if c.Rand.Float32() < n.Value {
+		return Success
+	}
+	return Failure
+}
+
+func (n *Chance) Reset() {}
+
+// ---------- действия ----------
+
+// Chase — идти к цели до дистанции Stop
+type Chase struct {
+	Stop     float32
+	SpeedMul float32
+}
+
+func (n *Chase) Tick(c *Context) Status {
+	_, _, d := c.toTarget()
+	if d <= n.Stop {
+		return Success
+	}
+	c.walk(c.BB.TargetX, c.BB.TargetY, n.SpeedMul)
+	return Running
+}
+
+func (n *Chase) Reset() {}
+
+// KeepDistance — держаться в кольце [Min, Max] вокруг цели
+type KeepDistance struct {
+	Min, Max float32
+	SpeedMul float32
+}
+
+func (n *KeepDistance) Tick(c *Context) Status {
+	dx, dy, d := c.toTarget()
+	if d < 1e-3 {
+		return Running
+	}
+	switch {
+	case d < n.Min:
+		c.Agent.Move(-dx/d, -dy/d, n.SpeedMul, c.DT)
+		return Running
+	case d > n.Max:
+		c.walk(c.BB.TargetX, c.BB.TargetY, n.SpeedMul)
+		return Running
+	}
+	return Success
+}
+
+func (n *KeepDistance) Reset() {}
+
+// Strafe — двигаться вбок относительно цели Duration секунд
+type Strafe struct {
+	Duration float32
+	SpeedMul float32
+	started  bool
+	until    float32
+	side     float32
+}
+
+func (n *Strafe) Tick(c *Context) Status {
+	if !n.started {
+		n.started = true
+		n.until = c.BB.Time + n.Duration
+		n.side = 1
+		if c.Rand.Intn(2) == 0 {
+			n.side = -1
+		}
+	}
+	if c.BB.Time >= n.until {
+		n.started = false
+		return Success
+	}
+	dx, dy, d := c.toTarget()
+	if d > 1e-3 {
+		c.Agent.Move(-dy/d*n.side, dx/d*n.side, n.SpeedMul, c.DT)
+	}
+	return Running
+}
+
+func (n *Strafe) Reset() { n.started = false }
+
+// Flee — убегать от цели
+type Flee struct{ SpeedMul float32 }
+
+func (n *Flee) Tick(c *Context) Status {
+	dx, dy, d := c.toTarget()
+	if d < 1e-3 {
+		dx, dy, d = 1, 0, 1
+	}
+	c.Agent.Move(-dx/d, -dy/d, n.SpeedMul, c.DT)
+	return Running
+}
+
+func (n *Flee) Reset() {}
+
+// Charge — замах Windup секунд на месте, затем рывок по зафиксированному направлению
+type Charge struct {
+	Windup   float32
+	Duration float32
+	SpeedMul float32
+
+	phase  int // 0 — не начат, 1 — замах, 2 — рывок
+	until  float32
+	dx, dy float32
+}
+
+func (n *Charge) Tick(c *Context) Status {
+	switch n.phase {
+	case 0:
+		n.phase = 1
+		n.until = c.BB.Time + n.Windup
+		c.Agent.SetWindup()
+		return Running
+	case 1:
+		if c.BB.Time < n.until {
+			c.Agent.SetWindup()
+			return Running
+		}
+		dx, dy, _ := c.toTarget()
+		n.dx, n.dy = norm(dx, dy)
+		n.phase = 2
+		n.until = c.BB.Time + n.Duration
+		return Running
+	}
+	if c.BB.Time >= n.until {
+		n.phase = 0
+		return Success
+	}
+	c.Agent.Move(n.dx, n.dy, n.SpeedMul, c.DT)
+	return Running
+}
+
+func (n *Charge) Reset() { n.phase = 0 }
+
+// Shoot — выстрел (или очередь) по цели. Прицел берётся из Aim узла,
+// а если его нет — из профиля агента (архетип + сложность).
+type Shoot struct {
+	Aim *AimSpec
+
+	left   int // осталось выстрелов в очереди
+	nextAt float32
+	aim    AimSpec
+}
+
+func (n *Shoot) Tick(c *Context) Status {
+	if n.left == 0 {
+		if !c.Agent.CanFire() {
+			return Failure
+		}
+		n.aim = c.Agent.Aim()
+		if n.Aim != nil {
+			n.aim = *n.Aim
+		}
+		n.left = n.aim.Shots()
+		n.nextAt = c.BB.Time
+	}
+	if c.BB.Time < n.nextAt {
+		return Running
+	}
+
+	ax, ay := c.Agent.Position()
+	x, y := AimPoint(n.aim, c.Rand, ax, ay, c.BB.TargetX, c.BB.TargetY, c.BB.TargetVX, c.BB.TargetVY, c.Agent.ProjectileSpeed())
+	c.Agent.Fire(x, y)
+	n.left--
+	if n.left > 0 {
+		n.nextAt = c.BB.Time + n.aim.Gap()
+		return Running
+	}
+	return Success
+}
+
+func (n *Shoot) Reset() { n.left = 0 }
+
+// Patrol — обход Points точек по окружности Radius вокруг дома
+type Patrol struct {
+	Radius   float32
+	Points   int
+	SpeedMul float32
+	idx      int
+}
+
+func (n *Patrol) Tick(c *Context) Status {
+	pts := n.Points
+	if pts < 2 {
+		pts = 4
+	}
+	a := float64(n.idx) * 2 * math.Pi / float64(pts)
+	x := c.BB.HomeX + n.Radius*float32(math.Cos(a))
+	y := c.BB.HomeY + n.Radius*float32(math.Sin(a))
+	if c.moveToward(x, y, n.SpeedMul) < 12 {
+		n.idx = (n.idx + 1) % pts
+	}
+	return Running
+}
+
+func (n *Patrol) Reset() {}
+
+// Wander — случайные точки в радиусе Radius от дома с паузами Pause
+type Wander struct {
+	Radius   float32
+	Pause    float32
+	SpeedMul float32
+
+	has    bool
+	x, y   float32
+	waitTo float32
+}
+
+func (n *Wander) Tick(c *Context) Status {
+	if c.BB.Time < n.waitTo {
+		return Running
+	}
+	if !n.has {
+		a := c.Rand.Float64() * 2 * math.Pi
+		r := n.Radius * float32(math.Sqrt(c.Rand.Float64()))
+		n.x = c.BB.HomeX + r*float32(math.Cos(a))
+		n.y = c.BB.HomeY + r*float32(math.Sin(a))
+		n.has = true
+	}
+	if c.moveToward(n.x, n.y, n.SpeedMul) < 8 {
+		n.has = false
+		n.waitTo = c.BB.Time + n.Pause
+	}
+	return Running
+}
+
+func (n *Wander) Reset() { n.has = false }
+
+// Wait — ждать Seconds, затем Success
+type Wait struct {
+	Seconds float32
+	started bool
+	until   float32
+}
+
+func (n *Wait) Tick(c *Context) Status {
+	if !n.started {
+		n.started = true
+		n.until = c.BB.Time + n.Seconds
+	}
+	if c.BB.Time >= n.until {
+		n.started = false
+		return Success
+	}
+	return Running
+}
+
+func (n *Wait) Reset() { n.started = false }
+
+func rotateAround(cx, cy, x, y, ang float32) (float32, float32) {
+	s, co := math.Sincos(float64(ang))
+	dx, dy := float64(x-cx), float64(y-cy)
+	return cx + float32(dx*co-dy*s), cy + float32(dx*s+dy*co)
+}
@@ -0,0 +1,129 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+)
+
+// Intercept — точка встречи снаряда скорости speed, выпущенного из (sx,sy),
+// с целью в (tx,ty), движущейся равномерно со скоростью (vx,vy).
+// ok=false, если догнать цель нельзя.
+func Intercept(sx, sy, tx, ty, vx, vy, speed float32) (x, y float32, ok bool) {
+	dx, dy := float64(tx-sx), float64(ty-sy)
+	wx, wy := float64(vx), float64(vy)
+	s := float64(speed)
+
+	// |D + W t| = s t  ->  (W·W - s²) t² + 2 (D·W) t + D·D = 0
+	a := wx*wx + wy*wy - s*s
+	b := 2 * (dx*wx + dy*wy)
+	c := dx*dx + dy*dy
+
+	var t float64
+	if math.Abs(a) < 1e-6 {
+		if b >= 0 {
+			return tx, ty, false
+		}
+		t = -c / b
+	} else {
+		disc := b*b - 4*a*c
+		if disc < 0 {
+			return tx, ty, false
+		}
+		sq := math.Sqrt(disc)
+		t1 := (-b - sq) / (2 * a)
+		t2 := (-b + sq) / (2 * a)
+		t = math.Inf(1)
+		if t1 > 0 {
+			t = t1
+		}
+		if t2 > 0 && t2 < t {
+			t = t2
+		}
+		if math.IsInf(t, 1) {
+			return tx, ty, false
+		}
+	}
+	return tx + vx*float32(t), ty + vy*float32(t), true
+}
+
+// Режимы прицеливания вражеских снарядов
+const (
+	AimDirect = "direct" // в текущую позицию цели
+	AimLead   = "lead"   // с упреждением по скорости цели
+	AimSpread = "spread" // неточно: случайный конус
+	AimBurst  = "burst"  // очередь из нескольких выстрелов
+)
+
+// AimSpec — настройки прицеливания архетипа. Поля складываются:
+// например, lead + spread + burst даёт очередь с упреждением и разбросом.
+type AimSpec struct {
+	Mode     string  `json:"mode"`
+	Lead     float32 `json:"lead,omitempty"`      // доля упреждения 0..1 (0 = полное)
+	Spread   float32 `json:"spread,omitempty"`    // градусы, полный конус
+	Burst    int     `json:"burst,omitempty"`     // выстрелов в очереди
+	BurstGap float32 `json:"burst_gap,omitempty"` // пауза между ними, с
+}
+
+// Shots — сколько выстрелов в одной очереди
+func (a AimSpec) Shots() int {
+	if a.Burst > 1 {
+		return a.Burst
+	}
+	if a.Mode == AimBurst {
+		return 3
+	}
+	return 1
+}
+
+func (a AimSpec) Gap() float32 {
+	if a.BurstGap > 0 {
+		return a.BurstGap
+	}
+	return 0.12
+}
+
+func (a AimSpec) spread() float32 {
+	if a.Spread > 0 {
+		return a.Spread
+	}
+	if a.Mode == AimSpread {
+		return 20
+	}
+	return 0
+}
+
+// AimPoint — куда стрелять из (sx,sy) по цели (tx,ty) со скоростью (vx,vy)
+// снарядом скорости speed
+func AimPoint(a AimSpec, rng *rand.Rand, sx, sy, tx, ty, vx, vy, speed float32) (float32, float32) {
+	x, y := tx, ty
+	if a.Mode == AimLead {
+		if ix, iy, ok := Intercept(sx, sy, tx, ty, vx, vy, speed); ok {
+			k := a.Lead
+			if k <= 0 || k > 1 {
+				k = 1
+			}
+			x, y = tx+(ix-tx)*k, ty+(iy-ty)*k
+		}
+	}
+	if s := a.spread(); s > 0 && rng != nil {
+		ang := (rng.Float32() - 0.5) * s * math.Pi / 180
+		x, y = rotateAround(sx, sy, x, y, ang)
+	}
+	return x, y
+}
+
+// LoadAimProfiles читает {"архетип": {"сложность": AimSpec}}
+func LoadAimProfiles(path string) (map[string]map[string]AimSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]map[string]AimSpec
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("aim %s: %w", path, err)
+	}
+	return out, nil
+}
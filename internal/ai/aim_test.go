@@ -0,0 +1,115 @@
+package ai
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// fly пускает снаряд скорости speed из (sx,sy) в точку (ax,ay) и ведёт цель
+// из (tx,ty) со скоростью (vx,vy) с шагом dt; возвращает наименьшее расстояние
+func fly(sx, sy, ax, ay, tx, ty, vx, vy, speed float32) float32 {
+	const dt = float32(1) / 120
+	dx, dy := norm(ax-sx, ay-sy)
+	px, py := sx, sy
+	best := length(tx-px, ty-py)
+	for i := 0; i < 3*120; i++ {
+		px += dx * speed * dt
+		py += dy * speed * dt
+		tx += vx * dt
+		ty += vy * dt
+		best = min(best, length(tx-px, ty-py))
+	}
+	return best
+}
+
+// радиус попадания вражеской пули (8 пикс × масштаб 1.4)
+const hitRadius = 11
+
+func TestLeadHitsMovingTarget(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		tx, ty, vx, vy float32
+	}{
+		{"поперёк", 300, 0, 0, 120},
+		{"навстречу", 400, 100, -90, 80},
+		{"от стрелка", 200, -150, 100, 60},
+		{"по диагонали", -250, 250, 100, 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			const speed = 300
+			x, y := AimPoint(AimSpec{Mode: AimLead}, nil, 0, 0, tc.tx, tc.ty, tc.vx, tc.vy, speed)
+			if d := fly(0, 0, x, y, tc.tx, tc.ty, tc.vx, tc.vy, speed); d > hitRadius {
+				t.Fatalf("упреждение промахнулось на %.1f пикс", d)
+			}
+			// для сравнения: выстрел в текущую позицию мимо
+			x, y = AimPoint(AimSpec{Mode: AimDirect}, nil, 0, 0, tc.tx, tc.ty, tc.vx, tc.vy, speed)
+			if d := fly(0, 0, x, y, tc.tx, tc.ty, tc.vx, tc.vy, speed); d <= hitRadius {
+				t.Fatalf("прямой выстрел попал (%.1f пикс) — тест ничего не проверяет", d)
+			}
+		})
+	}
+}
+
+func TestInterceptStationary(t *testing.T) {
+	x, y, ok := Intercept(0, 0, 120, -50, 0, 0, 300)
+	if !ok || x != 120 || y != -50 {
+		t.Fatalf("(%.1f, %.1f, %v), want (120, -50, true)", x, y, ok)
+	}
+}
+
+func TestInterceptNoSolution(t *testing.T) {
+	// цель уходит от стрелка быстрее снаряда
+	for _, v := range [][2]float32{{400, 0}, {300, 10}} {
+		x, y, ok := Intercept(0, 0, 200, 0, v[0], v[1], 300)
+		if ok {
+			t.Fatalf("v=%v: нашлась встреча (%.1f, %.1f)", v, x, y)
+		}
+		if x != 200 || y != 0 {
+			t.Fatalf("v=%v: без решения ждём текущую позицию, got (%.1f, %.1f)", v, x, y)
+		}
+	}
+	// без решения AimPoint стреляет в текущую позицию
+	x, y := AimPoint(AimSpec{Mode: AimLead}, nil, 0, 0, 200, 0, 400, 0, 300)
+	if x != 200 || y != 0 {
+		t.Fatalf("AimPoint без решения: (%.1f, %.1f)", x, y)
+	}
+}
+
+func TestLeadFraction(t *testing.T) {
+	ix, iy, _ := Intercept(0, 0, 300, 0, 0, 120, 300)
+	x, y := AimPoint(AimSpec{Mode: AimLead, Lead: 0.5}, nil, 0, 0, 300, 0, 0, 120, 300)
+	if math.Abs(float64(x-(300+ix)/2)) > 1e-3 || math.Abs(float64(y-iy/2)) > 1e-3 {
+		t.Fatalf("половина упреждения: (%.2f, %.2f), встреча (%.2f, %.2f)", x, y, ix, iy)
+	}
+}
+
+func TestSpreadCone(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	a := AimSpec{Mode: AimSpread, Spread: 20}
+	for i := 0; i < 200; i++ {
+		x, y := AimPoint(a, rng, 0, 0, 100, 0, 0, 0, 300)
+		deg := math.Atan2(float64(y), float64(x)) * 180 / math.Pi
+		if math.Abs(deg) > 10+1e-3 {
+			t.Fatalf("отклонение %.2f° вне конуса ±10°", deg)
+		}
+		if math.Abs(float64(length(x, y)-100)) > 1e-2 {
+			t.Fatalf("разброс изменил дальность: %.3f", length(x, y))
+		}
+	}
+}
+
+func TestBurstShots(t *testing.T) {
+	for _, tc := range []struct {
+		a    AimSpec
+		want int
+	}{
+		{AimSpec{Mode: AimDirect}, 1},
+		{AimSpec{Mode: AimBurst}, 3},
+		{AimSpec{Mode: AimLead, Burst: 5}, 5},
+	} {
+		if n := tc.a.Shots(); n != tc.want {
+			t.Errorf("%+v: %d выстрелов, want %d", tc.a, n, tc.want)
+		}
+	}
+}
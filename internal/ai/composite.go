@@ -0,0 +1,151 @@
+package ai
+
+// Sequence — реактивная последовательность: каждый тик идёт с начала,
+// пока дети возвращают Success. Прерванный выполняющийся ребёнок сбрасывается.
+// Latch — условия проверяются только на входе: пока ребёнок выполняется,
+// тик продолжает его, не перепроверяя предыдущих (рывок не обрывается,
+// когда цель вышла из in_range).
+type Sequence struct {
+	Children []Node
+	Latch    bool
+	running  int
+}
+
+func NewSequence(children ...Node) *Sequence { return &Sequence{Children: children, running: -1} }
+
+func (s *Sequence) Tick(c *Context) Status {
+	from := 0
+	if s.Latch && s.running >= 0 {
+		from = s.running
+	}
+	for i := from; i < len(s.Children); i++ {
+		st := s.Children[i].Tick(c)
+		if st == Success {
+			continue
+		}
+		s.switchRunning(i, st)
+		return st
+	}
+	s.switchRunning(len(s.Children), Success)
+	return Success
+}
+
+// switchRunning — тик остановился на ребёнке i со статусом st. Сбрасываем
+// только прерванного: выполнявшийся дальше i; закончивший сам сброса не ждёт.
+func (s *Sequence) switchRunning(i int, st Status) {
+	if s.running > i {
+		s.Children[s.running].Reset()
+	}
+	s.running = -1
+	if st == Running {
+		s.running = i
+	}
+}
+
+func (s *Sequence) Reset() {
+	if s.running >= 0 {
+		s.Children[s.running].Reset()
+	}
+	s.running = -1
+}
+
+// Selector — приоритетный выбор: первый ребёнок, не вернувший Failure
+type Selector struct {
+	Children []Node
+	running  int
+}
+
+func NewSelector(children ...Node) *Selector { return &Selector{Children: children, running: -1} }
+
+func (s *Selector) Tick(c *Context) Status {
+	for i, ch := range s.Children {
+		st := ch.Tick(c)
+		if st == Failure {
+			continue
+		}
+		// выполнявшийся ниже по приоритету прерван
+		if s.running > i {
+			s.Children[s.running].Reset()
+		}
+		s.running = -1
+		if st == Running {
+			s.running = i
+		}
+		return st
+	}
+	s.running = -1
+	return Failure
+}
+
+func (s *Selector) Reset() {
+	if s.running >= 0 {
+		s.Children[s.running].Reset()
+	}
+	s.running = -1
+}
+
+// ---------- декораторы ----------
+
+// Inverter меняет Success и Failure местами
+type Inverter struct{ Child Node }
+
+func (d *Inverter) Tick(c *Context) Status {
+	switch d.Child.Tick(c) {
+	case Success:
+		return Failure
+	case Failure:
+		return Success
+	}
+	return Running
+}
+
+func (d *Inverter) Reset() { d.Child.Reset() }
+
+// Succeeder всегда возвращает Success (кроме Running)
+type Succeeder struct{ Child Node }
+
+func (d *Succeeder) Tick(c *Context) Status {
+	if d.Child.Tick(c) == Running {
+		return Running
+	}
+	return Success
+}
+
+func (d *Succeeder) Reset() { d.Child.Reset() }
+
+// Cooldown не пускает в ребёнка чаще, чем раз в Seconds. Отсчёт начинается,
+// когда ребёнок закончил (с любым итогом) или был прерван после того, как
+// начал выполняться; сразу проваленная проверка кулдаун не тратит.
+type Cooldown struct {
+	Child   Node
+	Seconds float32
+	readyAt float32
+	running bool
+	now     float32 // время последнего тика (для Reset)
+}
+
+func (d *Cooldown) Tick(c *Context) Status {
+	d.now = c.BB.Time
+	if c.BB.Time < d.readyAt {
+		return Failure
+	}
+	st := d.Child.Tick(c)
+	if st == Running {
+		d.running = true
+	} else if st == Success || d.running {
+		d.start()
+	}
+	return st
+}
+
+func (d *Cooldown) Reset() {
+	if d.running {
+		d.start()
+	}
+	d.Child.Reset()
+}
+
+func (d *Cooldown) start() {
+	d.running = false
+	d.readyAt = d.now + d.Seconds
+}
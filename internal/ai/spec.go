@@ -0,0 +1,116 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Spec — описание узла в данных (json). Параметры общие для всех типов,
+// каждый узел берёт только свои.
+type Spec struct {
+	Type     string   `json:"type"`
+	Children []Spec   `json:"children,omitempty"`
+	Min      float32  `json:"min,omitempty"`
+	Max      float32  `json:"max,omitempty"`
+	Value    float32  `json:"value,omitempty"`
+	Seconds  float32  `json:"seconds,omitempty"`
+	Duration float32  `json:"duration,omitempty"`
+	Windup   float32  `json:"windup,omitempty"`
+	Speed    float32  `json:"speed,omitempty"` // множитель скорости агента, 0 = 1
+	Radius   float32  `json:"radius,omitempty"`
+	Pause    float32  `json:"pause,omitempty"`
+	Points   int      `json:"points,omitempty"`
+	Latch    bool     `json:"latch,omitempty"` // sequence: не перепроверять условия, пока ребёнок выполняется
+	Aim      *AimSpec `json:"aim,omitempty"`   // shoot: свой прицел вместо профиля
+}
+
+type builder func(s Spec, kids []Node) (Node, error)
+
+var builders = map[string]builder{
+	"sequence": func(s Spec, k []Node) (Node, error) {
+		seq := NewSequence(k...)
+		seq.Latch = s.Latch
+		return seq, nil
+	},
+	"selector": func(_ Spec, k []Node) (Node, error) { return NewSelector(k...), nil },
+	"invert":   one(func(s Spec, c Node) Node { return &Inverter{Child: c} }),
+	"succeed":  one(func(s Spec, c Node) Node { return &Succeeder{Child: c} }),
+	"cooldown": one(func(s Spec, c Node) Node { return &Cooldown{Child: c, Seconds: s.Seconds} }),
+
+	"in_range":      leaf(func(s Spec) Node { return &InRange{Min: s.Min, Max: s.Max} }),
+	"health_below":  leaf(func(s Spec) Node { return &HealthBelow{Value: s.Value} }),
+	"chance":        leaf(func(s Spec) Node { return &Chance{Value: s.Value} }),
+	"chase":         leaf(func(s Spec) Node { return &Chase{Stop: s.Min, SpeedMul: s.speed()} }),
+	"keep_distance": leaf(func(s Spec) Node { return &KeepDistance{Min: s.Min, Max: s.Max, SpeedMul: s.speed()} }),
+	"strafe":        leaf(func(s Spec) Node { return &Strafe{Duration: s.Duration, SpeedMul: s.speed()} }),
+	"flee":          leaf(func(s Spec) Node { return &Flee{SpeedMul: s.speed()} }),
+	"charge": leaf(func(s Spec) Node {
+		return &Charge{Windup: s.Windup, Duration: s.Duration, SpeedMul: s.speed()}
+	}),
+	"shoot":  leaf(func(s Spec) Node { return &Shoot{Aim: s.Aim} }),
+	"patrol": leaf(func(s Spec) Node { return &Patrol{Radius: s.Radius, Points: s.Points, SpeedMul: s.speed()} }),
+	"wander": leaf(func(s Spec) Node { return &Wander{Radius: s.Radius, Pause: s.Pause, SpeedMul: s.speed()} }),
+	"wait":   leaf(func(s Spec) Node { return &Wait{Seconds: s.Seconds} }),
+}
+
+func (s Spec) speed() float32 {
+	if s.Speed <= 0 {
+		return 1
+	}
+	return s.Speed
+}
+
+func leaf(f func(Spec) Node) builder {
+	return func(s Spec, k []Node) (Node, error) {
+		if len(k) != 0 {
+			return nil, fmt.Errorf("%s: leaf node has children", s.Type)
+		}
+		return f(s), nil
+	}
+}
+
+func one(f func(Spec, Node) Node) builder {
+	return func(s Spec, k []Node) (Node, error) {
+		if len(k) != 1 {
+			return nil, fmt.Errorf("%s: decorator needs exactly one child, got %d", s.Type, len(k))
+		}
+		return f(s, k[0]), nil
+	}
+}
+
+// Build собирает новое дерево узлов по описанию (у каждого вызова своё состояние)
+func Build(s Spec) (Node, error) {
+	b, ok := builders[s.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown node type %q", s.Type)
+	}
+	kids := make([]Node, 0, len(s.Children))
+	for _, cs := range s.Children {
+		n, err := Build(cs)
+		if err != nil {
+			return nil, err
+		}
+		kids = append(kids, n)
+	}
+	return b(s, kids)
+}
+
+// LoadArchetypes читает деревья по архетипам: {"melee": {...}, "slime": {...}}
+// и сразу проверяет, что каждое собирается.
+func LoadArchetypes(path string) (map[string]Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]Spec
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("ai %s: %w", path, err)
+	}
+	for name, s := range out {
+		if _, err := Build(s); err != nil {
+			return nil, fmt.Errorf("ai archetype %q: %w", name, err)
+		}
+	}
+	return out, nil
+}
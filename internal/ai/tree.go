@@ -0,0 +1,135 @@
+package ai
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Status — результат тика узла
+type Status int
+
+const (
+	Success Status = iota
+	Failure
+	Running
+)
+
+func (s Status) String() string {
+	switch s {
+	case Success:
+		return "success"
+	case Failure:
+		return "failure"
+	}
+	return "running"
+}
+
+// Agent — то, чем управляет дерево (враг). Пакет не знает ни о raylib, ни о entities.
+type Agent interface {
+	Position() (x, y float32)
+	// Move сдвигает агента по нормализованному направлению dx,dy
+	Move(dx, dy, speedMul, dt float32)
+	// Toward — нормализованное направление, которым идти к точке (x, y)
+	// в обход препятствий; 0,0 — идти некуда
+	Toward(x, y float32) (dx, dy float32)
+	Health() (hp, max int)
+	// CanFire — оружие перезаряжено и стрелять можно
+	CanFire() bool
+	// Fire стреляет в точку (перезарядка начинается заново)
+	Fire(aimX, aimY float32)
+	ProjectileSpeed() float32
+	// Aim — профиль прицеливания по умолчанию
+	Aim() AimSpec
+	// SetWindup отмечает, что агент в этом тике замахивается (для подсветки)
+	SetWindup()
+}
+
+// Blackboard — общие данные дерева
+type Blackboard struct {
+	TargetX, TargetY   float32
+	TargetVX, TargetVY float32
+	HomeX, HomeY       float32
+	Time               float32 // время жизни дерева, с
+	Values             map[string]float32
+}
+
+type Context struct {
+	Agent Agent
+	BB    *Blackboard
+	Rand  *rand.Rand
+	DT    float32
+}
+
+// Node — узел дерева. Reset вызывается, когда родитель прерывает выполняющийся узел.
+type Node interface {
+	Tick(c *Context) Status
+	Reset()
+}
+
+// Tree — экземпляр дерева для одного агента (у узлов своё состояние)
+type Tree struct {
+	Root Node
+	BB   Blackboard
+	Rand *rand.Rand
+
+	started bool
+}
+
+func NewTree(root Node, rng *rand.Rand) *Tree {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+	return &Tree{Root: root, Rand: rng, BB: Blackboard{Values: map[string]float32{}}}
+}
+
+// SetTarget обновляет позицию и скорость цели
+func (t *Tree) SetTarget(x, y, vx, vy float32) {
+	t.BB.TargetX, t.BB.TargetY = x, y
+	t.BB.TargetVX, t.BB.TargetVY = vx, vy
+}
+
+func (t *Tree) Tick(a Agent, dt float32) Status {
+	if !t.started {
+		t.started = true
+		t.BB.HomeX, t.BB.HomeY = a.Position()
+	}
+	t.BB.Time += dt
+	return t.Root.Tick(&Context{Agent: a, BB: &t.BB, Rand: t.Rand, DT: dt})
+}
+
+// ---------- геометрия ----------
+
+func (c *Context) toTarget() (dx, dy, dist float32) {
+	x, y := c.Agent.Position()
+	dx, dy = c.BB.TargetX-x, c.BB.TargetY-y
+	dist = length(dx, dy)
+	return
+}
+
+func length(x, y float32) float32 {
+	return float32(math.Hypot(float64(x), float64(y)))
+}
+
+func norm(x, y float32) (float32, float32) {
+	l := length(x, y)
+	if l < 1e-6 {
+		return 0, 0
+	}
+	return x / l, y / l
+}
+
+func (c *Context) moveToward(x, y, speedMul float32) float32 {
+	ax, ay := c.Agent.Position()
+	d := length(x-ax, y-ay)
+	if d > 1e-3 {
+		c.walk(x, y, speedMul)
+	}
+	return d
+}
+
+// walk — шаг к точке по пути в обход препятствий
+func (c *Context) walk(x, y, speedMul float32) {
+	if dx, dy := c.Agent.Toward(x, y); dx != 0 || dy != 0 {
+		c.Agent.Move(dx, dy, speedMul, c.DT)
+	}
+}
@@ -0,0 +1,305 @@
+package ai
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+// stub — узел с заранее заданными ответами; последний повторяется
+type stub struct {
+	out    []Status
+	ticks  int
+	resets int
+}
+
+func (n *stub) Tick(*Context) Status {
+	st := n.out[min(n.ticks, len(n.out)-1)]
+	n.ticks++
+	return st
+}
+
+func (n *stub) Reset() { n.resets++ }
+
+func ctx(dt float32) (*Context, func()) {
+	bb := &Blackboard{Values: map[string]float32{}}
+	c := &Context{Agent: &testAgent{}, BB: bb, Rand: rand.New(rand.NewSource(1)), DT: dt}
+	return c, func() { bb.Time += dt }
+}
+
+// testAgent — агент на плоскости без препятствий
+type testAgent struct {
+	x, y     float32
+	speed    float32
+	hp       int
+	fired    int
+	winds    int
+	cantFire bool
+}
+
+func (a *testAgent) Position() (float32, float32) { return a.x, a.y }
+
+func (a *testAgent) Move(dx, dy, mul, dt float32) {
+	a.x += dx * a.speed * mul * dt
+	a.y += dy * a.speed * mul * dt
+}
+
+func (a *testAgent) Toward(x, y float32) (float32, float32) { return norm(x-a.x, y-a.y) }
+func (a *testAgent) Health() (int, int)                     { return a.hp, 100 }
+func (a *testAgent) CanFire() bool                          { return !a.cantFire }
+func (a *testAgent) Fire(float32, float32)                  { a.fired++ }
+func (a *testAgent) ProjectileSpeed() float32               { return 300 }
+func (a *testAgent) Aim() AimSpec                           { return AimSpec{Mode: AimDirect} }
+func (a *testAgent) SetWindup()                             { a.winds++ }
+
+func TestSequence(t *testing.T) {
+	c, _ := ctx(0.1)
+	cond := &stub{out: []Status{Success}}
+	act := &stub{out: []Status{Running, Running, Success}}
+	seq := NewSequence(cond, act)
+
+	for i, want := range []Status{Running, Running, Success} {
+		if st := seq.Tick(c); st != want {
+			t.Fatalf("тик %d: %v, want %v", i, st, want)
+		}
+	}
+	// реактивная: условие проверяется каждый тик
+	if cond.ticks != 3 {
+		t.Fatalf("условие проверено %d раз, want 3", cond.ticks)
+	}
+	if act.resets != 0 {
+		t.Fatalf("законченный ребёнок сброшен %d раз", act.resets)
+	}
+}
+
+func TestSequenceAbortResets(t *testing.T) {
+	c, _ := ctx(0.1)
+	cond := &stub{out: []Status{Success, Failure}}
+	act := &stub{out: []Status{Running}}
+	seq := NewSequence(cond, act)
+
+	seq.Tick(c)
+	if st := seq.Tick(c); st != Failure {
+		t.Fatalf("%v, want failure", st)
+	}
+	if act.resets != 1 {
+		t.Fatalf("прерванный ребёнок сброшен %d раз, want 1", act.resets)
+	}
+	// Reset самой последовательности без выполняющегося ребёнка ничего не трогает
+	seq.Reset()
+	if act.resets != 1 {
+		t.Fatalf("лишний сброс: %d", act.resets)
+	}
+}
+
+func TestSequenceLatch(t *testing.T) {
+	c, _ := ctx(0.1)
+	cond := &stub{out: []Status{Success, Failure}}
+	act := &stub{out: []Status{Running, Running, Success}}
+	seq := NewSequence(cond, act)
+	seq.Latch = true
+
+	for i, want := range []Status{Running, Running, Success} {
+		if st := seq.Tick(c); st != want {
+			t.Fatalf("тик %d: %v, want %v", i, st, want)
+		}
+	}
+	if cond.ticks != 1 || act.resets != 0 {
+		t.Fatalf("условие проверено %d раз, сбросов %d; want 1 и 0", cond.ticks, act.resets)
+	}
+	// после завершения условие снова проверяется на входе
+	if st := seq.Tick(c); st != Failure || cond.ticks != 2 {
+		t.Fatalf("%v, условие %d раз; want failure, 2", st, cond.ticks)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	c, _ := ctx(0.1)
+	high := &stub{out: []Status{Failure, Failure, Success}}
+	low := &stub{out: []Status{Running}}
+	sel := NewSelector(high, low)
+
+	for i, want := range []Status{Running, Running, Success} {
+		if st := sel.Tick(c); st != want {
+			t.Fatalf("тик %d: %v, want %v", i, st, want)
+		}
+	}
+	// высший приоритет перебил выполняющийся низший — тот сброшен
+	if low.resets != 1 || low.ticks != 2 {
+		t.Fatalf("low: тиков %d, сбросов %d; want 2 и 1", low.ticks, low.resets)
+	}
+
+	none := NewSelector(&stub{out: []Status{Failure}}, &stub{out: []Status{Failure}})
+	if st := none.Tick(c); st != Failure {
+		t.Fatalf("все провалились: %v, want failure", st)
+	}
+}
+
+func TestInverterSucceeder(t *testing.T) {
+	c, _ := ctx(0.1)
+	for _, tc := range []struct {
+		node Node
+		want Status
+	}{
+		{&Inverter{&stub{out: []Status{Success}}}, Failure},
+		{&Inverter{&stub{out: []Status{Failure}}}, Success},
+		{&Inverter{&stub{out: []Status{Running}}}, Running},
+		{&Succeeder{&stub{out: []Status{Failure}}}, Success},
+		{&Succeeder{&stub{out: []Status{Running}}}, Running},
+	} {
+		if st := tc.node.Tick(c); st != tc.want {
+			t.Errorf("%T: %v, want %v", tc.node, st, tc.want)
+		}
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, next := ctx(0.5)
+		child := &stub{out: []Status{Success}}
+		cd := &Cooldown{Child: child, Seconds: 2}
+		var got []Status
+		for i := 0; i < 6; i++ {
+			got = append(got, cd.Tick(c))
+			next()
+		}
+		want := []Status{Success, Failure, Failure, Failure, Success, Failure}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("тики %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("failed check is free", func(t *testing.T) {
+		c, next := ctx(0.5)
+		child := &stub{out: []Status{Failure, Success}}
+		cd := &Cooldown{Child: child, Seconds: 2}
+		cd.Tick(c)
+		next()
+		if st := cd.Tick(c); st != Success {
+			t.Fatalf("%v: сразу проваленная проверка потратила кулдаун", st)
+		}
+	})
+
+	t.Run("failure after running", func(t *testing.T) {
+		c, next := ctx(0.5)
+		child := &stub{out: []Status{Running, Failure, Success}}
+		cd := &Cooldown{Child: child, Seconds: 2}
+		cd.Tick(c)
+		next()
+		cd.Tick(c)
+		next()
+		if st := cd.Tick(c); st != Failure || child.ticks != 2 {
+			t.Fatalf("%v, ребёнок %d тиков: прерванная атака не запустила кулдаун", st, child.ticks)
+		}
+	})
+
+	t.Run("reset while running", func(t *testing.T) {
+		c, next := ctx(0.5)
+		child := &stub{out: []Status{Running, Success}}
+		cd := &Cooldown{Child: child, Seconds: 2}
+		cd.Tick(c)
+		cd.Reset()
+		next()
+		if st := cd.Tick(c); st != Failure || child.resets != 1 {
+			t.Fatalf("%v, сбросов %d: прерванный родителем ребёнок не запустил кулдаун", st, child.resets)
+		}
+	})
+}
+
+func loadTrees(t *testing.T) map[string]Spec {
+	t.Helper()
+	specs, err := LoadArchetypes(filepath.Join("..", "..", "assets", "data", "ai.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return specs
+}
+
+// все деревья из данных собираются и отрабатывают без паники
+func TestBuildArchetypes(t *testing.T) {
+	for name, spec := range loadTrees(t) {
+		root, err := Build(spec)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		a := &testAgent{x: 300, speed: 80, hp: 100}
+		tree := NewTree(root, rand.New(rand.NewSource(1)))
+		for i := 0; i < 600; i++ {
+			if i == 300 {
+				a.hp = 10
+			}
+			tree.SetTarget(0, 0, 40, 0)
+			tree.Tick(a, 1.0/60)
+		}
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	for _, s := range []Spec{
+		{Type: "nope"},
+		{Type: "cooldown"},
+		{Type: "chase", Children: []Spec{{Type: "chase"}}},
+		{Type: "sequence", Children: []Spec{{Type: "nope"}}},
+	} {
+		if _, err := Build(s); err == nil {
+			t.Errorf("%+v: нет ошибки", s)
+		}
+	}
+}
+
+// meleeRun гоняет дерево ближника dur секунд; target — где цель в этом тике.
+// Возвращает время начала каждого замаха и число тиков рывка.
+func meleeRun(t *testing.T, a *testAgent, dur float32, target func() float32) (windups []float32, dashTicks int) {
+	t.Helper()
+	root, err := Build(loadTrees(t)["melee"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := NewTree(root, rand.New(rand.NewSource(1)))
+	const dt = float32(1) / 60
+	winding := false
+	for i := 0; i < int(dur/dt); i++ {
+		a.winds = 0
+		x := a.x
+		tree.SetTarget(target(), 0, 0, 0)
+		tree.Tick(a, dt)
+		if a.winds > 0 && !winding {
+			windups = append(windups, float32(i)*dt)
+		}
+		winding = a.winds > 0
+		// шаг рывка (3.5 скорости) много длиннее шага погони
+		if x-a.x > 2*a.speed*dt {
+			dashTicks++
+		}
+	}
+	return windups, dashTicks
+}
+
+// рывок ближника не обрывается, когда цель оказалась ближе in_range.min
+func TestMeleeChargeCompletes(t *testing.T) {
+	a := &testAgent{x: 200, speed: 80, hp: 100}
+	windups, dash := meleeRun(t, a, 2, func() float32 { return 0 })
+	if len(windups) != 1 {
+		t.Fatalf("замахи %v, want один", windups)
+	}
+	// 0.35 с рывка — около 21 тика; на 120 пикс он бы оборвался через 17
+	if dash < 20 {
+		t.Fatalf("рывок длился %d тиков, want ≥20", dash)
+	}
+}
+
+// следующий рывок — только через кулдаун после конца предыдущего
+func TestMeleeChargeCooldown(t *testing.T) {
+	a := &testAgent{x: 200, speed: 80, hp: 100}
+	// цель всё время в 200 пикс впереди — в зоне рывка
+	windups, _ := meleeRun(t, a, 8, func() float32 { return a.x - 200 })
+	if len(windups) != 2 {
+		t.Fatalf("замахи %v, want два", windups)
+	}
+	// замах 0.5 + рывок 0.35 + кулдаун 6
+	if gap := windups[1] - windups[0]; gap < 6.8 || gap > 7 {
+		t.Fatalf("между рывками %.2f с, want ≈6.85", gap)
+	}
+}
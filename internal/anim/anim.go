@@ -20,6 +20,8 @@ type Def struct {
 	FPS         float32  `json:"fps"`
 	Loop        bool     `json:"loop"`
 	Origin      [2]int32 `json:"origin"`
+	// события на кадрах: {"drop": 5} — на 6-м кадре сработает "drop"
+	Events map[string]int `json:"events"`
 }
 
 type Frame struct {
@@ -34,6 +36,7 @@ type Clip struct {
 	Loop   bool
 	Tex    rl.Texture2D
 	Frames []Frame
+	Events map[int]string // кадр -> имя события
 }
 
 type Animator struct {
@@ -41,6 +44,8 @@ type Animator struct {
 	Elapsed    float32
 	FrameIndex int
 	FlipX      bool
+
+	fired []string // события с прошлого TakeEvents
 }
 
 func (a *Animator) Play(c *Clip, reset bool) {
@@ -50,6 +55,23 @@ func (a *Animator) Play(c *Clip, reset bool) {
 	a.Current = c
 	a.Elapsed = 0
 	a.FrameIndex = 0
+	a.fire()
+}
+
+func (a *Animator) fire() {
+	if a.Current == nil {
+		return
+	}
+	if ev, ok := a.Current.Events[a.FrameIndex]; ok {
+		a.fired = append(a.fired, ev)
+	}
+}
+
+// TakeEvents отдаёт события кадров, сработавшие с прошлого вызова
+func (a *Animator) TakeEvents() []string {
+	out := a.fired
+	a.fired = nil
+	return out
 }
 
 func (a *Animator) Update(dt float32) {
@@ -60,6 +82,7 @@ func (a *Animator) Update(dt float32) {
 	dur := 1.0 / a.Current.FPS
 	for a.Elapsed >= dur {
 		a.Elapsed -= dur
+		prev := a.FrameIndex
 		a.FrameIndex++
 		if a.FrameIndex >= len(a.Current.Frames) {
 			if a.Current.Loop {
@@ -68,6 +91,9 @@ func (a *Animator) Update(dt float32) {
 				a.FrameIndex = len(a.Current.Frames) - 1
 			}
 		}
+		if a.FrameIndex != prev {
+			a.fire()
+		}
 	}
 }
 
@@ -151,7 +177,14 @@ func LoadFromJSON(jsonPath string) (*Clip, error) {
 		y += fh
 	}
 
-	return &Clip{Name: d.Name, FPS: ifnz(d.FPS, 10), Loop: d.Loop, Tex: tex, Frames: frames}, nil
+	clip := &Clip{Name: d.Name, FPS: ifnz(d.FPS, 10), Loop: d.Loop, Tex: tex, Frames: frames}
+	if len(d.Events) > 0 {
+		clip.Events = make(map[int]string, len(d.Events))
+		for name, f := range d.Events {
+			clip.Events[f] = name
+		}
+	}
+	return clip, nil
 }
 
 func ifnz(v, def float32) float32 {
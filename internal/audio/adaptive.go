@@ -0,0 +1,62 @@
+package audio
+
+// SetIntensity — насколько напряжена игра сейчас (0..1, см. Intensity).
+// Слои адаптивного трека подстроятся к ней на ближайшей границе долей.
+func (m *Manager) SetIntensity(v float32) { m.intensity = clamp01(v) }
+
+func (m *Manager) Intensity() float32 { return m.intensity }
+
+// layerFade — за сколько секунд слой входит/уходит у трека без темпа
+const layerFade = 1.0
+
+// latch фиксирует, к какой громкости едут слои при интенсивности i
+func (s *stream) latch(i float32) {
+	if len(s.def.Layers) == 0 {
+		for k := range s.want {
+			s.want[k] = 1
+		}
+		return
+	}
+	for k, l := range s.def.Layers {
+		s.want[k] = l.Gain(i)
+	}
+}
+
+// window — длина окна квантования в секундах; 0 — трек без темпа
+func (s *stream) window() float32 {
+	if s.def.BPM <= 0 {
+		return 0
+	}
+	return 60 / s.def.BPM * float32(s.def.Quantize)
+}
+
+// layers — раз в кадр: на границе окна берём новую интенсивность,
+// слои плавно едут к своим громкостям за одно окно
+func (m *Manager) layers(s *stream, dt float32) {
+	fade := float32(layerFade)
+	if w := s.window(); w <= 0 {
+		s.latch(m.intensity)
+	} else {
+		fade = w
+		for _, h := range s.hs {
+			if h < 0 {
+				continue
+			}
+			// все слои стартовали вместе — время любого годится
+			if b := int(m.be.MusicTime(h) / w); b != s.beat {
+				s.beat = b
+				s.latch(m.intensity)
+			}
+			break
+		}
+	}
+	step := dt / fade
+	for k := range s.mix {
+		switch {
+		case s.mix[k] < s.want[k]:
+			s.mix[k] = min(s.mix[k]+step, s.want[k])
+		case s.mix[k] > s.want[k]:
+			s.mix[k] = max(s.mix[k]-step, s.want[k])
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Package audio — звук игры: шины громкости, именованные звуки (cue) с разбросом
+// высоты и громкости и ограничением полифонии, музыка с кроссфейдами и приглушение
+// (ducking) под важные звуки.
+//
+// Сам пакет не знает про raylib: всё железо — за интерфейсом Backend
+// (боевой — audio/rlaudio), так что логику можно гонять без звуковой карты.
+package audio
+
+// Handle — номер звука или музыкального потока внутри бэкенда
+type Handle int32
+
+// Backend — то, что умеет настоящее аудиоустройство
+type Backend interface {
+	LoadSound(path string) (Handle, error)
+	// Alias — ещё один голос того же звука (общие сэмплы, своё воспроизведение)
+	Alias(h Handle) (Handle, error)
+	UnloadSound(h Handle)
+	PlaySound(h Handle, volume, pitch, pan float32)
+	StopSound(h Handle)
+	SoundPlaying(h Handle) bool
+
+	LoadMusic(path string) (Handle, error)
+	UnloadMusic(h Handle)
+	PlayMusic(h Handle) // с начала
+	StopMusic(h Handle)
+	UpdateMusic(h Handle)       // подкачка потока, раз в кадр
+	MusicTime(h Handle) float32 // сколько секунд проиграно с начала петли
+	SetMusicVolume(h Handle, volume float32)
+
+	SetMasterVolume(v float32)
+}
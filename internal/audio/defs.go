@@ -0,0 +1,161 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Bus — шина громкости; итог звука = громкость cue × шина × master
+type Bus int
+
+const (
+	BusMaster Bus = iota
+	BusMusic
+	BusSFX
+	BusUI
+	busCount
+)
+
+var busNames = map[string]Bus{"music": BusMusic, "sfx": BusSFX, "ui": BusUI}
+
+// CueDef — описание именованного звука в audio.json
+type CueDef struct {
+	Files     []string   `json:"files"` // пути от корня ассетов; вариант выбирается случайно
+	Bus       string     `json:"bus"`   // sfx (по умолчанию) | ui
+	Volume    float32    `json:"volume"`
+	VolJitter float32    `json:"vol_jitter"` // ± доля громкости
+	Pitch     [2]float32 `json:"pitch"`      // диапазон высоты; [0,0] — 1
+	Voices    int        `json:"voices"`     // одновременных копий; 0 — 1
+	Cooldown  float32    `json:"cooldown"`   // не чаще раза в N секунд
+	Duck      float32    `json:"duck"`       // на сколько приглушить музыку (0..1)
+	DuckTime  float32    `json:"duck_time"`  // сколько держать приглушение
+	Priority  int        `json:"priority"`   // при нехватке голосов первыми молкнут низкие
+	Falloff   *Falloff   `json:"falloff"`    // для PlayAt; nil — DefaultFalloff
+
+	bus Bus
+}
+
+// TrackDef — музыкальное состояние. Stream — имя другого состояния, чей поток
+// оно разделяет: переход между такими состояниями — только смена громкости,
+// без перезапуска (так пауза приглушает игровую музыку).
+// Вместо одного File трек может состоять из синхронных слоёв (Layers),
+// которые вступают и уходят по интенсивности (см. SetIntensity).
+type TrackDef struct {
+	File     string     `json:"file"`
+	Stream   string     `json:"stream"`
+	Volume   float32    `json:"volume"`
+	Layers   []LayerDef `json:"layers"`
+	BPM      float32    `json:"bpm"`      // темп: слои меняются только на долях; 0 — сразу
+	Quantize int        `json:"quantize"` // раз в сколько долей (4 — на такт); 0 — 1
+}
+
+// LayerDef — слой адаптивного трека. Громкость растёт от Floor на интенсивности
+// From до Volume на Full; Full <= From — слой включается сразу на From.
+// Floor > 0 — слой звучит и в затишье (база, которая громчает в бою).
+type LayerDef struct {
+	Name   string  `json:"name"`
+	File   string  `json:"file"`
+	Volume float32 `json:"volume"`
+	Floor  float32 `json:"floor"`
+	From   float32 `json:"from"`
+	Full   float32 `json:"full"`
+}
+
+// Gain — громкость слоя при интенсивности i
+func (l LayerDef) Gain(i float32) float32 {
+	if i < l.From {
+		return l.Floor
+	}
+	if l.Full <= l.From {
+		return l.Volume
+	}
+	return l.Floor + (l.Volume-l.Floor)*clamp01((i-l.From)/(l.Full-l.From))
+}
+
+func (t TrackDef) hasFiles() bool { return t.File != "" || len(t.Layers) > 0 }
+
+// Defs — содержимое audio.json
+type Defs struct {
+	Cues  map[string]CueDef   `json:"cues"`
+	Music map[string]TrackDef `json:"music"`
+}
+
+// LoadDefs читает и проверяет audio.json
+func LoadDefs(path string) (Defs, error) {
+	var d Defs
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return d, fmt.Errorf("%s: %w", path, err)
+	}
+	for name, c := range d.Cues {
+		c.bus = BusSFX
+		if c.Bus != "" {
+			b, ok := busNames[c.Bus]
+			if !ok || b == BusMusic {
+				return d, fmt.Errorf("%s: cue %q: неизвестная шина %q", path, name, c.Bus)
+			}
+			c.bus = b
+		}
+		if c.Volume == 0 {
+			c.Volume = 1
+		}
+		if c.Voices < 1 {
+			c.Voices = 1
+		}
+		if c.Pitch == [2]float32{} {
+			c.Pitch = [2]float32{1, 1}
+		}
+		if f := c.Falloff; f != nil {
+			switch f.Curve {
+			case "":
+				f.Curve = FalloffInverse
+			case FalloffLinear, FalloffInverse, FalloffSquared:
+			default:
+				return d, fmt.Errorf("%s: cue %q: неизвестная кривая %q", path, name, f.Curve)
+			}
+			if f.Max <= f.Min {
+				return d, fmt.Errorf("%s: cue %q: falloff max должен быть больше min", path, name)
+			}
+		}
+		d.Cues[name] = c
+	}
+	for name, t := range d.Music {
+		if !t.hasFiles() && t.Stream == "" {
+			return d, fmt.Errorf("%s: музыка %q: нужен file, layers или stream", path, name)
+		}
+		if t.File != "" && len(t.Layers) > 0 {
+			return d, fmt.Errorf("%s: музыка %q: file и layers вместе не бывают", path, name)
+		}
+		for i, l := range t.Layers {
+			if l.File == "" {
+				return d, fmt.Errorf("%s: музыка %q: у слоя %d нет file", path, name, i)
+			}
+			if l.Volume == 0 {
+				t.Layers[i].Volume = 1
+			}
+			if l.Floor < 0 || l.Floor > t.Layers[i].Volume {
+				return d, fmt.Errorf("%s: музыка %q: у слоя %d floor вне [0, volume]", path, name, i)
+			}
+		}
+		if t.BPM < 0 {
+			return d, fmt.Errorf("%s: музыка %q: bpm не может быть отрицательным", path, name)
+		}
+		if t.Quantize < 1 {
+			t.Quantize = 1
+		}
+		if t.Stream != "" {
+			if src, ok := d.Music[t.Stream]; !ok || !src.hasFiles() {
+				return d, fmt.Errorf("%s: музыка %q: stream %q должен ссылаться на трек с файлом", path, name, t.Stream)
+			}
+		}
+		if t.Volume == 0 {
+			t.Volume = 1
+		}
+		d.Music[name] = t
+	}
+	return d, nil
+}
@@ -0,0 +1,46 @@
+package audio
+
+// Combat — снимок боя для расчёта интенсивности музыки
+type Combat struct {
+	Enemies      int     // живых врагов рядом
+	RecentDamage float32 // сколько HP игрок потерял за последние секунды
+	HP, MaxHP    int
+	Boss         bool // идёт бой с боссом
+}
+
+// Веса интенсивности; сумма без босса — 1
+const (
+	intensityCrowd = 12 // столько врагов — полная «толпа»
+	weightCrowd    = 0.45
+	weightDamage   = 0.25
+	weightLowHP    = 0.30
+	lowHPFrom      = 0.5  // ниже этой доли HP начинается «опасность»
+	damageFull     = 0.25 // потеря такой доли MaxHP — полный вклад урона
+	bossFloor      = 0.6  // с боссом музыка не тише этого
+	bossBonus      = 0.2
+	damageFade     = 8 // недавний урон тает на столько HP в секунду
+)
+
+// FadeDamage — недавний урон через dt секунд: тает линейно до нуля
+func FadeDamage(recent, dt float32) float32 {
+	return max(recent-damageFade*dt, 0)
+}
+
+// Intensity — напряжённость боя 0..1: толпа врагов, недавний урон, низкое HP
+// и присутствие босса. Чистая функция — без состояния и случайности.
+func Intensity(c Combat) float32 {
+	crowd := min(float32(max(c.Enemies, 0))/intensityCrowd, 1)
+
+	var damage, danger float32
+	if c.MaxHP > 0 {
+		damage = clamp01(c.RecentDamage / (damageFull * float32(c.MaxHP)))
+		hp := clamp01(float32(c.HP) / float32(c.MaxHP))
+		danger = clamp01((lowHPFrom - hp) / lowHPFrom)
+	}
+
+	v := weightCrowd*crowd + weightDamage*damage + weightLowHP*danger
+	if c.Boss {
+		v = max(v+bossBonus, bossFloor)
+	}
+	return clamp01(v)
+}
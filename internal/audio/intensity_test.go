@@ -0,0 +1,146 @@
+package audio
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestIntensity(t *testing.T) {
+	full := Combat{HP: 100, MaxHP: 100}
+	for _, tc := range []struct {
+		name string
+		c    Combat
+		want float32
+	}{
+		{"пустой экран", full, 0},
+		{"без MaxHP", Combat{}, 0},
+		{"половина толпы", Combat{Enemies: 6, HP: 100, MaxHP: 100}, 0.225},
+		{"полная толпа", Combat{Enemies: 12, HP: 100, MaxHP: 100}, 0.45},
+		{"толпа сверх предела", Combat{Enemies: 500, HP: 100, MaxHP: 100}, 0.45},
+		{"отрицательные враги", Combat{Enemies: -3, HP: 100, MaxHP: 100}, 0},
+		{"HP ровно на пороге", Combat{HP: 50, MaxHP: 100}, 0},
+		{"четверть HP", Combat{HP: 25, MaxHP: 100}, 0.15},
+		{"при смерти", Combat{HP: 0, MaxHP: 100}, 0.30},
+		{"HP выше максимума", Combat{HP: 250, MaxHP: 100}, 0},
+		{"немного урона", Combat{RecentDamage: 12.5, HP: 100, MaxHP: 100}, 0.125},
+		{"урон сверх предела", Combat{RecentDamage: 1000, HP: 100, MaxHP: 100}, 0.25},
+		{"босс на пустом экране", Combat{HP: 100, MaxHP: 100, Boss: true}, 0.6},
+		{"босс поднимает бой", Combat{Enemies: 12, HP: 100, MaxHP: 100, Boss: true}, 0.65},
+		{"всё сразу — зажато в 1", Combat{Enemies: 50, RecentDamage: 100, HP: 0, MaxHP: 100, Boss: true}, 1},
+		{"всё сразу без босса", Combat{Enemies: 50, RecentDamage: 100, HP: 0, MaxHP: 100}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Intensity(tc.c)
+			if !near(got, tc.want) {
+				t.Fatalf("Intensity = %.3f, want %.3f", got, tc.want)
+			}
+			if got < 0 || got > 1 {
+				t.Fatalf("Intensity = %.3f вне [0,1]", got)
+			}
+		})
+	}
+}
+
+// недавний урон тает, и интенсивность спадает вместе с ним
+func TestDamageDecay(t *testing.T) {
+	c := Combat{RecentDamage: 25, HP: 100, MaxHP: 100}
+	if got := Intensity(c); !near(got, 0.25) {
+		t.Fatalf("сразу после удара %.3f, want 0.25", got)
+	}
+	if got := FadeDamage(20, 1); !near(got, 12) {
+		t.Fatalf("FadeDamage(20, 1) = %.3f, want 12", got)
+	}
+	const dt = float32(1) / 60
+	prev := Intensity(c)
+	for i := 0; i < 60*4; i++ {
+		c.RecentDamage = FadeDamage(c.RecentDamage, dt)
+		if c.RecentDamage < 0 {
+			t.Fatalf("урон ушёл в минус: %.3f", c.RecentDamage)
+		}
+		got := Intensity(c)
+		if got > prev {
+			t.Fatalf("кадр %d: интенсивность выросла %.3f → %.3f", i, prev, got)
+		}
+		prev = got
+	}
+	// 25 HP тают за 25/8 с — к четырём секундам следа нет
+	if c.RecentDamage != 0 || prev != 0 {
+		t.Fatalf("через 4 с урон %.3f, интенсивность %.3f", c.RecentDamage, prev)
+	}
+}
+
+func TestLayerGain(t *testing.T) {
+	lead := LayerDef{Volume: 0.8, From: 0.4, Full: 0.8}
+	base := LayerDef{Volume: 1, Floor: 0.5, From: 0.2, Full: 0.6}
+	step := LayerDef{Volume: 0.6, From: 0.5}
+	for _, tc := range []struct {
+		name string
+		l    LayerDef
+		i    float32
+		want float32
+	}{
+		{"слой молчит до from", lead, 0.3, 0},
+		{"середина нарастания", lead, 0.6, 0.4},
+		{"после full — volume", lead, 1, 0.8},
+		{"база в затишье — floor", base, 0, 0.5},
+		{"база на середине", base, 0.4, 0.75},
+		{"база в бою — volume", base, 0.9, 1},
+		{"без full — ступенька", step, 0.49, 0},
+		{"без full — сразу volume", step, 0.5, 0.6},
+	} {
+		if got := tc.l.Gain(tc.i); !near(got, tc.want) {
+			t.Errorf("%s: Gain(%.2f) = %.3f, want %.3f", tc.name, tc.i, got, tc.want)
+		}
+	}
+}
+
+// игровой трек из audio.json реагирует на интенсивность, а не стоит на месте
+func TestShippedGameTrackReacts(t *testing.T) {
+	d, err := LoadDefs(filepath.Join("..", "..", "assets", "data", "audio.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, ok := d.Music["game"]
+	if !ok || len(game.Layers) == 0 {
+		t.Fatal("в audio.json нет слоёв у game")
+	}
+	mix := func(i float32) (sum float32) {
+		for _, l := range game.Layers {
+			sum += l.Gain(i)
+		}
+		return sum
+	}
+	if calm, fight := mix(0), mix(1); calm <= 0 || fight <= calm+0.2 {
+		t.Fatalf("громкость слоёв: затишье %.2f, бой %.2f", calm, fight)
+	}
+}
+
+// адаптивный трек: слои идут за интенсивностью только на границе такта
+func TestLayersFollowIntensity(t *testing.T) {
+	m, be := setup(t, `{"music": {"game": {"volume": 1, "bpm": 120, "quantize": 4, "layers": [
+		{"name": "base", "file": "base.mp3"},
+		{"name": "lead", "file": "lead.mp3", "from": 0.5, "full": 1}]}}}`)
+	base, lead := be.track("base.mp3"), be.track("lead.mp3")
+	m.PlayMusic("game", 0)
+	step := func(seconds float32) {
+		const dt = float32(1) / 100
+		for n := int(seconds/dt + 0.5); n > 0; n-- {
+			be.advance(dt)
+			m.Update(dt)
+		}
+	}
+	step(0.5)
+	if !near(base.volume, 1) || lead.volume != 0 {
+		t.Fatalf("тихо: base %.3f, lead %.3f", base.volume, lead.volume)
+	}
+	// такт — 2 с при 120 bpm; до его конца слой не вступает
+	m.SetIntensity(1)
+	step(1)
+	if lead.volume != 0 {
+		t.Fatalf("слой вступил до границы такта: %.3f", lead.volume)
+	}
+	step(1 + 2 + 0.1)
+	if !near(lead.volume, 1) {
+		t.Fatalf("после такта lead %.3f, want 1", lead.volume)
+	}
+}
@@ -0,0 +1,333 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+)
+
+// voice — одна копия звука (оригинал или alias) и когда её запустили
+type voice struct {
+	h       Handle
+	started float64
+}
+
+type cue struct {
+	def    CueDef
+	voices [][]voice // [вариант][копия]
+	last   float64   // когда играл в последний раз
+}
+
+// stream — загруженный музыкальный трек: один поток или несколько синхронных
+// слоёв. Общая громкость плавно едет к target, громкость слоёв — к want.
+type stream struct {
+	hs      []Handle
+	mix     []float32 // текущая громкость слоёв
+	want    []float32 // к чему едут слои (фиксируется на долях)
+	def     TrackDef
+	beat    int // номер последнего окна квантования
+	gain    float32
+	target  float32
+	rate    float32 // изменение gain в секунду
+	playing bool
+}
+
+// Manager — единая точка для всего звука игры
+type Manager struct {
+	be    Backend
+	buses [busCount]float32
+	cues  map[string]*cue
+	music map[string]TrackDef
+	strs  map[string]*stream // по имени трека-владельца файла
+	track string             // текущее музыкальное состояние
+
+	ear  listener
+	live []playing // звучащие голоса — для бюджета MaxVoices
+
+	intensity float32 // 0..1 — для слоёв адаптивной музыки
+
+	duck      float32 // текущий множитель музыки от приглушения (1 — нет)
+	duckDepth float32
+	duckHold  float32
+
+	now float64
+	rng *rand.Rand
+}
+
+// duckRelease — за сколько секунд музыка возвращается после приглушения
+const duckRelease = 0.4
+
+func New(be Backend, seed int64) *Manager {
+	m := &Manager{
+		be:    be,
+		cues:  map[string]*cue{},
+		music: map[string]TrackDef{},
+		strs:  map[string]*stream{},
+		duck:  1,
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+	for i := range m.buses {
+		m.buses[i] = 1
+	}
+	return m
+}
+
+// Load загружает звуки и музыку из defs; root — корень ассетов.
+// Отсутствующий файл не фатален: cue/трек просто молчит, ошибки собираются.
+func (m *Manager) Load(root string, d Defs) error {
+	var errs []error
+	for name, def := range d.Cues {
+		c := &cue{def: def, last: -1e9}
+		for _, f := range def.Files {
+			base, err := m.be.LoadSound(filepath.Join(root, f))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cue %q: %w", name, err))
+				continue
+			}
+			vs := []voice{{h: base}}
+			for i := 1; i < def.Voices; i++ {
+				a, err := m.be.Alias(base)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("cue %q: alias: %w", name, err))
+					break
+				}
+				vs = append(vs, voice{h: a})
+			}
+			c.voices = append(c.voices, vs)
+		}
+		m.cues[name] = c
+	}
+	for name, t := range d.Music {
+		m.music[name] = t
+		files := []string{t.File}
+		if len(t.Layers) > 0 {
+			files = files[:0]
+			for _, l := range t.Layers {
+				files = append(files, l.File)
+			}
+		} else if t.File == "" {
+			continue
+		}
+		s := &stream{def: t}
+		for _, f := range files {
+			h, err := m.be.LoadMusic(filepath.Join(root, f))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("music %q: %w", name, err))
+				h = -1 // слой молчит, но индексы слоёв не съезжают
+			}
+			s.hs = append(s.hs, h)
+		}
+		s.mix = make([]float32, len(s.hs))
+		s.want = make([]float32, len(s.hs))
+		m.strs[name] = s
+	}
+	return errors.Join(errs...)
+}
+
+// Unload освобождает всё загруженное
+func (m *Manager) Unload() {
+	for _, c := range m.cues {
+		for _, vs := range c.voices {
+			for _, v := range vs {
+				m.be.UnloadSound(v.h)
+			}
+		}
+	}
+	for _, s := range m.strs {
+		if s.playing {
+			s.each(m.be.StopMusic)
+		}
+		s.each(m.be.UnloadMusic)
+	}
+	m.cues, m.strs, m.live = map[string]*cue{}, map[string]*stream{}, nil
+}
+
+// SetBus — громкость шины 0..1 (Master уходит прямо в устройство)
+func (m *Manager) SetBus(b Bus, v float32) {
+	m.buses[b] = clamp01(v)
+	if b == BusMaster {
+		m.be.SetMasterVolume(m.buses[b])
+	}
+	m.applyMusic()
+}
+
+func (m *Manager) Bus(b Bus) float32 { return m.buses[b] }
+
+// Play запускает cue без позиции; false — нет такого, нечего играть,
+// рано (cooldown) или не нашлось голоса в бюджете MaxVoices
+func (m *Manager) Play(name string) bool { return m.play(name, 1, 0) }
+
+// play — с дополнительным множителем громкости и панорамой (-1 лево … 1 право)
+func (m *Manager) play(name string, gain, pan float32) bool {
+	c, ok := m.cues[name]
+	if !ok || len(c.voices) == 0 {
+		return false
+	}
+	d := &c.def
+	if m.now-c.last < float64(d.Cooldown) {
+		return false
+	}
+
+	vs := c.voices[m.rng.Intn(len(c.voices))]
+	// свободный голос, а если все заняты — самый старый (обрываем его)
+	pick := 0
+	for i, v := range vs {
+		if !m.be.SoundPlaying(v.h) {
+			pick = i
+			break
+		}
+		if v.started < vs[pick].started {
+			pick = i
+		}
+	}
+	v := &vs[pick]
+
+	vol := d.Volume * (1 + d.VolJitter*(2*m.rng.Float32()-1))
+	vol = clamp01(vol * gain * m.buses[d.bus])
+	if m.be.SoundPlaying(v.h) {
+		// свой же голос обрываем — общее число звуков не растёт
+		m.be.StopSound(v.h)
+		m.forget(v.h)
+	} else if !m.admit(d.Priority, vol) {
+		return false
+	}
+	c.last = m.now
+	v.started = m.now
+
+	pitch := d.Pitch[0] + (d.Pitch[1]-d.Pitch[0])*m.rng.Float32()
+	m.be.PlaySound(v.h, vol, pitch, pan)
+	m.live = append(m.live, playing{h: v.h, priority: d.Priority, gain: vol})
+
+	if d.Duck > 0 {
+		m.duckDepth = max(m.duckDepth, d.Duck)
+		m.duckHold = max(m.duckHold, d.DuckTime)
+	}
+	return true
+}
+
+func (m *Manager) forget(h Handle) {
+	for i, p := range m.live {
+		if p.h == h {
+			m.live = append(m.live[:i], m.live[i+1:]...)
+			return
+		}
+	}
+}
+
+// PlayMusic плавно переводит музыку в состояние name за fade секунд.
+// Состояния с общим потоком меняют только громкость; остальные потоки
+// затухают и останавливаются, новый стартует с начала.
+func (m *Manager) PlayMusic(name string, fade float32) {
+	t, ok := m.music[name]
+	if !ok {
+		return
+	}
+	m.track = name
+	owner := name
+	if t.Stream != "" {
+		owner = t.Stream
+	}
+	for n, s := range m.strs {
+		target := float32(0)
+		if n == owner {
+			target = t.Volume
+			if !s.playing {
+				s.each(m.be.PlayMusic)
+				s.playing, s.gain, s.beat = true, 0, 0
+				// слои стартуют сразу в нужном сочетании
+				s.latch(m.intensity)
+				copy(s.mix, s.want)
+			}
+		}
+		m.fadeTo(s, target, fade)
+	}
+}
+
+// StopMusic — всё затихает за fade секунд
+func (m *Manager) StopMusic(fade float32) {
+	m.track = ""
+	for _, s := range m.strs {
+		m.fadeTo(s, 0, fade)
+	}
+}
+
+// Music — текущее музыкальное состояние ("" — тишина)
+func (m *Manager) Music() string { return m.track }
+
+func (m *Manager) fadeTo(s *stream, target, fade float32) {
+	s.target = target
+	if fade <= 0 {
+		s.gain = target
+		s.rate = 0
+		return
+	}
+	s.rate = abs(target-s.gain) / fade
+}
+
+// Update — раз в кадр: кроссфейды, приглушение, подкачка потоков
+func (m *Manager) Update(dt float32) {
+	m.now += float64(dt)
+
+	// приглушение: держим, потом отпускаем
+	if m.duckHold > 0 {
+		m.duckHold -= dt
+		m.duck = 1 - m.duckDepth
+	} else if m.duck < 1 {
+		m.duck = min(m.duck+dt/duckRelease, 1)
+		if m.duck == 1 {
+			m.duckDepth = 0
+		}
+	}
+
+	for _, s := range m.strs {
+		if !s.playing {
+			continue
+		}
+		switch {
+		case s.gain < s.target:
+			s.gain = min(s.gain+s.rate*dt, s.target)
+		case s.gain > s.target:
+			s.gain = max(s.gain-s.rate*dt, s.target)
+		}
+		if s.gain <= 0 && s.target <= 0 {
+			s.each(m.be.StopMusic)
+			s.playing = false
+			continue
+		}
+		s.each(m.be.UpdateMusic)
+		m.layers(s, dt)
+	}
+	m.applyMusic()
+}
+
+func (m *Manager) applyMusic() {
+	for _, s := range m.strs {
+		if !s.playing {
+			continue
+		}
+		for i, h := range s.hs {
+			if h >= 0 {
+				m.be.SetMusicVolume(h, s.gain*s.mix[i]*m.buses[BusMusic]*m.duck)
+			}
+		}
+	}
+}
+
+// each вызывает f для всех загруженных потоков трека
+func (s *stream) each(f func(Handle)) {
+	for _, h := range s.hs {
+		if h >= 0 {
+			f(h)
+		}
+	}
+}
+
+func clamp01(v float32) float32 { return min(max(v, 0), 1) }
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,289 @@
+package audio
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setup грузит audio.json из строки в менеджер с поддельным бэкендом
+func setup(t *testing.T, js string) (*Manager, *mockBackend) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audio.json")
+	if err := os.WriteFile(path, []byte(js), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := LoadDefs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	be := newMock()
+	m := New(be, 1)
+	if err := m.Load("root", d); err != nil {
+		t.Fatal(err)
+	}
+	return m, be
+}
+
+func near(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-3 }
+
+func TestPolyphonyRetriggersOldest(t *testing.T) {
+	m, be := setup(t, `{"cues": {"hit": {"files": ["hit.wav"], "voices": 2}}}`)
+	for i := 0; i < 3; i++ {
+		if !m.Play("hit") {
+			t.Fatalf("вызов %d отклонён", i)
+		}
+		m.Update(0.01)
+	}
+	// два голоса: третий вызов обрывает самый старый, а не добавляет третий
+	if len(be.sounds) != 2 {
+		t.Fatalf("загружено голосов %d, want 2 (оригинал + alias)", len(be.sounds))
+	}
+	if n := be.playing(); n != 2 {
+		t.Fatalf("звучит %d, want 2", n)
+	}
+	first := be.sounds[1]
+	if first.plays != 2 || first.stops != 1 {
+		t.Fatalf("старший голос: запусков %d, остановок %d; want 2 и 1", first.plays, first.stops)
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	m, _ := setup(t, `{"cues": {"hit": {"files": ["hit.wav"], "voices": 4, "cooldown": 0.1}}}`)
+	if !m.Play("hit") || m.Play("hit") {
+		t.Fatal("второй вызов в том же кадре прошёл сквозь cooldown")
+	}
+	m.Update(0.05)
+	if m.Play("hit") {
+		t.Fatal("прошёл раньше cooldown")
+	}
+	m.Update(0.06)
+	if !m.Play("hit") {
+		t.Fatal("не играет после cooldown")
+	}
+}
+
+func TestVariationBounds(t *testing.T) {
+	m, be := setup(t, `{"cues": {"step": {"files": ["a.wav", "b.wav"], "volume": 0.5, "vol_jitter": 0.2, "pitch": [0.9, 1.1]}}}`)
+	lo, hi := float32(1), float32(0)
+	plo, phi := float32(2), float32(0)
+	files := map[string]bool{}
+	for i := 0; i < 500; i++ {
+		m.Play("step")
+		for _, s := range be.sounds {
+			if !s.playing {
+				continue
+			}
+			files[s.path] = true
+			if s.volume < 0.4-1e-4 || s.volume > 0.6+1e-4 {
+				t.Fatalf("громкость %.3f вне 0.5±20%%", s.volume)
+			}
+			if s.pitch < 0.9 || s.pitch > 1.1 {
+				t.Fatalf("высота %.3f вне [0.9, 1.1]", s.pitch)
+			}
+			lo, hi = min(lo, s.volume), max(hi, s.volume)
+			plo, phi = min(plo, s.pitch), max(phi, s.pitch)
+		}
+		be.finish()
+	}
+	// разброс действительно есть и покрывает диапазон
+	if hi-lo < 0.15 || phi-plo < 0.15 {
+		t.Fatalf("разброс мал: громкость [%.3f, %.3f], высота [%.3f, %.3f]", lo, hi, plo, phi)
+	}
+	if len(files) != 2 {
+		t.Fatalf("варианты: %v, want оба файла", files)
+	}
+}
+
+func TestBuses(t *testing.T) {
+	m, be := setup(t, `{"cues": {
+		"hit":   {"files": ["hit.wav"], "volume": 0.8},
+		"click": {"files": ["click.wav"], "bus": "ui", "volume": 0.8}}}`)
+	m.SetBus(BusSFX, 0.5)
+	m.SetBus(BusMaster, 0.3)
+	m.Play("hit")
+	m.Play("click")
+	for _, s := range be.sounds {
+		want := float32(0.8)
+		if s.path == filepath.Join("root", "hit.wav") {
+			want = 0.4
+		}
+		if !near(s.volume, want) {
+			t.Errorf("%s: громкость %.3f, want %.3f", s.path, s.volume, want)
+		}
+	}
+	// master уходит в устройство, а не в каждый звук
+	if be.master != 0.3 {
+		t.Fatalf("master %.2f, want 0.3", be.master)
+	}
+	m.SetBus(BusSFX, 7)
+	if m.Bus(BusSFX) != 1 {
+		t.Fatalf("шина не зажата в [0,1]: %.2f", m.Bus(BusSFX))
+	}
+}
+
+func TestVoiceBudget(t *testing.T) {
+	js := `{"cues": {"low": {"files": ["low.wav"], "voices": 24, "volume": 0.5},
+		"quiet": {"files": ["quiet.wav"], "volume": 0.1},
+		"high": {"files": ["high.wav"], "priority": 5}}}`
+	m, be := setup(t, js)
+	for i := 0; i < MaxVoices; i++ {
+		m.Play("low")
+	}
+	if be.playing() != MaxVoices {
+		t.Fatalf("звучит %d, want %d", be.playing(), MaxVoices)
+	}
+	// тот же приоритет, но тише — не вытесняет
+	if m.Play("quiet") {
+		t.Fatal("тихий звук того же приоритета вытеснил голос")
+	}
+	// важный вытесняет одного из неважных
+	if !m.Play("high") || be.playing() != MaxVoices {
+		t.Fatalf("важный звук: звучит %d", be.playing())
+	}
+	// доигравшие освобождают бюджет
+	be.finish()
+	if !m.Play("quiet") {
+		t.Fatal("после тишины звук не запустился")
+	}
+}
+
+func TestMissingFileIsSilent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audio.json")
+	os.WriteFile(path, []byte(`{"cues": {"hit": {"files": ["missing.wav"]}}, "music": {"menu": {"file": "missing.mp3"}}}`), 0o644)
+	d, err := LoadDefs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(newMock(), 1)
+	if err := m.Load("root", d); err == nil {
+		t.Fatal("нет ошибки про отсутствующие файлы")
+	}
+	if m.Play("hit") || m.Play("nope") {
+		t.Fatal("молчащий cue сыграл")
+	}
+	m.PlayMusic("menu", 0)
+	m.Update(0.1) // без паники на потоке -1
+}
+
+const musicJSON = `{"music": {
+	"menu":  {"file": "menu.mp3", "volume": 0.8},
+	"game":  {"file": "game.mp3", "volume": 0.6},
+	"pause": {"stream": "game", "volume": 0.2}},
+	"cues": {"ult": {"files": ["ult.wav"], "duck": 0.5, "duck_time": 1}}}`
+
+func run(m *Manager, seconds float32) {
+	const dt = float32(1) / 100
+	for n := int(seconds/dt + 0.5); n > 0; n-- {
+		m.Update(dt)
+	}
+}
+
+func TestCrossfade(t *testing.T) {
+	m, be := setup(t, musicJSON)
+	menu, game := be.track("menu.mp3"), be.track("game.mp3")
+
+	m.PlayMusic("menu", 0)
+	m.Update(0.01)
+	if !menu.playing || !near(menu.volume, 0.8) {
+		t.Fatalf("меню: играет %v, громкость %.3f", menu.playing, menu.volume)
+	}
+
+	m.PlayMusic("game", 1)
+	run(m, 0.5)
+	if !near(menu.volume, 0.4) || !near(game.volume, 0.3) {
+		t.Fatalf("середина кроссфейда: меню %.3f, игра %.3f; want 0.4 и 0.3", menu.volume, game.volume)
+	}
+	run(m, 0.6)
+	if menu.playing || !near(game.volume, 0.6) {
+		t.Fatalf("после кроссфейда: меню играет %v, игра %.3f", menu.playing, game.volume)
+	}
+	if m.Music() != "game" {
+		t.Fatalf("состояние %q", m.Music())
+	}
+
+	// пауза делит поток с игрой: только громкость, без перезапуска
+	m.PlayMusic("pause", 0.5)
+	run(m, 0.6)
+	if game.plays != 1 || !game.playing || !near(game.volume, 0.2) {
+		t.Fatalf("пауза: запусков %d, играет %v, громкость %.3f", game.plays, game.playing, game.volume)
+	}
+
+	m.StopMusic(0.2)
+	run(m, 0.3)
+	if game.playing || m.Music() != "" {
+		t.Fatal("StopMusic не остановил поток")
+	}
+}
+
+func TestMusicBus(t *testing.T) {
+	m, be := setup(t, musicJSON)
+	m.PlayMusic("game", 0)
+	m.SetBus(BusMusic, 0.5)
+	if g := be.track("game.mp3"); !near(g.volume, 0.3) {
+		t.Fatalf("громкость %.3f, want 0.3 (0.6 × шина 0.5)", g.volume)
+	}
+}
+
+func TestDucking(t *testing.T) {
+	m, be := setup(t, musicJSON)
+	game := be.track("game.mp3")
+	m.PlayMusic("game", 0)
+	m.Update(0.01)
+
+	m.Play("ult")
+	m.Update(0.01)
+	if !near(game.volume, 0.3) {
+		t.Fatalf("под приглушением %.3f, want 0.3", game.volume)
+	}
+	// держится duck_time
+	run(m, 0.9)
+	if !near(game.volume, 0.3) {
+		t.Fatalf("приглушение отпустило раньше: %.3f", game.volume)
+	}
+	// затем за duckRelease возвращается
+	run(m, 0.1+duckRelease/2)
+	if game.volume <= 0.3 || game.volume >= 0.6 {
+		t.Fatalf("на отпускании %.3f, want между 0.3 и 0.6", game.volume)
+	}
+	run(m, duckRelease)
+	if !near(game.volume, 0.6) {
+		t.Fatalf("после отпускания %.3f, want 0.6", game.volume)
+	}
+}
+
+func TestPlayAt(t *testing.T) {
+	m, be := setup(t, `{"cues": {"boom": {"files": ["boom.wav"], "falloff": {"curve": "linear", "min": 100, "max": 300}}}}`)
+	m.SetListener(0, 0, 200)
+	s := be.sounds[1]
+
+	if !m.PlayAt("boom", 50, 0) || !near(s.volume, 1) {
+		t.Fatalf("вблизи: громкость %.3f, want 1", s.volume)
+	}
+	be.finish()
+	if !m.PlayAt("boom", -200, 0) || !near(s.volume, 0.5) || !near(s.pan, -1) {
+		t.Fatalf("слева на 200: громкость %.3f, панорама %.2f; want 0.5 и -1", s.volume, s.pan)
+	}
+	be.finish()
+	if m.PlayAt("boom", 0, 400) {
+		t.Fatal("за Max звук запустился")
+	}
+}
+
+func TestFalloffCurves(t *testing.T) {
+	for _, curve := range []string{FalloffLinear, FalloffInverse, FalloffSquared} {
+		f := Falloff{Curve: curve, Min: 100, Max: 500}
+		prev := float32(1)
+		for d := float32(0); d <= 600; d += 25 {
+			g := f.Gain(d)
+			if g < 0 || g > 1 || g > prev+1e-6 {
+				t.Fatalf("%s: Gain(%.0f) = %.3f (пред. %.3f)", curve, d, g, prev)
+			}
+			prev = g
+		}
+		if f.Gain(100) != 1 || f.Gain(500) != 0 {
+			t.Fatalf("%s: края %.3f и %.3f", curve, f.Gain(100), f.Gain(500))
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package audio
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mockSound — что бэкенд знает о голосе
+type mockSound struct {
+	path    string
+	playing bool
+	plays   int
+	stops   int
+	volume  float32
+	pitch   float32
+	pan     float32
+}
+
+type mockMusic struct {
+	path    string
+	playing bool
+	plays   int
+	volume  float32
+	time    float32
+}
+
+// mockBackend — Backend без устройства: запоминает вызовы. Файлы с "missing"
+// в пути не загружаются; звуки звучат, пока тест не вызовет finish.
+type mockBackend struct {
+	next   Handle
+	sounds map[Handle]*mockSound
+	music  map[Handle]*mockMusic
+	master float32
+}
+
+func newMock() *mockBackend {
+	return &mockBackend{sounds: map[Handle]*mockSound{}, music: map[Handle]*mockMusic{}, master: 1}
+}
+
+func (b *mockBackend) handle() Handle {
+	b.next++
+	return b.next
+}
+
+func (b *mockBackend) LoadSound(path string) (Handle, error) {
+	if strings.Contains(path, "missing") {
+		return -1, fmt.Errorf("no such file %s", path)
+	}
+	h := b.handle()
+	b.sounds[h] = &mockSound{path: path}
+	return h, nil
+}
+
+func (b *mockBackend) Alias(h Handle) (Handle, error) {
+	s, ok := b.sounds[h]
+	if !ok {
+		return -1, fmt.Errorf("alias of unknown %d", h)
+	}
+	a := b.handle()
+	b.sounds[a] = &mockSound{path: s.path}
+	return a, nil
+}
+
+func (b *mockBackend) UnloadSound(h Handle) { delete(b.sounds, h) }
+
+func (b *mockBackend) PlaySound(h Handle, volume, pitch, pan float32) {
+	s := b.sounds[h]
+	s.playing = true
+	s.plays++
+	s.volume, s.pitch, s.pan = volume, pitch, pan
+}
+
+func (b *mockBackend) StopSound(h Handle) {
+	s := b.sounds[h]
+	s.playing = false
+	s.stops++
+}
+
+func (b *mockBackend) SoundPlaying(h Handle) bool { return b.sounds[h].playing }
+
+func (b *mockBackend) LoadMusic(path string) (Handle, error) {
+	if strings.Contains(path, "missing") {
+		return -1, fmt.Errorf("no such file %s", path)
+	}
+	h := b.handle()
+	b.music[h] = &mockMusic{path: path}
+	return h, nil
+}
+
+func (b *mockBackend) UnloadMusic(h Handle) { delete(b.music, h) }
+
+func (b *mockBackend) PlayMusic(h Handle) {
+	m := b.music[h]
+	m.playing, m.time = true, 0
+	m.plays++
+}
+
+func (b *mockBackend) StopMusic(h Handle)                      { b.music[h].playing = false }
+func (b *mockBackend) UpdateMusic(Handle)                      {}
+func (b *mockBackend) MusicTime(h Handle) float32              { return b.music[h].time }
+func (b *mockBackend) SetMusicVolume(h Handle, volume float32) { b.music[h].volume = volume }
+func (b *mockBackend) SetMasterVolume(v float32)               { b.master = v }
+
+// finish — все звуки доиграли
+func (b *mockBackend) finish() {
+	for _, s := range b.sounds {
+		s.playing = false
+	}
+}
+
+// playing — сколько голосов звучит
+func (b *mockBackend) playing() int {
+	n := 0
+	for _, s := range b.sounds {
+		if s.playing {
+			n++
+		}
+	}
+	return n
+}
+
+// track — поток по пути файла
+func (b *mockBackend) track(path string) *mockMusic {
+	for _, m := range b.music {
+		if strings.HasSuffix(m.path, path) {
+			return m
+		}
+	}
+	return nil
+}
+
+// advance двигает время всех играющих потоков (для долей адаптивной музыки)
+func (b *mockBackend) advance(dt float32) {
+	for _, m := range b.music {
+		if m.playing {
+			m.time += dt
+		}
+	}
+}
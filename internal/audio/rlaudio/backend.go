@@ -0,0 +1,135 @@
+// Package rlaudio — audio.Backend поверх raylib
+package rlaudio
+
+import (
+	"fmt"
+	"os"
+
+	"example.com/my2dgame/internal/audio"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Backend хранит звуки и потоки raylib по номерам audio.Handle.
+// Аудиоустройство должно быть открыто (rl.InitAudioDevice) до загрузки.
+type Backend struct {
+	sounds  []rl.Sound
+	aliases map[audio.Handle]bool
+	music   []rl.Music
+}
+
+func New() *Backend {
+	return &Backend{aliases: map[audio.Handle]bool{}}
+}
+
+func (b *Backend) LoadSound(path string) (audio.Handle, error) {
+	if _, err := os.Stat(path); err != nil {
+		return -1, err
+	}
+	s := rl.LoadSound(path)
+	if !rl.IsSoundValid(s) {
+		return -1, fmt.Errorf("не удалось загрузить звук %s", path)
+	}
+	b.sounds = append(b.sounds, s)
+	return audio.Handle(len(b.sounds) - 1), nil
+}
+
+func (b *Backend) Alias(h audio.Handle) (audio.Handle, error) {
+	if !b.validSound(h) {
+		return -1, fmt.Errorf("alias: нет звука %d", h)
+	}
+	b.sounds = append(b.sounds, rl.LoadSoundAlias(b.sounds[h]))
+	a := audio.Handle(len(b.sounds) - 1)
+	b.aliases[a] = true
+	return a, nil
+}
+
+func (b *Backend) validSound(h audio.Handle) bool { return h >= 0 && int(h) < len(b.sounds) }
+
+func (b *Backend) UnloadSound(h audio.Handle) {
+	if !b.validSound(h) {
+		return
+	}
+	if b.aliases[h] {
+		rl.UnloadSoundAlias(b.sounds[h])
+	} else {
+		rl.UnloadSound(b.sounds[h])
+	}
+	b.sounds[h] = rl.Sound{}
+}
+
+func (b *Backend) PlaySound(h audio.Handle, volume, pitch, pan float32) {
+	if !b.validSound(h) {
+		return
+	}
+	s := b.sounds[h]
+	rl.SetSoundVolume(s, volume)
+	rl.SetSoundPitch(s, pitch)
+	// у raylib 0.5 — центр, 1 — левый край
+	rl.SetSoundPan(s, 0.5-pan/2)
+	rl.PlaySound(s)
+}
+
+func (b *Backend) StopSound(h audio.Handle) {
+	if b.validSound(h) {
+		rl.StopSound(b.sounds[h])
+	}
+}
+
+func (b *Backend) SoundPlaying(h audio.Handle) bool {
+	return b.validSound(h) && rl.IsSoundPlaying(b.sounds[h])
+}
+
+func (b *Backend) LoadMusic(path string) (audio.Handle, error) {
+	if _, err := os.Stat(path); err != nil {
+		return -1, err
+	}
+	m := rl.LoadMusicStream(path)
+	if !rl.IsMusicValid(m) {
+		return -1, fmt.Errorf("не удалось загрузить музыку %s", path)
+	}
+	m.Looping = true
+	b.music = append(b.music, m)
+	return audio.Handle(len(b.music) - 1), nil
+}
+
+func (b *Backend) validMusic(h audio.Handle) bool { return h >= 0 && int(h) < len(b.music) }
+
+func (b *Backend) UnloadMusic(h audio.Handle) {
+	if b.validMusic(h) {
+		rl.UnloadMusicStream(b.music[h])
+		b.music[h] = rl.Music{}
+	}
+}
+
+func (b *Backend) PlayMusic(h audio.Handle) {
+	if b.validMusic(h) {
+		rl.PlayMusicStream(b.music[h])
+	}
+}
+
+func (b *Backend) StopMusic(h audio.Handle) {
+	if b.validMusic(h) {
+		rl.StopMusicStream(b.music[h])
+	}
+}
+
+func (b *Backend) UpdateMusic(h audio.Handle) {
+	if b.validMusic(h) {
+		rl.UpdateMusicStream(b.music[h])
+	}
+}
+
+func (b *Backend) MusicTime(h audio.Handle) float32 {
+	if b.validMusic(h) {
+		return rl.GetMusicTimePlayed(b.music[h])
+	}
+	return 0
+}
+
+func (b *Backend) SetMusicVolume(h audio.Handle, volume float32) {
+	if b.validMusic(h) {
+		rl.SetMusicVolume(b.music[h], volume)
+	}
+}
+
+func (b *Backend) SetMasterVolume(v float32) { rl.SetMasterVolume(v) }
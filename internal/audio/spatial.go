@@ -0,0 +1,134 @@
+package audio
+
+import "math"
+
+// Кривые затухания с расстоянием
+const (
+	FalloffLinear  = "linear"  // ровно от min до max
+	FalloffInverse = "inverse" // как в жизни: громко вблизи, длинный хвост
+	FalloffSquared = "squared" // быстро гаснет к краю
+)
+
+// Falloff — как звук тише с расстоянием: до Min — полная громкость, за Max — тишина
+type Falloff struct {
+	Curve string  `json:"curve"`
+	Min   float32 `json:"min"`
+	Max   float32 `json:"max"`
+}
+
+// DefaultFalloff — для cue без своей кривой; расстояния в мировых единицах
+var DefaultFalloff = Falloff{Curve: FalloffInverse, Min: 200, Max: 1400}
+
+// Gain — множитель громкости на расстоянии d
+func (f Falloff) Gain(d float32) float32 {
+	if d <= f.Min {
+		return 1
+	}
+	if d >= f.Max || f.Max <= f.Min {
+		return 0
+	}
+	t := (d - f.Min) / (f.Max - f.Min) // 0..1
+	switch f.Curve {
+	case FalloffSquared:
+		return (1 - t) * (1 - t)
+	case FalloffInverse:
+		// 1/(1+k·t), подрезанное так, чтобы к Max прийти ровно в ноль
+		const k = 6
+		g := 1 / (1 + k*t)
+		end := float32(1.0 / (1 + k))
+		return (g - end) / (1 - end)
+	}
+	return 1 - t
+}
+
+// Pan — панорама -1 (лево) … 1 (право) по смещению dx от слушателя;
+// width — на каком смещении звук целиком в одном канале
+func Pan(dx, width float32) float32 {
+	if width <= 0 {
+		return 0
+	}
+	return min(max(dx/width, -1), 1)
+}
+
+// listener — откуда слушаем (обычно центр камеры)
+type listener struct {
+	x, y  float32
+	width float32 // полширины видимой области: на краю экрана звук в одном ухе
+	set   bool
+}
+
+// SetListener — положение слушателя в мире и полширины видимой области
+func (m *Manager) SetListener(x, y, halfWidth float32) {
+	m.ear = listener{x: x, y: y, width: halfWidth, set: true}
+}
+
+// PlayAt — cue в точке мира: громкость по расстоянию до слушателя, панорама по X.
+// Без слушателя — как Play.
+func (m *Manager) PlayAt(name string, x, y float32) bool {
+	if !m.ear.set {
+		return m.Play(name)
+	}
+	c, ok := m.cues[name]
+	if !ok {
+		return false
+	}
+	f := c.def.Falloff
+	if f == nil {
+		f = &DefaultFalloff
+	}
+	dx, dy := x-m.ear.x, y-m.ear.y
+	g := f.Gain(float32(math.Hypot(float64(dx), float64(dy))))
+	if g <= minAudible {
+		return false
+	}
+	return m.play(name, g, Pan(dx, m.ear.width))
+}
+
+// minAudible — тише этого звук не запускаем вовсе
+const minAudible = 0.01
+
+// playing — звучащий голос для бюджета полифонии
+type playing struct {
+	h        Handle
+	priority int
+	gain     float32 // слышимая громкость (для выбора, кого вытеснить)
+}
+
+// MaxVoices — сколько звуков cue одновременно на всю игру
+const MaxVoices = 24
+
+// admit решает, можно ли запустить ещё один голос. Если бюджет полон —
+// вытесняет самый неважный (приоритет ниже, а при равном — тише), либо
+// отказывает новому, если неважнее он сам.
+func (m *Manager) admit(priority int, gain float32) bool {
+	live := m.live[:0]
+	for _, p := range m.live {
+		if m.be.SoundPlaying(p.h) {
+			live = append(live, p)
+		}
+	}
+	m.live = live
+	if len(m.live) < MaxVoices {
+		return true
+	}
+	worst := 0
+	for i, p := range m.live {
+		if less(p, m.live[worst]) {
+			worst = i
+		}
+	}
+	if !less(m.live[worst], playing{priority: priority, gain: gain}) {
+		return false
+	}
+	m.be.StopSound(m.live[worst].h)
+	m.live = append(m.live[:worst], m.live[worst+1:]...)
+	return true
+}
+
+// less — a неважнее b
+func less(a, b playing) bool {
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	return a.gain < b.gain
+}
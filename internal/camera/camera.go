@@ -0,0 +1,285 @@
+package camera
+
+import "math"
+
+// Camera — контроллер камеры без raylib: плавное следование с мёртвой зоной,
+// упреждение по прицелу, тряска, плавный зум, фокус на точке и ограничение миром.
+// Координаты X, Y — центр кадра в мире; перевод в rl.Camera2D делает вызывающий.
+type Camera struct {
+	X, Y float32 // центр кадра (без тряски)
+	Zoom float32
+
+	ViewW, ViewH float32 // размер экрана, пикс
+
+	MinZoom, MaxZoom float32
+	// Cover — не отдалять дальше, чем мир закрывает экран
+	Cover bool
+
+	DeadZoneW, DeadZoneH float32 // мёртвая зона, пикс экрана
+	Smooth               float32 // скорость догона, 1/с (0 — без сглаживания)
+	LookAhead            float32 // доля расстояния до прицела
+	LookMax              float32 // предел упреждения, пикс мира
+
+	Shake Shake
+
+	worldW, worldH float32 // 0 — без ограничения
+
+	followX, followY float32
+	aimX, aimY       float32
+	hasAim           bool
+	goalX, goalY     float32 // центр мёртвой зоны
+
+	zoomFrom, zoomTo float32
+	zoomT, zoomDur   float32
+
+	focusX, focusY float32
+	focusHold      float32 // сколько ещё держать фокус
+	focusBlend     float32 // время въезда/выезда
+	focusW         float32 // 0..1 — вес точки фокуса
+}
+
+func New(viewW, viewH float32) *Camera {
+	return &Camera{
+		Zoom:      1,
+		ViewW:     viewW,
+		ViewH:     viewH,
+		MinZoom:   0.3,
+		MaxZoom:   3.0,
+		DeadZoneW: 80,
+		DeadZoneH: 60,
+		Smooth:    8,
+		LookAhead: 0.2,
+		LookMax:   120,
+		Shake:     DefaultShake(),
+		zoomTo:    1,
+	}
+}
+
+// SetViewport — размер экрана (вызывать при смене разрешения/полноэкранного режима)
+func (c *Camera) SetViewport(w, h float32) {
+	c.ViewW, c.ViewH = w, h
+}
+
+// SetBounds ограничивает кадр миром [0,w]x[0,h]; w или h <= 0 — без ограничения
+func (c *Camera) SetBounds(w, h float32) {
+	c.worldW, c.worldH = w, h
+}
+
+// Follow — точка, за которой следим (обычно игрок)
+func (c *Camera) Follow(x, y float32) {
+	c.followX, c.followY = x, y
+}
+
+// Aim — точка прицела в мире для упреждения
+func (c *Camera) Aim(x, y float32) {
+	c.aimX, c.aimY = x, y
+	c.hasAim = true
+}
+
+// Snap мгновенно ставит камеру на цель (старт забега, телепорт)
+func (c *Camera) Snap() {
+	c.Zoom = c.clampZoom(c.zoomTo)
+	c.zoomDur = 0
+	c.goalX, c.goalY = c.followX, c.followY
+	c.focusW, c.focusHold = 0, 0
+	c.X, c.Y = c.clampPos(c.desired())
+}
+
+// SetZoom — зум без анимации
+func (c *Camera) SetZoom(z float32) {
+	c.Zoom = c.clampZoom(z)
+	c.zoomTo = c.Zoom
+	c.zoomDur = 0
+}
+
+// ZoomTo — плавный переход к зуму z за dur секунд
+func (c *Camera) ZoomTo(z, dur float32) {
+	if dur <= 0 {
+		c.SetZoom(z)
+		return
+	}
+	c.zoomFrom = c.Zoom
+	c.zoomTo = z
+	c.zoomT = 0
+	c.zoomDur = dur
+}
+
+// ZoomBy — шаг зума относительно текущей цели (колесо мыши)
+func (c *Camera) ZoomBy(delta, dur float32) {
+	c.ZoomTo(c.clampZoom(c.zoomTo+delta), dur)
+}
+
+// Focus временно уводит камеру к точке (x, y): въезд за blend, удержание hold, выезд за blend
+func (c *Camera) Focus(x, y, hold, blend float32) {
+	c.focusX, c.focusY = x, y
+	c.focusHold = hold
+	c.focusBlend = blend
+}
+
+// Focusing — идёт ли сейчас показ точки фокуса
+func (c *Camera) Focusing() bool { return c.focusHold > 0 || c.focusW > 0 }
+
+// AddTrauma — удар по камере (0..1), см. Shake
+func (c *Camera) AddTrauma(t float32) { c.Shake.Add(t) }
+
+func (c *Camera) Update(dt float32) {
+	c.updateZoom(dt)
+	c.updateFocus(dt)
+	c.updateDeadZone()
+
+	tx, ty := c.desired()
+	if c.Smooth > 0 {
+		k := 1 - float32(math.Exp(float64(-c.Smooth*dt)))
+		c.X += (tx - c.X) * k
+		c.Y += (ty - c.Y) * k
+	} else {
+		c.X, c.Y = tx, ty
+	}
+	c.X, c.Y = c.clampPos(c.X, c.Y)
+
+	c.Shake.Update(dt)
+}
+
+// Target — центр кадра с учётом тряски
+func (c *Camera) Target() (float32, float32) {
+	ox, oy, _ := c.Shake.Offset()
+	return c.X + ox/c.Zoom, c.Y + oy/c.Zoom
+}
+
+// Roll — наклон кадра от тряски, градусы
+func (c *Camera) Roll() float32 {
+	_, _, r := c.Shake.Offset()
+	return r
+}
+
+// ScreenToWorld — перевод точки экрана в мир (без наклона)
+func (c *Camera) ScreenToWorld(sx, sy float32) (float32, float32) {
+	x, y := c.Target()
+	return x + (sx-c.ViewW/2)/c.Zoom, y + (sy-c.ViewH/2)/c.Zoom
+}
+
+// WorldToScreen — обратный перевод (без наклона)
+func (c *Camera) WorldToScreen(wx, wy float32) (float32, float32) {
+	x, y := c.Target()
+	return (wx-x)*c.Zoom + c.ViewW/2, (wy-y)*c.Zoom + c.ViewH/2
+}
+
+// Visible — видимый прямоугольник мира (x, y, w, h)
+func (c *Camera) Visible() (float32, float32, float32, float32) {
+	w, h := c.ViewW/c.Zoom, c.ViewH/c.Zoom
+	x, y := c.Target()
+	return x - w/2, y - h/2, w, h
+}
+
+func (c *Camera) updateZoom(dt float32) {
+	if c.zoomDur > 0 {
+		c.zoomT += dt
+		t := c.zoomT / c.zoomDur
+		if t >= 1 {
+			t = 1
+			c.zoomDur = 0
+		}
+		c.Zoom = c.zoomFrom + (c.zoomTo-c.zoomFrom)*easeOut(t)
+	}
+	c.Zoom = c.clampZoom(c.Zoom)
+}
+
+func (c *Camera) updateFocus(dt float32) {
+	if c.focusHold <= 0 && c.focusW <= 0 {
+		return
+	}
+	step := float32(1)
+	if c.focusBlend > 0 {
+		step = dt / c.focusBlend
+	}
+	if c.focusHold > 0 {
+		c.focusW = min(c.focusW+step, 1)
+		if c.focusW >= 1 {
+			c.focusHold -= dt
+		}
+	} else {
+		c.focusW = max(c.focusW-step, 0)
+	}
+}
+
+// мёртвая зона: цель сдвигается, только когда игрок выходит за её рамку
+func (c *Camera) updateDeadZone() {
+	hw := c.DeadZoneW / 2 / c.Zoom
+	hh := c.DeadZoneH / 2 / c.Zoom
+	if dx := c.followX - c.goalX; dx > hw {
+		c.goalX = c.followX - hw
+	} else if dx < -hw {
+		c.goalX = c.followX + hw
+	}
+	if dy := c.followY - c.goalY; dy > hh {
+		c.goalY = c.followY - hh
+	} else if dy < -hh {
+		c.goalY = c.followY + hh
+	}
+}
+
+// куда хочет встать камера: центр мёртвой зоны + упреждение, смешанные с фокусом
+func (c *Camera) desired() (float32, float32) {
+	x, y := c.goalX, c.goalY
+	if c.hasAim && c.LookAhead > 0 {
+		lx := (c.aimX - c.followX) * c.LookAhead
+		ly := (c.aimY - c.followY) * c.LookAhead
+		if l := float32(math.Hypot(float64(lx), float64(ly))); c.LookMax > 0 && l > c.LookMax {
+			lx *= c.LookMax / l
+			ly *= c.LookMax / l
+		}
+		x += lx
+		y += ly
+	}
+	if c.focusW > 0 {
+		w := smoothstep(c.focusW)
+		x += (c.focusX - x) * w
+		y += (c.focusY - y) * w
+	}
+	return x, y
+}
+
+func (c *Camera) clampZoom(z float32) float32 {
+	lo, hi := c.MinZoom, c.MaxZoom
+	if c.Cover && c.worldW > 0 && c.worldH > 0 {
+		lo = max(lo, c.ViewW/c.worldW, c.ViewH/c.worldH)
+	}
+	if hi > 0 && lo > hi {
+		lo = hi // мир меньше экрана даже на максимальном зуме — центрирует clampPos
+	}
+	if z < lo {
+		z = lo
+	}
+	if hi > 0 && z > hi {
+		z = hi
+	}
+	return z
+}
+
+// clampPos держит кадр внутри мира; если мир уже кадра по оси — центрирует
+func (c *Camera) clampPos(x, y float32) (float32, float32) {
+	if c.worldW > 0 {
+		x = clampAxis(x, c.ViewW/2/c.Zoom, c.worldW)
+	}
+	if c.worldH > 0 {
+		y = clampAxis(y, c.ViewH/2/c.Zoom, c.worldH)
+	}
+	return x, y
+}
+
+func clampAxis(v, half, size float32) float32 {
+	if 2*half >= size {
+		return size / 2
+	}
+	if v < half {
+		return half
+	}
+	if v > size-half {
+		return size - half
+	}
+	return v
+}
+
+func easeOut(t float32) float32 { return 1 - (1-t)*(1-t) }
+
+func smoothstep(t float32) float32 { return t * t * (3 - 2*t) }
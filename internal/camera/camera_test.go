@@ -0,0 +1,205 @@
+package camera
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-3 }
+
+func run(c *Camera, seconds float32) {
+	const dt = float32(1) / 60
+	for n := int(seconds/dt + 0.5); n > 0; n-- {
+		c.Update(dt)
+	}
+}
+
+// камера без сглаживания и упреждения: видно чистую мёртвую зону
+func rigid() *Camera {
+	c := New(800, 600)
+	c.Smooth = 0
+	c.LookAhead = 0
+	c.Follow(100, 100)
+	c.Snap()
+	return c
+}
+
+func TestDeadZone(t *testing.T) {
+	c := rigid() // зона 80×60: ±40 по x, ±30 по y
+
+	// внутри зоны — стоим
+	c.Follow(135, 75)
+	c.Update(0.016)
+	if c.X != 100 || c.Y != 100 {
+		t.Fatalf("сдвиг внутри мёртвой зоны: (%.1f, %.1f)", c.X, c.Y)
+	}
+	// вышли за рамку — камера тянется ровно до края
+	c.Follow(190, 20)
+	c.Update(0.016)
+	if !near(c.X, 150) || !near(c.Y, 50) {
+		t.Fatalf("(%.1f, %.1f), want (150, 50)", c.X, c.Y)
+	}
+	// на зуме 2 зона в мире вдвое меньше
+	c.SetZoom(2)
+	c.Follow(190+30, 50)
+	c.Update(0.016)
+	if !near(c.X, 200) {
+		t.Fatalf("зум 2: x %.1f, want 200", c.X)
+	}
+}
+
+func TestSmoothFollow(t *testing.T) {
+	c := New(800, 600)
+	c.LookAhead = 0
+	c.Follow(0, 0)
+	c.Snap()
+	c.Follow(1000, 0)
+	c.Update(0.1)
+	// догон по экспоненте: 1 - e^(-8·0.1) от пути до цели 960
+	if want := float32(960 * (1 - math.Exp(-0.8))); !near(c.X, want) {
+		t.Fatalf("x %.2f, want %.2f", c.X, want)
+	}
+	run(c, 2)
+	if !near(c.X, 960) {
+		t.Fatalf("не догнал: x %.2f", c.X)
+	}
+}
+
+func TestLookAhead(t *testing.T) {
+	c := rigid()
+	c.LookAhead = 0.5
+	c.Aim(100+100, 100)
+	c.Update(0.016)
+	if !near(c.X, 150) {
+		t.Fatalf("упреждение: x %.1f, want 150", c.X)
+	}
+	// предел LookMax
+	c.Aim(100+1000, 100)
+	c.Update(0.016)
+	if !near(c.X, 100+c.LookMax) {
+		t.Fatalf("упреждение сверх предела: x %.1f, want %.1f", c.X, 100+c.LookMax)
+	}
+}
+
+func TestClampToWorld(t *testing.T) {
+	c := rigid()
+	c.SetBounds(2000, 1000)
+	c.Follow(0, 0)
+	c.Snap()
+	if c.X != 400 || c.Y != 300 {
+		t.Fatalf("угол мира: (%.0f, %.0f), want (400, 300)", c.X, c.Y)
+	}
+	c.Follow(5000, 5000)
+	c.Snap()
+	if c.X != 1600 || c.Y != 700 {
+		t.Fatalf("дальний угол: (%.0f, %.0f), want (1600, 700)", c.X, c.Y)
+	}
+}
+
+// мир меньше экрана — кадр центрируется по этой оси
+func TestClampCentresSmallWorld(t *testing.T) {
+	c := rigid()
+	c.MaxZoom = 1
+	c.SetBounds(500, 2000)
+	for _, p := range [][2]float32{{0, 0}, {480, 1500}, {-300, 3000}} {
+		c.Follow(p[0], p[1])
+		c.Snap()
+		if c.X != 250 {
+			t.Fatalf("follow %v: x %.1f, want 250 (центр узкого мира)", p, c.X)
+		}
+	}
+	if c.Y != 1700 {
+		t.Fatalf("y %.1f, want 1700: по длинной оси обычный зажим", c.Y)
+	}
+
+	// Cover поднимает зум, пока мир не закроет экран, но не выше MaxZoom
+	c.Cover = true
+	c.SetZoom(0.5)
+	if c.Zoom != 1 {
+		t.Fatalf("Cover: зум %.2f, want 1 (упёрлись в MaxZoom)", c.Zoom)
+	}
+	c.MaxZoom = 3
+	c.SetZoom(0.5)
+	if !near(c.Zoom, 1.6) {
+		t.Fatalf("Cover: зум %.2f, want 1.6 (800/500)", c.Zoom)
+	}
+}
+
+func TestZoomTween(t *testing.T) {
+	c := rigid()
+	c.ZoomTo(2, 0.5)
+	run(c, 0.25)
+	// easeOut(0.5) = 0.75
+	if !near(c.Zoom, 1.75) {
+		t.Fatalf("на середине зум %.3f, want 1.75", c.Zoom)
+	}
+	run(c, 0.5)
+	if c.Zoom != 2 {
+		t.Fatalf("в конце зум %.3f, want ровно 2", c.Zoom)
+	}
+	// цель за пределами — упирается в MaxZoom и остаётся там
+	c.ZoomBy(10, 0.2)
+	run(c, 1)
+	if c.Zoom != c.MaxZoom {
+		t.Fatalf("зум %.3f, want MaxZoom %.1f", c.Zoom, c.MaxZoom)
+	}
+	c.ZoomTo(0.01, 0)
+	if c.Zoom != c.MinZoom {
+		t.Fatalf("зум %.3f, want MinZoom %.1f", c.Zoom, c.MinZoom)
+	}
+}
+
+func TestFocus(t *testing.T) {
+	c := rigid()
+	c.Focus(500, 100, 1, 0.25)
+	run(c, 0.5)
+	if !c.Focusing() || !near(c.X, 500) {
+		t.Fatalf("в фокусе: x %.1f, focusing %v", c.X, c.Focusing())
+	}
+	run(c, 1.5)
+	if c.Focusing() || !near(c.X, 100) {
+		t.Fatalf("после фокуса: x %.1f, focusing %v", c.X, c.Focusing())
+	}
+}
+
+func TestTraumaDecay(t *testing.T) {
+	s := DefaultShake()
+	s.Add(0.7)
+	s.Add(0.7)
+	if s.Trauma() != 1 {
+		t.Fatalf("trauma %.2f, want 1 (потолок)", s.Trauma())
+	}
+	s.Update(0.25)
+	if want := float32(1 - 1.6*0.25); !near(s.Trauma(), want) {
+		t.Fatalf("trauma %.3f, want %.3f", s.Trauma(), want)
+	}
+	// амплитуда ~ trauma²
+	ox, oy, roll := s.Offset()
+	k := s.Trauma() * s.Trauma()
+	if abs(ox) > s.MaxOffset*k || abs(oy) > s.MaxOffset*k || abs(roll) > s.MaxRoll*k {
+		t.Fatalf("смещение (%.2f, %.2f, %.2f) больше предела при trauma %.2f", ox, oy, roll, s.Trauma())
+	}
+	s.Update(1)
+	if s.Trauma() != 0 {
+		t.Fatalf("trauma %.3f, want 0", s.Trauma())
+	}
+	if ox, oy, roll := s.Offset(); ox != 0 || oy != 0 || roll != 0 {
+		t.Fatal("тряска без травмы")
+	}
+}
+
+func TestScreenWorldRoundTrip(t *testing.T) {
+	c := rigid()
+	c.SetZoom(1.5)
+	wx, wy := c.ScreenToWorld(123, 456)
+	sx, sy := c.WorldToScreen(wx, wy)
+	if !near(sx, 123) || !near(sy, 456) {
+		t.Fatalf("(%.3f, %.3f), want (123, 456)", sx, sy)
+	}
+	x, y, w, h := c.Visible()
+	if !near(w, 800/1.5) || !near(h, 400) || !near(x+w/2, c.X) || !near(y+h/2, c.Y) {
+		t.Fatalf("Visible = (%.1f, %.1f, %.1f, %.1f)", x, y, w, h)
+	}
+}
+
+func abs(v float32) float32 { return float32(math.Abs(float64(v))) }
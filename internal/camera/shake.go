@@ -0,0 +1,51 @@
+package camera
+
+import "math"
+
+// Shake — тряска «по травме»: удары копят trauma (0..1), она спадает со временем,
+// а амплитуда растёт как trauma², поэтому мелкие удары почти не заметны.
+type Shake struct {
+	MaxOffset float32 // пикс экрана при trauma=1
+	MaxRoll   float32 // градусы при trauma=1
+	Decay     float32 // спад trauma, 1/с
+	Freq      float32 // частота шума, Гц
+
+	trauma float32
+	t      float32
+}
+
+func DefaultShake() Shake {
+	return Shake{MaxOffset: 18, MaxRoll: 2.5, Decay: 1.6, Freq: 18}
+}
+
+func (s *Shake) Add(t float32) {
+	s.trauma = min(s.trauma+t, 1)
+}
+
+func (s *Shake) Trauma() float32 { return s.trauma }
+
+func (s *Shake) Update(dt float32) {
+	if s.trauma <= 0 {
+		return
+	}
+	s.t += dt
+	s.trauma = max(s.trauma-s.Decay*dt, 0)
+}
+
+// Offset — текущее смещение (пикс экрана) и наклон (градусы)
+func (s *Shake) Offset() (float32, float32, float32) {
+	if s.trauma <= 0 {
+		return 0, 0, 0
+	}
+	k := s.trauma * s.trauma
+	ph := float64(s.t * s.Freq)
+	return s.MaxOffset * k * noise(ph, 1.3),
+		s.MaxOffset * k * noise(ph, 7.1),
+		s.MaxRoll * k * noise(ph, 13.7)
+}
+
+// noise — гладкий псевдошум в [-1, 1]: сумма несоизмеримых синусов, свой seed на ось
+func noise(x, seed float64) float32 {
+	v := math.Sin(x+seed) + 0.5*math.Sin(2.17*x+seed*1.9) + 0.25*math.Sin(4.63*x+seed*3.1)
+	return float32(v / 1.75)
+}
@@ -0,0 +1,70 @@
+package canvas
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Canvas — виртуальный экран фиксированного размера: вся игра рисуется в него,
+// а Present растягивает кадр на окно с полями по краям.
+type Canvas struct {
+	W, H    int32
+	Integer bool // целочисленный масштаб (иначе — «вписать» дробно)
+
+	target rl.RenderTexture2D
+}
+
+func New(w, h int32, integer bool) *Canvas {
+	c := &Canvas{W: w, H: h, Integer: integer}
+	c.target = rl.LoadRenderTexture(w, h)
+	rl.SetTextureFilter(c.target.Texture, rl.FilterPoint)
+	return c
+}
+
+func (c *Canvas) Unload() {
+	if c.target.ID != 0 {
+		rl.UnloadRenderTexture(c.target)
+		c.target = rl.RenderTexture2D{}
+	}
+}
+
+// Resize пересоздаёт буфер под новое виртуальное разрешение
+func (c *Canvas) Resize(w, h int32) {
+	if w == c.W && h == c.H {
+		return
+	}
+	c.Unload()
+	c.W, c.H = w, h
+	c.target = rl.LoadRenderTexture(w, h)
+	rl.SetTextureFilter(c.target.Texture, rl.FilterPoint)
+}
+
+func (c *Canvas) Size() (float32, float32) { return float32(c.W), float32(c.H) }
+
+// Begin — дальше всё рисуется в виртуальный экран
+func (c *Canvas) Begin() { rl.BeginTextureMode(c.target) }
+
+func (c *Canvas) End() { rl.EndTextureMode() }
+
+// fit — текущие масштаб и отступы для размера окна
+func (c *Canvas) fit() (float32, float32, float32) {
+	return Fit(float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight()), float32(c.W), float32(c.H), c.Integer)
+}
+
+// Present выводит кадр в окно; вызывать между BeginDrawing/EndDrawing
+func (c *Canvas) Present(border rl.Color) {
+	rl.ClearBackground(border)
+	s, ox, oy := c.fit()
+	w, h := float32(c.W), float32(c.H)
+	// у render texture ось Y перевёрнута
+	src := rl.NewRectangle(0, 0, w, -h)
+	dst := rl.NewRectangle(ox, oy, w*s, h*s)
+	rl.DrawTexturePro(c.target.Texture, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+}
+
+// Mouse — позиция мыши в координатах виртуального экрана (прижата к краям)
+func (c *Canvas) Mouse() rl.Vector2 {
+	m := rl.GetMousePosition()
+	s, ox, oy := c.fit()
+	x, y := ToVirtual(m.X, m.Y, s, ox, oy)
+	x = rl.Clamp(x, 0, float32(c.W)-1)
+	y = rl.Clamp(y, 0, float32(c.H)-1)
+	return rl.NewVector2(x, y)
+}
@@ -0,0 +1,24 @@
+package canvas
+
+import "math"
+
+// Fit считает, как вывести кадр w×h в окно winW×winH: масштаб и отступы
+// под поля (letterbox). integer — только целый масштаб (чёткий пиксель-арт);
+// если окно меньше кадра, целого масштаба нет — тогда уменьшаем дробно.
+func Fit(winW, winH, w, h float32, integer bool) (scale, offX, offY float32) {
+	if w <= 0 || h <= 0 || winW <= 0 || winH <= 0 {
+		return 1, 0, 0
+	}
+	scale = float32(math.Min(float64(winW/w), float64(winH/h)))
+	if integer && scale >= 1 {
+		scale = float32(math.Floor(float64(scale)))
+	}
+	offX = float32(math.Floor(float64((winW - w*scale) / 2)))
+	offY = float32(math.Floor(float64((winH - h*scale) / 2)))
+	return scale, offX, offY
+}
+
+// ToVirtual переводит точку окна в координаты кадра (с теми же scale/off, что дал Fit)
+func ToVirtual(x, y, scale, offX, offY float32) (float32, float32) {
+	return (x - offX) / scale, (y - offY) / scale
+}
@@ -0,0 +1,67 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-3 }
+
+func TestFit(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		winW, winH float32
+		integer    bool
+		scale      float32
+		offX, offY float32
+	}{
+		{"ровно вдвое", 1280, 720, true, 2, 0, 0},
+		{"1080p целым", 1920, 1080, true, 3, 0, 0},
+		{"900p целым — поля со всех сторон", 1600, 900, true, 2, 160, 90},
+		{"900p дробно — без полей", 1600, 900, false, 2.5, 0, 0},
+		{"широкое целым — поля по бокам", 2560, 1080, true, 3, 320, 0},
+		{"широкое дробно — те же поля", 2560, 1080, false, 3, 320, 0},
+		{"квадрат дробно — поля сверху и снизу", 1000, 1000, false, 1.5625, 0, 218},
+		{"окно меньше кадра — целого нет, уменьшаем", 320, 200, true, 0.5, 0, 10},
+		{"окно меньше кадра дробно", 320, 200, false, 0.5, 0, 10},
+		{"свёрнутое окно", 0, 0, true, 1, 0, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, ox, oy := Fit(tc.winW, tc.winH, 640, 360, tc.integer)
+			if !near(s, tc.scale) || ox != tc.offX || oy != tc.offY {
+				t.Fatalf("Fit = (%.4f, %.0f, %.0f), want (%.4f, %.0f, %.0f)", s, ox, oy, tc.scale, tc.offX, tc.offY)
+			}
+			if tc.winW == 0 {
+				return
+			}
+			// кадр целиком в окне
+			if ox < 0 || oy < 0 || ox+640*s > tc.winW+1e-3 || oy+360*s > tc.winH+1e-3 {
+				t.Fatalf("кадр вылез за окно: (%.0f, %.0f) + %.1f×%.1f", ox, oy, 640*s, 360*s)
+			}
+		})
+	}
+}
+
+// мышь: края кадра в окне — края виртуального экрана, поля — за ними
+func TestToVirtual(t *testing.T) {
+	for _, integer := range []bool{true, false} {
+		s, ox, oy := Fit(1600, 1000, 640, 360, integer)
+		for _, tc := range []struct {
+			x, y   float32
+			vx, vy float32
+		}{
+			{ox, oy, 0, 0},
+			{ox + 640*s, oy + 360*s, 640, 360},
+			{ox + 320*s, oy + 180*s, 320, 180},
+		} {
+			vx, vy := ToVirtual(tc.x, tc.y, s, ox, oy)
+			if !near(vx, tc.vx) || !near(vy, tc.vy) {
+				t.Errorf("integer=%v: (%.0f, %.0f) → (%.2f, %.2f), want (%.0f, %.0f)", integer, tc.x, tc.y, vx, vy, tc.vx, tc.vy)
+			}
+		}
+		// в левом верхнем поле — отрицательные координаты (Mouse прижмёт к краю)
+		if vx, vy := ToVirtual(0, 0, s, ox, oy); vx > 0 || vy >= 0 {
+			t.Errorf("integer=%v: угол окна → (%.2f, %.2f), want вне кадра", integer, vx, vy)
+		}
+	}
+}
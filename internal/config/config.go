@@ -0,0 +1,164 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Version — текущая версия формата файла настроек
+const Version = 1
+
+// Режимы окна
+const (
+	Windowed   = "windowed"
+	Fullscreen = "fullscreen"
+	Borderless = "borderless"
+)
+
+// Масштабирование виртуального экрана в окно
+const (
+	ScaleInteger = "integer" // только целый масштаб с полями — чёткий пиксель
+	ScaleFit     = "fit"     // дробное «вписывание», поля только по одной оси
+)
+
+// Config — пользовательские настройки (config.json в os.UserConfigDir)
+type Config struct {
+	Version int `json:"version"`
+
+	MasterVolume float32 `json:"master_volume"`
+	MusicVolume  float32 `json:"music_volume"`
+	SFXVolume    float32 `json:"sfx_volume"`
+	UIVolume     float32 `json:"ui_volume"`
+
+	WindowMode string `json:"window_mode"` // windowed | fullscreen | borderless
+	Width      int    `json:"width"`       // размер окна в режиме windowed
+	Height     int    `json:"height"`
+	VSync      bool   `json:"vsync"`
+	FPSCap     int    `json:"fps_cap"` // 0 — без ограничения
+
+	VirtualW int    `json:"virtual_width"` // виртуальный экран, в который рисуется игра
+	VirtualH int    `json:"virtual_height"`
+	Scaling  string `json:"scaling"` // integer | fit
+
+	UIScale     float32 `json:"ui_scale"`
+	ScreenShake bool    `json:"screen_shake"`
+	Language    string  `json:"language"`
+
+	Difficulty string `json:"difficulty"` // easy | normal | hard (ключи aim.json)
+}
+
+func Default() Config {
+	return Config{
+		Version:      Version,
+		MasterVolume: 1,
+		MusicVolume:  1,
+		SFXVolume:    1,
+		UIVolume:     1,
+		WindowMode:   Fullscreen,
+		Width:        1280,
+		Height:       720,
+		VSync:        true,
+		FPSCap:       60,
+		VirtualW:     640,
+		VirtualH:     360,
+		Scaling:      ScaleInteger,
+		UIScale:      1,
+		ScreenShake:  true,
+		Language:     "ru",
+		Difficulty:   "normal",
+	}
+}
+
+// Path — файл настроек: <UserConfigDir>/666adididas/config.json
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "666adididas", "config.json"), nil
+}
+
+// Load читает настройки. Файла нет — умолчания без ошибки (первый запуск);
+// файл битый или незнакомой версии — умолчания и ошибка-предупреждение.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Default(), err
+	}
+	c := Default()
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Default(), fmt.Errorf("config %s: %w (используются настройки по умолчанию)", path, err)
+	}
+	if c.Version != Version {
+		return Default(), fmt.Errorf("config %s: неизвестная версия %d (используются настройки по умолчанию)", path, c.Version)
+	}
+	c.Sanitize()
+	return c, nil
+}
+
+// Save пишет настройки, создавая каталог при необходимости
+func Save(path string, c Config) error {
+	c.Version = Version
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Sanitize приводит значения к допустимым диапазонам
+func (c *Config) Sanitize() {
+	d := Default()
+	c.MasterVolume = clamp01(c.MasterVolume)
+	c.MusicVolume = clamp01(c.MusicVolume)
+	c.SFXVolume = clamp01(c.SFXVolume)
+	c.UIVolume = clamp01(c.UIVolume)
+	switch c.WindowMode {
+	case Windowed, Fullscreen, Borderless:
+	default:
+		c.WindowMode = d.WindowMode
+	}
+	if c.Width < 320 || c.Height < 180 {
+		c.Width, c.Height = d.Width, d.Height
+	}
+	if c.FPSCap < 0 {
+		c.FPSCap = 0
+	}
+	if c.VirtualW < 320 || c.VirtualH < 180 || c.VirtualW > 1920 || c.VirtualH > 1080 {
+		c.VirtualW, c.VirtualH = d.VirtualW, d.VirtualH
+	}
+	switch c.Scaling {
+	case ScaleInteger, ScaleFit:
+	default:
+		c.Scaling = d.Scaling
+	}
+	if c.UIScale < 0.5 || c.UIScale > 2 {
+		c.UIScale = d.UIScale
+	}
+	if c.Language == "" {
+		c.Language = d.Language
+	}
+	if c.Difficulty == "" {
+		c.Difficulty = d.Difficulty
+	}
+}
+
+func clamp01(v float32) float32 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
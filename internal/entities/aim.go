@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"math/rand"
+	"time"
+
+	"example.com/my2dgame/internal/ai"
+)
+
+// Уровни сложности — ключи в aim.json
+const (
+	DifficultyEasy   = "easy"
+	DifficultyNormal = "normal"
+	DifficultyHard   = "hard"
+)
+
+var (
+	aimProfiles map[string]map[string]ai.AimSpec
+	difficulty  = DifficultyNormal
+	aimRand     = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// LoadAimProfiles — прицеливание по архетипам и сложности; вызываем один раз из main
+func LoadAimProfiles(path string) error {
+	p, err := ai.LoadAimProfiles(path)
+	if err != nil {
+		return err
+	}
+	aimProfiles = p
+	return nil
+}
+
+// SetDifficulty влияет на врагов, созданных после вызова
+func SetDifficulty(d string) { difficulty = d }
+
+func Difficulty() string { return difficulty }
+
+func aimFor(kind string) ai.AimSpec {
+	byDiff := aimProfiles[kind]
+	if a, ok := byDiff[difficulty]; ok {
+		return a
+	}
+	if a, ok := byDiff[DifficultyNormal]; ok {
+		return a
+	}
+	return ai.AimSpec{Mode: ai.AimDirect}
+}
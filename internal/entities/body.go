@@ -0,0 +1,53 @@
+package entities
+
+import "math"
+
+// Body — физический отклик на удары: скорость отбрасывания с затуханием.
+// Смещение за кадр отдаёт Step, а применяет владелец (чтобы учесть Clamp и коллизии).
+type Body struct {
+	KnockX, KnockY float32 // пикс/с
+	Mass           float32 // 0 = 1
+	Drag           float32 // затухание, 1/с (0 = 10)
+}
+
+// Impulse — толчок: изменение скорости обратно пропорционально массе
+func (b *Body) Impulse(ix, iy float32) {
+	m := b.Mass
+	if m <= 0 {
+		m = 1
+	}
+	b.KnockX += ix / m
+	b.KnockY += iy / m
+}
+
+// Step возвращает смещение за dt и гасит скорость
+func (b *Body) Step(dt float32) (float32, float32) {
+	if b.KnockX == 0 && b.KnockY == 0 {
+		return 0, 0
+	}
+	dx, dy := b.KnockX*dt, b.KnockY*dt
+	drag := b.Drag
+	if drag <= 0 {
+		drag = 10
+	}
+	k := float32(math.Exp(float64(-drag * dt)))
+	b.KnockX *= k
+	b.KnockY *= k
+	if b.KnockX*b.KnockX+b.KnockY*b.KnockY < 4 {
+		b.KnockX, b.KnockY = 0, 0
+	}
+	return dx, dy
+}
+
+// массы архетипов: чем тяжелее, тем слабее отбрасывает
+var kindMass = map[string]float32{
+	"melee": 1.0,
+	"slime": 0.7,
+}
+
+func massOf(kind string) float32 {
+	if m, ok := kindMass[kind]; ok {
+		return m
+	}
+	return 1
+}
@@ -0,0 +1,448 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"example.com/my2dgame/internal/anim"
+	"example.com/my2dgame/internal/render"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// BossKind — значение Enemy.Kind у всех боссов
+const BossKind = "boss"
+
+// Типы атак босса
+const (
+	PatternRing      = "ring"      // кольцо пуль во все стороны
+	PatternSpiral    = "spiral"    // вращающаяся очередь
+	PatternCharge    = "charge"    // рывок в сторону игрока после замаха
+	PatternSummon    = "summon"    // призыв миньонов
+	PatternTelegraph = "telegraph" // отложенные взрывы по области
+)
+
+type BossPattern struct {
+	Type     string  `json:"type"`
+	Cooldown float32 `json:"cooldown"`
+	Count    int     `json:"count"`
+	Speed    float32 `json:"speed"` // пули; у призыва — скорость миньона (0 — как в уровне)
+	Damage   int     `json:"damage"`
+	Spin     float32 `json:"spin"`     // спираль: градусов между выстрелами
+	Duration float32 `json:"duration"` // спираль/рывок: длительность
+	Windup   float32 `json:"windup"`   // рывок: замах, область: задержка взрыва
+	Radius   float32 `json:"radius"`   // область: радиус взрыва
+	Kind     string  `json:"kind"`     // призыв: тип миньона
+	Scale    float32 `json:"scale"`    // призыв: масштаб миньона (0 — как в уровне)
+}
+
+type BossPhase struct {
+	Name     string        `json:"name"`     // ключ перевода
+	HPBelow  float32       `json:"hp_below"` // доля HP, при которой фаза включается
+	Invuln   float32       `json:"invuln"`   // неуязвимость на переходе
+	Speed    float32       `json:"speed"`    // 0 — базовая скорость босса
+	Patterns []BossPattern `json:"patterns"`
+}
+
+type BossDef struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`   // ключ перевода
+	Sprite        string      `json:"sprite"` // папка в textures/ с idle/anim.json
+	Scale         float32     `json:"scale"`
+	HP            int         `json:"hp"`
+	Speed         float32     `json:"speed"`
+	ContactDamage int         `json:"contact_damage"`
+	Souls         int         `json:"souls"`
+	SpawnAt       float32     `json:"spawn_at"` // секунда забега
+	Mass          float32     `json:"mass"`
+	Tint          [3]uint8    `json:"tint"`
+	Phases        []BossPhase `json:"phases"`
+}
+
+// LoadBossDefs читает список боссов из json
+func LoadBossDefs(path string) ([]*BossDef, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs []*BossDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("boss defs %s: %w", path, err)
+	}
+	for _, d := range defs {
+		if len(d.Phases) == 0 {
+			return nil, fmt.Errorf("boss %q: no phases", d.ID)
+		}
+		// фазы идут от полного HP к низкому
+		sort.SliceStable(d.Phases, func(i, j int) bool { return d.Phases[i].HPBelow > d.Phases[j].HPBelow })
+	}
+	return defs, nil
+}
+
+// Telegraph — предупреждение о взрыве по области
+type Telegraph struct {
+	X, Y   float32
+	Radius float32
+	Delay  float32
+	Timer  float32
+	Damage int
+	// HitsAll — взрыв ранит не только игрока, но и врагов с объектами (бочки)
+	HitsAll bool
+	// Friendly — взрыв игрока (бомба): ранит всех, кроме него
+	Friendly bool
+}
+
+// Update продвигает таймер; true — взрыв сработал в этом кадре
+func (t *Telegraph) Update(dt float32) bool {
+	t.Timer += dt
+	return t.Timer >= t.Delay
+}
+
+func (t *Telegraph) Draw() {
+	k := float32(1)
+	if t.Delay > 0 {
+		k = t.Timer / t.Delay
+	}
+	if k > 1 {
+		k = 1
+	}
+	c := rl.NewVector2(t.X, t.Y)
+	fill, line := rl.NewColor(230, 40, 40, uint8(40+100*k)), rl.NewColor(255, 80, 80, 220)
+	if t.Friendly {
+		fill, line = rl.NewColor(255, 190, 40, uint8(40+100*k)), rl.NewColor(255, 220, 90, 220)
+	}
+	rl.DrawCircleV(c, t.Radius*k, fill)
+	rl.DrawCircleLinesV(c, t.Radius, line)
+}
+
+// Summon — запрос на призыв миньона (его создаёт main). Speed и Scale — из
+// паттерна; 0 — взять у этого вида врага в уровне.
+type Summon struct {
+	Kind         string
+	X, Y         float32
+	Speed, Scale float32
+}
+
+type patternState struct {
+	cd     float32 // до следующего срабатывания
+	active float32 // оставшееся время (спираль, рывок)
+	windup float32
+	step   float32 // спираль: таймер между выстрелами
+	angle  float32
+	dx, dy float32
+}
+
+type Boss struct {
+	*Enemy
+	Def   *BossDef
+	Phase int
+
+	Telegraphs []*Telegraph
+
+	states  []patternState
+	blasts  []Telegraph
+	summons []Summon
+}
+
+// NewBoss грузит спрайт босса и создаёт его в точке x,y
+func NewBoss(assetsRoot string, def *BossDef, x, y float32) (*Boss, error) {
+	clip, err := anim.LoadFromJSON(filepath.Join(assetsRoot, "textures", def.Sprite, "idle", "anim.json"))
+	if err != nil {
+		return nil, err
+	}
+	b := NewBossFromDef(def, clip, x, y)
+	b.Death = loadDeathClip(assetsRoot, def.Sprite)
+	return b, nil
+}
+
+// NewBossFromDef не трогает файлы и GPU: clip может быть nil (безоконная симуляция)
+func NewBossFromDef(def *BossDef, clip *anim.Clip, x, y float32) *Boss {
+	scale := def.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	e := &Enemy{
+		X: x, Y: y,
+		Speed:     def.Speed,
+		BaseSpeed: def.Speed,
+		Scale:     scale,
+		Idle:      clip,
+		Alive:     true,
+		Kind:      BossKind,
+
+		HP:    def.HP,
+		MaxHP: def.HP,
+		Tint:  rl.White,
+
+		CanShoot:      true,
+		MeleeRange:    28 * scale,
+		AttackCD:      1.0,
+		ContactDamage: def.ContactDamage,
+		SoulDrops:     maxInt(def.Souls, 1),
+		Body:          Body{Mass: def.Mass},
+	}
+	if def.Tint != [3]uint8{} {
+		e.Tint = rl.NewColor(def.Tint[0], def.Tint[1], def.Tint[2], 255)
+	}
+	e.Anim.Play(clip, true)
+
+	b := &Boss{Enemy: e, Def: def}
+	e.Controller = b
+	b.enterPhase(0)
+	return b
+}
+
+// PhaseCount / Thresholds — для полоски здоровья
+func (b *Boss) PhaseCount() int { return len(b.Def.Phases) }
+
+func (b *Boss) Thresholds() []float32 {
+	out := make([]float32, 0, len(b.Def.Phases))
+	for _, p := range b.Def.Phases[1:] {
+		out = append(out, p.HPBelow)
+	}
+	return out
+}
+
+// DrainSummons отдаёт накопленные призывы и очищает очередь
+func (b *Boss) DrainSummons() []Summon {
+	out := b.summons
+	b.summons = nil
+	return out
+}
+
+// DrainBlasts отдаёт взрывы, сработавшие с прошлого вызова
+func (b *Boss) DrainBlasts() []Telegraph {
+	out := b.blasts
+	b.blasts = nil
+	return out
+}
+
+func (b *Boss) enterPhase(i int) {
+	b.Phase = i
+	ph := &b.Def.Phases[i]
+	b.states = make([]patternState, len(ph.Patterns))
+	for k, p := range ph.Patterns {
+		// первая атака — через полкулдауна, чтобы не стрелять прямо на переходе
+		b.states[k].cd = p.Cooldown * 0.5
+	}
+	b.BaseSpeed = b.Def.Speed
+	if ph.Speed > 0 {
+		b.BaseSpeed = ph.Speed
+	}
+	if b.FreezeTimer <= 0 {
+		b.Speed = b.BaseSpeed
+	}
+	b.Invuln = ph.Invuln
+	if i > 0 {
+		b.Shots = b.Shots[:0]
+		b.Telegraphs = b.Telegraphs[:0]
+	}
+}
+
+// Control — реализация Controller: фазы и атаки босса
+func (b *Boss) Control(e *Enemy, dt float32, t Target) {
+	targetX, targetY := t.X, t.Y
+	// смена фазы по порогу HP
+	if e.MaxHP > 0 {
+		frac := float32(e.HP) / float32(e.MaxHP)
+		for b.Phase+1 < len(b.Def.Phases) && frac <= b.Def.Phases[b.Phase+1].HPBelow {
+			b.enterPhase(b.Phase + 1)
+		}
+	}
+
+	// заморожен ультой — стоим
+	if e.FreezeTimer > 0 {
+		return
+	}
+
+	b.updateTelegraphs(dt)
+
+	// переход фаз: стоим и не атакуем
+	if e.Invuln > 0 {
+		return
+	}
+
+	ph := &b.Def.Phases[b.Phase]
+	busy := false // рывок перехватывает движение
+	for i := range ph.Patterns {
+		if b.runPattern(&ph.Patterns[i], &b.states[i], dt, targetX, targetY) {
+			busy = true
+		}
+	}
+	if busy {
+		return
+	}
+
+	dx := targetX - e.X
+	dy := targetY - e.Y
+	dist := float32(math.Hypot(float64(dx), float64(dy)))
+	if dist > 16 {
+		nx, ny := e.Toward(targetX, targetY)
+		e.moveBy(nx*e.Speed*dt, ny*e.Speed*dt)
+		e.Face(nx)
+	}
+}
+
+// runPattern возвращает true, если паттерн сейчас управляет движением
+func (b *Boss) runPattern(p *BossPattern, st *patternState, dt, tx, ty float32) bool {
+	cx, cy := b.Center()
+
+	// продолжающиеся атаки
+	if st.windup > 0 {
+		st.windup -= dt
+		if st.windup <= 0 && p.Type == PatternCharge {
+			dx, dy := tx-b.X, ty-b.Y
+			l := float32(math.Hypot(float64(dx), float64(dy)))
+			if l > 0 {
+				st.dx, st.dy = dx/l, dy/l
+			}
+			st.active = p.Duration
+		}
+		return p.Type == PatternCharge
+	}
+	if st.active > 0 {
+		st.active -= dt
+		switch p.Type {
+		case PatternSpiral:
+			interval := p.Duration / float32(maxInt(p.Count, 1))
+			st.step -= dt
+			for st.step <= 0 {
+				st.step += interval
+				b.fire(cx, cy, st.angle, p)
+				st.angle += p.Spin * math.Pi / 180
+			}
+		case PatternCharge:
+			b.moveBy(st.dx*p.Speed*dt, st.dy*p.Speed*dt)
+			b.Face(st.dx)
+			return true
+		}
+		return false
+	}
+
+	st.cd -= dt
+	if st.cd > 0 {
+		return false
+	}
+	st.cd = p.Cooldown
+
+	switch p.Type {
+	case PatternRing:
+		n := maxInt(p.Count, 1)
+		base := rand.Float32() * 2 * math.Pi
+		for i := 0; i < n; i++ {
+			b.fire(cx, cy, base+float32(i)*2*math.Pi/float32(n), p)
+		}
+	case PatternSpiral:
+		st.active = p.Duration
+		st.step = 0
+		st.angle = float32(math.Atan2(float64(ty-cy), float64(tx-cx)))
+	case PatternCharge:
+		st.windup = p.Windup
+		return true
+	case PatternSummon:
+		n := maxInt(p.Count, 1)
+		for i := 0; i < n; i++ {
+			a := float64(i) * 2 * math.Pi / float64(n)
+			b.summons = append(b.summons, Summon{
+				Kind:  p.Kind,
+				X:     b.X + 90*float32(math.Cos(a)),
+				Y:     b.Y + 90*float32(math.Sin(a)),
+				Speed: p.Speed,
+				Scale: p.Scale,
+			})
+		}
+	case PatternTelegraph:
+		n := maxInt(p.Count, 1)
+		for i := 0; i < n; i++ {
+			x, y := tx, ty
+			if i > 0 {
+				// остальные — вокруг игрока
+				a := rand.Float64() * 2 * math.Pi
+				r := p.Radius * (1 + rand.Float32()*2)
+				x += r * float32(math.Cos(a))
+				y += r * float32(math.Sin(a))
+			}
+			b.Telegraphs = append(b.Telegraphs, &Telegraph{
+				X: x, Y: y,
+				Radius: p.Radius,
+				Delay:  p.Windup,
+				Damage: p.Damage,
+			})
+		}
+	}
+	return false
+}
+
+func (b *Boss) fire(x, y, ang float32, p *BossPattern) {
+	dx := float32(math.Cos(float64(ang)))
+	dy := float32(math.Sin(float64(ang)))
+	s := NewSlimeBolt(x, y, dx, dy)
+	if p.Speed > 0 {
+		s.Speed = p.Speed
+	}
+	if p.Damage > 0 {
+		s.Damage = p.Damage
+	}
+	b.Shots = append(b.Shots, s)
+	playCueAt("enemy_shot", x, y) // веер из одного кадра — один звук (cooldown)
+}
+
+func (b *Boss) updateTelegraphs(dt float32) {
+	out := b.Telegraphs[:0]
+	for _, t := range b.Telegraphs {
+		if t.Update(dt) {
+			b.blasts = append(b.blasts, *t)
+			continue
+		}
+		out = append(out, t)
+	}
+	b.Telegraphs = out
+}
+
+// Charging — босс замахивается или несётся в рывке
+func (b *Boss) Charging() bool {
+	ph := &b.Def.Phases[b.Phase]
+	for i, p := range ph.Patterns {
+		if p.Type == PatternCharge && (b.states[i].windup > 0 || b.states[i].active > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// Submit — как у Enemy, плюс телеграфы ударов на полу
+func (b *Boss) Submit(q *render.Queue) {
+	for _, t := range b.Telegraphs {
+		q.Submit(render.LayerGround, t.Y, t.Draw)
+	}
+	b.submit(q, b.Draw)
+}
+
+// Flashing — кадр мигания: босс на замахе или неуязвим
+func (b *Boss) Flashing() bool {
+	return (b.Invuln > 0 || b.Charging()) && blink(12)
+}
+
+func (b *Boss) Draw() {
+	if !b.Alive {
+		b.Enemy.Draw()
+		return
+	}
+	tint := b.Tint
+	if b.Flashing() {
+		tint = rl.White
+	}
+	b.Anim.Draw(b.X, b.Y, b.Scale, tint)
+	b.drawHitFlash()
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,146 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+// Безоконная сессия с боссом: спрайта нет, время — своё
+
+const bossDT = float32(1) / 60
+
+func testBossDef() *BossDef {
+	return &BossDef{
+		ID:    "test",
+		Name:  "boss.test",
+		HP:    1000,
+		Speed: 100,
+		Phases: []BossPhase{
+			{Name: "p1", HPBelow: 1, Patterns: []BossPattern{
+				{Type: PatternRing, Cooldown: 1, Count: 8},
+			}},
+			{Name: "p2", HPBelow: 0.5, Invuln: 1, Patterns: []BossPattern{
+				{Type: PatternSummon, Cooldown: 2, Count: 3, Kind: "melee", Scale: 1.5},
+			}},
+			{Name: "p3", HPBelow: 0.2, Invuln: 1, Speed: 200, Patterns: []BossPattern{
+				{Type: PatternCharge, Cooldown: 3, Windup: 0.5, Duration: 0.4, Speed: 400},
+			}},
+		},
+	}
+}
+
+// fakeClock подменяет clock на время сессии и возвращает шаг
+func fakeClock(t *testing.T) func(b *Boss, seconds float32, tg Target) {
+	now, old := 0.0, clock
+	clock = func() float64 { return now }
+	t.Cleanup(func() { clock = old })
+	return func(b *Boss, seconds float32, tg Target) {
+		for n := int(seconds/bossDT + 0.5); n > 0; n-- {
+			b.Update(bossDT, tg)
+			now += float64(bossDT)
+		}
+	}
+}
+
+func TestBossPhases(t *testing.T) {
+	step := fakeClock(t)
+	b := NewBossFromDef(testBossDef(), nil, 0, 0)
+	far := Target{X: 0, Y: 5000}
+
+	// фаза 1: кольцо через полкулдауна
+	step(b, 0.4, far)
+	if len(b.Shots) != 0 {
+		t.Fatalf("кольцо раньше срока: %d пуль", len(b.Shots))
+	}
+	step(b, 0.2, far)
+	if b.Phase != 0 || len(b.Shots) != 8 {
+		t.Fatalf("фаза %d, пуль %d; want фаза 0, 8 пуль", b.Phase, len(b.Shots))
+	}
+
+	// порог 50%: переход, неуязвимость, пули фазы 1 убраны
+	b.TakeDamage(500)
+	step(b, bossDT, far)
+	if b.Phase != 1 || b.Invuln <= 0 || len(b.Shots) != 0 {
+		t.Fatalf("после порога: фаза %d, invuln %.2f, пуль %d", b.Phase, b.Invuln, len(b.Shots))
+	}
+	// мигает по часам: за 1/6 с бывает и ярким, и обычным
+	lit := map[bool]int{}
+	for i := 0; i < 10; i++ {
+		lit[b.Flashing()]++
+		step(b, bossDT, far)
+	}
+	if lit[true] == 0 || lit[false] == 0 {
+		t.Fatalf("мигание на неуязвимости: %v", lit)
+	}
+	b.TakeDamage(100)
+	if b.HP != 500 {
+		t.Fatalf("урон прошёл сквозь неуязвимость: HP %d", b.HP)
+	}
+
+	// пока неуязвим — не атакует и стоит
+	y := b.Y
+	step(b, 0.7, far)
+	if n := len(b.DrainSummons()); n != 0 || b.Y != y {
+		t.Fatalf("атака или движение на переходе: призывов %d, y %.1f → %.1f", n, y, b.Y)
+	}
+	// после неуязвимости призыв через полкулдауна
+	step(b, 1.2, far)
+	sm := b.DrainSummons()
+	if len(sm) != 3 {
+		t.Fatalf("призывов %d, want 3", len(sm))
+	}
+	// масштаб из паттерна, скорость не задана — её берёт уровень
+	if s := sm[0]; s.Kind != "melee" || s.Scale != 1.5 || s.Speed != 0 {
+		t.Fatalf("призыв %+v", s)
+	}
+
+	// порог 20%: скорость фазы
+	b.TakeDamage(350)
+	step(b, bossDT, far)
+	if b.Phase != 2 || b.Speed != 200 {
+		t.Fatalf("фаза %d, скорость %.0f; want 2, 200", b.Phase, b.Speed)
+	}
+}
+
+func TestBossSkipsPhases(t *testing.T) {
+	step := fakeClock(t)
+	b := NewBossFromDef(testBossDef(), nil, 0, 0)
+	b.TakeDamage(900)
+	step(b, bossDT, Target{})
+	if b.Phase != 2 {
+		t.Fatalf("фаза %d, want 2: оба порога пройдены за кадр", b.Phase)
+	}
+}
+
+func TestBossCharge(t *testing.T) {
+	step := fakeClock(t)
+	def := testBossDef()
+	def.Phases = def.Phases[2:]
+	def.Phases[0].HPBelow, def.Phases[0].Invuln = 1, 0
+	b := NewBossFromDef(def, nil, 0, 0)
+	tg := Target{X: 1000}
+
+	// до рывка (1.5 с) идёт на цель своей скоростью
+	step(b, 1, tg)
+	if math.Abs(float64(b.X-200)) > 4 || b.Charging() {
+		t.Fatalf("x %.1f, charging %v; want ≈200 без рывка", b.X, b.Charging())
+	}
+	step(b, 0.55, tg)
+	if !b.Charging() {
+		t.Fatal("нет замаха после кулдауна")
+	}
+	// на замахе стоит
+	x := b.X
+	step(b, 0.4, tg)
+	if b.X != x {
+		t.Fatalf("сдвинулся на замахе: %.1f → %.1f", x, b.X)
+	}
+	// рывок: 0.4 с на 400 пикс/с
+	step(b, 0.5, tg)
+	if b.Charging() {
+		t.Fatal("рывок не закончился")
+	}
+	if d := b.X - x; math.Abs(float64(d-160)) > 10 {
+		t.Fatalf("рывок на %.1f пикс, want ≈160", d)
+	}
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"example.com/my2dgame/internal/ai"
+)
+
+// деревья поведения по Enemy.Kind
+var brainSpecs map[string]ai.Spec
+
+// LoadBrains читает деревья поведения из json; вызываем один раз из main.
+// Враги без дерева ведут себя по-старому (идут на игрока и стреляют).
+func LoadBrains(path string) error {
+	specs, err := ai.LoadArchetypes(path)
+	if err != nil {
+		return err
+	}
+	brainSpecs = specs
+	return nil
+}
+
+// brain — Controller на дереве поведения
+type brain struct {
+	tree *ai.Tree
+}
+
+func (b *brain) Control(e *Enemy, dt float32, t Target) {
+	e.FireTimer -= dt
+	e.Winding = false
+	b.tree.SetTarget(t.X, t.Y, t.VX, t.VY)
+	b.tree.Tick(enemyAgent{e}, dt)
+}
+
+// enemyAgent — Enemy глазами пакета ai
+type enemyAgent struct{ e *Enemy }
+
+func (a enemyAgent) Position() (float32, float32) { return a.e.X, a.e.Y }
+
+func (a enemyAgent) Move(dx, dy, speedMul, dt float32) {
+	a.e.moveBy(dx*a.e.Speed*speedMul*dt, dy*a.e.Speed*speedMul*dt)
+	a.e.Face(dx)
+}
+
+func (a enemyAgent) Toward(x, y float32) (float32, float32) { return a.e.Toward(x, y) }
+
+func (a enemyAgent) Health() (int, int) { return a.e.HP, a.e.MaxHP }
+
+func (a enemyAgent) CanFire() bool { return a.e.CanShoot && a.e.FireTimer <= 0 }
+
+func (a enemyAgent) Fire(x, y float32) { a.e.FireAt(x, y) }
+
+func (a enemyAgent) ProjectileSpeed() float32 { return EnemyBoltSpeed }
+
+func (a enemyAgent) Aim() ai.AimSpec { return a.e.Aim }
+
+func (a enemyAgent) SetWindup() { a.e.Winding = true }
@@ -0,0 +1,50 @@
+package entities
+
+// Временные усиления игрока (подбираемые power)
+const (
+	BuffSpeed  = "speed"  // множитель скорости бега
+	BuffRapid  = "rapid"  // множитель скорострельности
+	BuffDamage = "damage" // множитель урона выстрела
+)
+
+// Buff — действующее усиление: повторный подбор продлевает время
+type Buff struct {
+	Kind string
+	Mult float32
+	Left float32 // секунд до конца
+	Full float32 // длительность при подборе (для полоски в HUD)
+}
+
+// AddBuff включает усиление kind или продлевает уже действующее
+func (p *Player) AddBuff(kind string, mult, dur float32) {
+	for _, b := range p.Buffs {
+		if b.Kind == kind {
+			b.Mult = max(b.Mult, mult)
+			b.Left = max(b.Left, dur)
+			b.Full = max(b.Full, dur)
+			return
+		}
+	}
+	p.Buffs = append(p.Buffs, &Buff{Kind: kind, Mult: mult, Left: dur, Full: dur})
+}
+
+// BuffMult — множитель усиления kind; 1, если его нет
+func (p *Player) BuffMult(kind string) float32 {
+	for _, b := range p.Buffs {
+		if b.Kind == kind {
+			return b.Mult
+		}
+	}
+	return 1
+}
+
+func (p *Player) updateBuffs(dt float32) {
+	out := p.Buffs[:0]
+	for _, b := range p.Buffs {
+		b.Left -= dt
+		if b.Left > 0 {
+			out = append(out, b)
+		}
+	}
+	p.Buffs = out
+}
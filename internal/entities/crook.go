@@ -25,16 +25,14 @@ type Crook struct {
 	MaxDist float32
 	State   CrookState
 	Active  bool
-	HitSoul *Soul // если зацепили душу
+	Hooked  *Pickup // если зацепили предмет
+	hit     bool    // зацепили, а main ещё не узнал
 
 	// визуальные данные
 	Tex    rl.Texture2D
 	RotDeg float32
 	Scale  float32
 
-	SndThrow rl.Sound
-	SndHit   rl.Sound
-
 	ShowHeadshot  bool
 	HeadshotTimer float32
 	HeadshotTex   rl.Texture2D
@@ -53,29 +51,24 @@ func NewCrook(assetsRoot string, playerX, playerY, targetX, targetY float32) *Cr
 	texPath := filepath.Join(assetsRoot, "textures", "crook", "crook.png")
 	tex := rl.LoadTexture(texPath)
 
-	sndThrow := rl.LoadSound(filepath.Join(assetsRoot, "sounds", "crook.mp3"))
-	sndHit := rl.LoadSound(filepath.Join(assetsRoot, "sounds", "headshot.mp3"))
-
 	return &Crook{
-		X:        playerX,
-		Y:        playerY,
-		StartX:   playerX,
-		StartY:   playerY,
-		DirX:     dx,
-		DirY:     dy,
-		Speed:    900,
-		MaxDist:  400,
-		State:    CrookForward,
-		Active:   true,
-		Tex:      tex,
-		Scale:    2.0,
-		SndThrow: sndThrow,
-		SndHit:   sndHit,
+		X:       playerX,
+		Y:       playerY,
+		StartX:  playerX,
+		StartY:  playerY,
+		DirX:    dx,
+		DirY:    dy,
+		Speed:   900,
+		MaxDist: 400,
+		State:   CrookForward,
+		Active:  true,
+		Tex:     tex,
+		Scale:   2.0,
 	}
 }
 
 // Обновление крюка
-func (c *Crook) Update(dt float32, playerX, playerY float32, souls []*Soul) {
+func (c *Crook) Update(dt float32, playerX, playerY float32, pickups []*Pickup) {
 	if !c.Active {
 		return
 	}
@@ -95,18 +88,19 @@ func (c *Crook) Update(dt float32, playerX, playerY float32, souls []*Soul) {
 			c.State = CrookReturning
 		}
 
-		// проверяем попадание в душу
-		for _, s := range souls {
-			if !s.Alive || s.IsAbsorbing {
+		// проверяем попадание в предмет
+		for _, p := range pickups {
+			if !p.Alive || p.Absorbing {
 				continue
 			}
-			dx := s.X - c.X
-			dy := s.Y - c.Y
+			dx := p.X - c.X
+			dy := p.Y - c.Y
 			if dx*dx+dy*dy < 25*25 { // радиус зацепа
-				s.IsAbsorbing = true
-				c.HitSoul = s
+				p.Attract()
+				c.Hooked = p
+				c.hit = true
 				c.State = CrookReturning
-				rl.PlaySound(c.SndHit)
+				playCueAt("headshot", c.X, c.Y)
 				break
 			}
 		}
@@ -124,12 +118,12 @@ func (c *Crook) Update(dt float32, playerX, playerY float32, souls []*Soul) {
 		// обновляем угол (нос направлен к игроку)
 		c.RotDeg = float32(math.Atan2(float64(dy), float64(dx))*180/math.Pi) - 90
 
-		// если душа зацеплена — тянем её за крюком
-		if c.HitSoul != nil && c.HitSoul.Alive {
-			c.HitSoul.X = c.X
-			c.HitSoul.Y = c.Y
+		// если предмет зацеплен — тянем его за крюком
+		if c.Hooked != nil && c.Hooked.Alive {
+			c.Hooked.X = c.X
+			c.Hooked.Y = c.Y
 			if dist < 30 {
-				c.HitSoul.IsAbsorbing = true
+				c.Hooked.Attract()
 				c.Active = false
 				return
 			}
@@ -147,15 +141,22 @@ func (c *Crook) Update(dt float32, playerX, playerY float32, souls []*Soul) {
 			}
 		}
 
-		// при попадании души
-		if c.HitSoul != nil {
-			rl.PlaySound(c.SndHit)
+		// при попадании в предмет — один раз, а не каждый кадр возврата
+		if c.Hooked != nil && !c.ShowHeadshot {
+			playCueAt("headshot", c.X, c.Y)
 			c.ShowHeadshot = true
 			c.HeadshotTimer = 2.0
 		}
 	}
 }
 
+// TakeHit — крюк только что зацепил предмет; второй вызов — false
+func (c *Crook) TakeHit() bool {
+	h := c.hit
+	c.hit = false
+	return h
+}
+
 // Отрисовка
 func (c *Crook) Draw(playerX, playerY float32) {
 	if !c.Active {
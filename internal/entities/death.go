@@ -0,0 +1,154 @@
+package entities
+
+import (
+	"os"
+	"path/filepath"
+
+	"example.com/my2dgame/internal/anim"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// EnemyState — жизненный цикл врага
+type EnemyState int
+
+const (
+	EnemyActive EnemyState = iota
+	EnemyDying             // проигрывает смерть, не сталкивается
+	EnemyCorpse            // тающий труп на земле
+	EnemyGone              // можно убирать из списка
+)
+
+// Событие кадра клипа смерти, на котором выпадают души
+const DropEvent = "drop"
+
+const (
+	dissolveTime = 0.5 // растворение, если нет клипа смерти
+	dissolveDrop = 0.2 // момент выпадения душ при растворении
+)
+
+// сколько секунд лежит труп (0 — не оставлять)
+var corpseTime = map[string]float32{
+	"melee": 6,
+	"slime": 4,
+}
+
+// loadDeathClip — textures/<kind>/death/anim.json, если есть
+func loadDeathClip(assetsRoot, kind string) *anim.Clip {
+	path := filepath.Join(assetsRoot, "textures", kind, "death", "anim.json")
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	clip, err := anim.LoadFromJSON(path)
+	if err != nil {
+		return nil
+	}
+	clip.Loop = false
+	return clip
+}
+
+func (e *Enemy) die() {
+	e.Alive = false
+	e.State = EnemyDying
+	e.deathT = 0
+	e.Winding = false
+	if e.Death != nil {
+		e.Anim.Play(e.Death, true)
+	}
+}
+
+// TakeDeathDrop — true один раз, когда пора выронить души (событие "drop")
+func (e *Enemy) TakeDeathDrop() bool {
+	if e.dropPending {
+		e.dropPending = false
+		return true
+	}
+	return false
+}
+
+// Removed — врага можно убрать из списка
+func (e *Enemy) Removed() bool { return e.State == EnemyGone }
+
+func (e *Enemy) updateDeath(dt float32) {
+	switch e.State {
+	case EnemyDying:
+		prev := e.deathT
+		e.deathT += dt
+		kx, ky := e.Step(dt)
+		e.moveBy(kx, ky)
+
+		done := false
+		if e.Death != nil {
+			e.Anim.Update(dt)
+			for _, ev := range e.Anim.TakeEvents() {
+				if ev == DropEvent {
+					e.dropPending = true
+				}
+			}
+			done = e.Anim.Done()
+			// в клипе нет события — роняем в конце
+			if done && !hasEvent(e.Death, DropEvent) {
+				e.dropPending = true
+			}
+		} else {
+			if prev < dissolveDrop && e.deathT >= dissolveDrop {
+				e.dropPending = true
+			}
+			done = e.deathT >= dissolveTime
+		}
+		if done {
+			e.corpseT = corpseTime[e.Kind]
+			if e.corpseT > 0 {
+				e.State = EnemyCorpse
+			} else {
+				e.State = EnemyGone
+			}
+		}
+	case EnemyCorpse:
+		e.corpseT -= dt
+		if e.corpseT <= 0 {
+			e.State = EnemyGone
+		}
+	}
+}
+
+func hasEvent(c *anim.Clip, name string) bool {
+	for _, ev := range c.Events {
+		if ev == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Enemy) drawDeath() {
+	switch e.State {
+	case EnemyDying:
+		if e.Death != nil {
+			e.Anim.Draw(e.X, e.Y, e.Scale, e.Tint)
+			return
+		}
+		// растворение: светлеем, бледнеем и чуть всплываем
+		k := e.deathT / dissolveTime
+		if k > 1 {
+			k = 1
+		}
+		e.Anim.Draw(e.X, e.Y-12*k, e.Scale, rl.Fade(e.Tint, 1-k))
+		rl.BeginBlendMode(rl.BlendAdditive)
+		e.Anim.Draw(e.X, e.Y-12*k, e.Scale, rl.Fade(rl.White, (1-k)*0.8))
+		rl.EndBlendMode()
+	case EnemyCorpse:
+		total := corpseTime[e.Kind]
+		a := float32(0.7)
+		if e.corpseT < 1 {
+			a *= e.corpseT // последняя секунда — плавно исчезаем
+		}
+		if e.Death != nil {
+			// последний кадр смерти, затемнённый
+			e.Anim.Draw(e.X, e.Y, e.Scale, rl.Fade(rl.NewColor(90, 80, 80, 255), a))
+			return
+		}
+		// без клипа — тёмное пятно на земле
+		w := 26 * e.Scale * (1 + 0.1*(total-e.corpseT)/total)
+		rl.DrawEllipse(int32(e.X), int32(e.Y), w, w*0.35, rl.Fade(rl.NewColor(40, 20, 30, 255), a*0.8))
+	}
+}
@@ -0,0 +1,164 @@
+package entities
+
+import (
+	"math/rand"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Affix — модификатор элитного врага
+type Affix int
+
+const (
+	AffixFast       Affix = iota // быстрый
+	AffixArmored                 // снижает входящий урон
+	AffixSplitting               // при смерти делится на двоих
+	AffixVampiric                // лечится, нанося урон
+	AffixShielded                // щит поверх HP
+	AffixExplosive               // взрывается при смерти
+	AffixReflecting              // отражает пули игрока
+)
+
+type affixInfo struct {
+	Name    string
+	Weight  float32 // базовый вес
+	Growth  float32 // прибавка к весу за минуту забега
+	MinTime float32 // с какой секунды может выпасть
+	Color   rl.Color
+}
+
+var affixTable = [...]affixInfo{
+	AffixFast:       {"fast", 10, 0, 0, rl.NewColor(255, 220, 60, 255)},
+	AffixArmored:    {"armored", 8, 1, 0, rl.NewColor(150, 160, 180, 255)},
+	AffixSplitting:  {"splitting", 5, 2, 45, rl.NewColor(120, 230, 120, 255)},
+	AffixVampiric:   {"vampiric", 5, 1.5, 30, rl.NewColor(200, 20, 40, 255)},
+	AffixShielded:   {"shielded", 6, 1.5, 30, rl.NewColor(80, 170, 255, 255)},
+	AffixExplosive:  {"explosive", 3, 2.5, 60, rl.NewColor(255, 120, 20, 255)},
+	AffixReflecting: {"reflecting", 2, 2, 90, rl.NewColor(230, 230, 255, 255)},
+}
+
+func (a Affix) String() string { return affixTable[a].Name }
+
+// Шанс, что заспавненный враг станет элитой: растёт со временем забега
+func EliteChance(elapsed float32) float32 {
+	c := 0.04 + elapsed/600*0.25
+	if c > 0.35 {
+		c = 0.35
+	}
+	return c
+}
+
+// RollAffixes выбирает модификаторы по взвешенной таблице; их число и веса
+// «тяжёлых» аффиксов растут с elapsed (секунды забега)
+func RollAffixes(rng *rand.Rand, elapsed float32) []Affix {
+	count := 1
+	if rng.Float32() < elapsed/300 {
+		count++
+	}
+	if rng.Float32() < elapsed/900 {
+		count++
+	}
+
+	minutes := elapsed / 60
+	weights := make([]float32, len(affixTable))
+	for i, a := range affixTable {
+		if elapsed >= a.MinTime {
+			weights[i] = a.Weight + a.Growth*minutes
+		}
+	}
+
+	out := make([]Affix, 0, count)
+	for len(out) < count {
+		var total float32
+		for _, w := range weights {
+			total += w
+		}
+		if total <= 0 {
+			break
+		}
+		r := rng.Float32() * total
+		for i, w := range weights {
+			if w <= 0 {
+				continue
+			}
+			if r < w {
+				out = append(out, Affix(i))
+				weights[i] = 0 // без повторов
+				break
+			}
+			r -= w
+		}
+	}
+	return out
+}
+
+// MakeElite повышает врага до элиты: статы, подсветка, больше душ
+func (e *Enemy) MakeElite(affixes []Affix) {
+	e.Elite = true
+	e.Affixes = affixes
+	e.HP *= 2
+	e.MaxHP *= 2
+	e.Scale *= 1.15
+	e.SoulDrops = 2 + len(affixes)
+
+	for _, a := range affixes {
+		switch a {
+		case AffixFast:
+			e.BaseSpeed *= 1.5
+			e.Speed = e.BaseSpeed
+			e.FirePeriod *= 0.75
+		case AffixArmored:
+			e.Armor = 0.5
+			e.Mass *= 2
+		case AffixShielded:
+			e.MaxShield = e.MaxHP / 2
+			e.Shield = e.MaxShield
+		}
+	}
+
+	// контур — цвет первого аффикса, лёгкий оттенок — смесь всех
+	e.Outline = affixTable[affixes[0]].Color
+	var r, g, b int
+	for _, a := range affixes {
+		c := affixTable[a].Color
+		r, g, b = r+int(c.R), g+int(c.G), b+int(c.B)
+	}
+	n := len(affixes)
+	e.Tint = rl.NewColor(uint8((255+r/n)/2), uint8((255+g/n)/2), uint8((255+b/n)/2), 255)
+}
+
+func (e *Enemy) Has(a Affix) bool {
+	for _, x := range e.Affixes {
+		if x == a {
+			return true
+		}
+	}
+	return false
+}
+
+// OnDealtDamage — враг попал по игроку (вампиризм)
+func (e *Enemy) OnDealtDamage(dmg int) {
+	if !e.Has(AffixVampiric) || !e.Alive {
+		return
+	}
+	e.HP += dmg
+	if e.HP > e.MaxHP {
+		e.HP = e.MaxHP
+	}
+}
+
+// ReflectChance — вероятность отразить пулю игрока
+func (e *Enemy) ReflectChance() float32 {
+	if e.Has(AffixReflecting) {
+		return 0.35
+	}
+	return 0
+}
+
+// Reflect разворачивает пулю игрока обратно во вражескую
+func (e *Enemy) Reflect(shot *Projectile, targetX, targetY float32) {
+	cx, cy := e.Center()
+	e.Shots = append(e.Shots, NewSlimeBolt(cx, cy, targetX-cx, targetY-cy))
+	shot.Alive = false
+	playCueAt("enemy_shot", cx, cy)
+}
@@ -1,20 +1,32 @@
 package entities
 
 import (
+	"fmt"
 	"math"
 	"path/filepath"
 
+	"example.com/my2dgame/internal/ai"
 	"example.com/my2dgame/internal/anim"
+	"example.com/my2dgame/internal/nav"
+	"example.com/my2dgame/internal/render"
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
+// clock — время для мигания (замах, неуязвимость); тесты подставляют своё
+var clock = rl.GetTime
+
+// blink — мигание с частотой hz: true в первой половине каждого периода
+func blink(hz float64) bool { return int(clock()*hz)%2 == 0 }
+
 type Enemy struct {
 	X, Y       float32
 	Speed      float32
 	Scale      float32
 	Anim       anim.Animator
 	Idle       *anim.Clip
-	Alive      bool
+	Death      *anim.Clip // может быть nil — тогда растворяемся
+	Alive      bool       // активен и участвует в столкновениях (State == EnemyActive)
+	State      EnemyState
 	Kind       string
 	FacesRight bool
 
@@ -32,6 +44,47 @@ type Enemy struct {
 
 	BaseSpeed   float32
 	FreezeTimer float32
+
+	Body
+	HitFlash float32 // белая вспышка при попадании
+
+	MaxHP      int
+	Invuln     float32    // неуязвимость (переход фаз у босса)
+	Tint       rl.Color   // цвет спрайта
+	Controller Controller // если задан — заменяет стандартное поведение
+	Winding    bool       // замах перед рывком (мигаем)
+
+	// ---- элита ----
+	Elite     bool
+	Affixes   []Affix
+	Armor     float32 // доля поглощаемого урона
+	Shield    int
+	MaxShield int
+	Outline   rl.Color
+	SoulDrops int
+
+	Aim       ai.AimSpec // прицел по архетипу и сложности
+	burstLeft int
+	burstT    float32
+
+	path     []nav.Point // свой путь A* (когда цель не совпадает с полем потока)
+	pathGoal nav.Point
+	pathT    float32 // до пересчёта пути
+
+	deathT      float32 // время с момента смерти
+	corpseT     float32
+	dropPending bool
+}
+
+// Target — цель врага: позиция и скорость (пикс/с)
+type Target struct {
+	X, Y   float32
+	VX, VY float32
+}
+
+// Controller подменяет стандартное поведение врага (боссы и т.п.)
+type Controller interface {
+	Control(e *Enemy, dt float32, t Target)
 }
 
 func NewEnemyKind(assetsRoot, kind string, x, y, speed, scale float32) (*Enemy, error) {
@@ -50,7 +103,11 @@ func NewEnemyKind(assetsRoot, kind string, x, y, speed, scale float32) (*Enemy,
 		Alive:     true,
 		Kind:      kind,
 
-		HP: 50,
+		HP:        50,
+		MaxHP:     50,
+		Tint:      rl.White,
+		SoulDrops: 1,
+		Body:      Body{Mass: massOf(kind)},
 
 		FacesRight:    false,           // базовый кадр смотрит влево
 		CanShoot:      kind != "melee", // <-- только не-melee
@@ -62,6 +119,16 @@ func NewEnemyKind(assetsRoot, kind string, x, y, speed, scale float32) (*Enemy,
 		ContactDamage: 10,
 	}
 	e.Anim.Play(e.Idle, true)
+	e.Death = loadDeathClip(assetsRoot, kind)
+	e.Aim = aimFor(kind)
+
+	if spec, ok := brainSpecs[kind]; ok {
+		root, err := ai.Build(spec)
+		if err != nil {
+			return nil, fmt.Errorf("ai %s: %w", kind, err)
+		}
+		e.Controller = &brain{tree: ai.NewTree(root, nil)}
+	}
 
 	return e, nil
 }
@@ -70,61 +137,107 @@ func NewEnemy(assetsRoot string, x, y float32) (*Enemy, error) {
 	return NewEnemyKind(assetsRoot, "melee", x, y, 80, 2.25)
 }
 
-func (e *Enemy) Update(dt float32, targetX, targetY float32) {
+func (e *Enemy) Update(dt float32, t Target) {
 	e.AttackTimer -= dt
 	if e.AttackTimer < 0 {
 		e.AttackTimer = 0
 	}
 
-	if !e.Alive {
+	if e.State != EnemyActive {
+		e.updateDeath(dt)
+		e.updateShots(dt) // выпущенные пули долетают
 		return
 	}
 
-	dx := targetX - e.X
-	dy := targetY - e.Y
+	// отбрасывание работает и на замороженных
+	kx, ky := e.Step(dt)
+	e.moveBy(kx, ky)
+	e.pathT -= dt
+	if e.HitFlash > 0 {
+		e.HitFlash -= dt
+	}
+
+	if e.Invuln > 0 {
+		e.Invuln -= dt
+		if e.Invuln < 0 {
+			e.Invuln = 0
+		}
+	}
+
+	if e.Controller != nil {
+		e.Controller.Control(e, dt, t)
+		e.updateShots(dt)
+		e.updateFreeze(dt)
+		e.Anim.Update(dt)
+		return
+	}
+
+	dx := t.X - e.X
+	dy := t.Y - e.Y
 	dist := float32(math.Hypot(float64(dx), float64(dy)))
 
-	// движение к цели (как раньше)
+	// движение к цели в обход препятствий
 	stopDist := float32(16)
 	if dist > 0.001 && dist > stopDist {
-		nx := dx / dist
-		ny := dy / dist
-		e.X += nx * e.Speed * dt
-		e.Y += ny * e.Speed * dt
-
-		// корректный флип (базово смотрит влево)
-		if !e.FacesRight {
-			if nx > 0 {
-				e.Anim.FlipX = true
-			} else if nx < 0 {
-				e.Anim.FlipX = false
-			}
-		} else {
-			if nx < 0 {
-				e.Anim.FlipX = true
-			} else if nx > 0 {
-				e.Anim.FlipX = false
-			}
-		}
+		nx, ny := e.Toward(t.X, t.Y)
+		e.moveBy(nx*e.Speed*dt, ny*e.Speed*dt)
+		e.Face(nx)
 	}
 
 	if e.CanShoot {
 		e.FireTimer -= dt
-		if dist <= e.FireRange && e.FireTimer <= 0 {
-			e.Shots = append(e.Shots, NewSlimeBolt(e.X, e.Y, dx, dy))
-			e.FireTimer = e.FirePeriod
+		if dist <= e.FireRange && e.FireTimer <= 0 && e.burstLeft == 0 {
+			e.burstLeft = e.Aim.Shots()
+			e.burstT = 0
 		}
-		// апдейт пуль и очистка мёртвых
-		out := e.Shots[:0]
-		for _, p := range e.Shots {
-			p.Update(dt)
-			if p.Alive {
-				out = append(out, p)
+		// очередь: по выстрелу каждые Gap секунд, каждый прицеливается заново
+		if e.burstLeft > 0 {
+			e.burstT -= dt
+			if e.burstT <= 0 {
+				e.FireAt(e.aimPoint(t))
+				e.burstLeft--
+				e.burstT = e.Aim.Gap()
 			}
 		}
-		e.Shots = out
 	}
+	// пули могут быть и у ближника (отражённые)
+	e.updateShots(dt)
 
+	e.updateFreeze(dt)
+	e.Anim.Update(dt)
+}
+
+// Face разворачивает спрайт по горизонтальному направлению движения
+func (e *Enemy) Face(nx float32) {
+	// корректный флип (базово смотрит влево)
+	if !e.FacesRight {
+		if nx > 0 {
+			e.Anim.FlipX = true
+		} else if nx < 0 {
+			e.Anim.FlipX = false
+		}
+	} else {
+		if nx < 0 {
+			e.Anim.FlipX = true
+		} else if nx > 0 {
+			e.Anim.FlipX = false
+		}
+	}
+}
+
+// апдейт пуль и очистка мёртвых
+func (e *Enemy) updateShots(dt float32) {
+	out := e.Shots[:0]
+	for _, p := range e.Shots {
+		p.Update(dt)
+		if p.Alive {
+			out = append(out, p)
+		}
+	}
+	e.Shots = out
+}
+
+func (e *Enemy) updateFreeze(dt float32) {
 	if e.FreezeTimer > 0 {
 		e.FreezeTimer -= dt
 		if e.FreezeTimer <= 0 {
@@ -132,30 +245,122 @@ func (e *Enemy) Update(dt float32, targetX, targetY float32) {
 			e.CanShoot = e.Kind != "melee"
 		}
 	}
+}
 
-	e.Anim.Update(dt)
+// Foot — точка ног (середина нижнего края кадра), по ней сортируется отрисовка
+func (e *Enemy) Foot() (float32, float32) {
+	x, y, _ := footprint(&e.Anim, e.X, e.Y, e.Scale)
+	return x, y
+}
+
+// Submit кладёт врага, его тень и снаряды в очередь отрисовки
+func (e *Enemy) Submit(q *render.Queue) {
+	e.submit(q, e.Draw)
+}
+
+func (e *Enemy) submit(q *render.Queue, draw func()) {
+	fx, fy, w := footprint(&e.Anim, e.X, e.Y, e.Scale)
+	switch e.State {
+	case EnemyCorpse:
+		q.Submit(render.LayerGround, fy, draw)
+	case EnemyActive:
+		q.Submit(render.LayerShadows, fy, func() { drawShadow(fx, fy, w) })
+		q.Submit(render.LayerActors, fy, draw)
+	default:
+		q.Submit(render.LayerActors, fy, draw)
+	}
+	e.SubmitShots(q)
+}
+
+// SubmitShots — только снаряды: сам враг за кадром, а его выстрелы могут быть видны
+func (e *Enemy) SubmitShots(q *render.Queue) {
+	for _, p := range e.Shots {
+		q.Submit(render.LayerProjectiles, p.Y, p.Draw)
+	}
 }
 
 func (e *Enemy) Draw() {
-	if !e.Alive {
+	if e.State != EnemyActive {
+		e.drawDeath()
 		return
 	}
-	e.Anim.Draw(e.X, e.Y, e.Scale, rl.White)
-
-	if e.CanShoot {
-		for _, p := range e.Shots {
-			p.Draw()
-		}
+	if e.Elite {
+		// контур: тот же кадр со сдвигом в 4 стороны
+		const o = 2
+		e.Anim.Draw(e.X-o, e.Y, e.Scale, e.Outline)
+		e.Anim.Draw(e.X+o, e.Y, e.Scale, e.Outline)
+		e.Anim.Draw(e.X, e.Y-o, e.Scale, e.Outline)
+		e.Anim.Draw(e.X, e.Y+o, e.Scale, e.Outline)
+	}
+	tint := e.Tint
+	if e.Winding && blink(14) {
+		tint = rl.NewColor(255, 90, 90, 255)
 	}
+	e.Anim.Draw(e.X, e.Y, e.Scale, tint)
+	e.drawHitFlash()
 }
 
 func (e *Enemy) TakeDamage(dmg int) {
-	if !e.Alive || dmg <= 0 {
+	if !e.Alive || dmg <= 0 || e.Invuln > 0 {
 		return
 	}
+	if e.Armor > 0 {
+		dmg = int(float32(dmg) * (1 - e.Armor))
+		if dmg < 1 {
+			dmg = 1
+		}
+	}
+	if e.Shield > 0 {
+		if e.Shield >= dmg {
+			e.Shield -= dmg
+			return
+		}
+		dmg -= e.Shield
+		e.Shield = 0
+	}
 	e.HP -= dmg
+	e.HitFlash = hitFlashTime
 	if e.HP <= 0 {
 		e.HP = 0
-		e.Alive = false
+		e.die()
+	}
+}
+
+// Center — визуальный центр текущего кадра (X,Y — это «ноги» спрайта)
+func (e *Enemy) Center() (float32, float32) {
+	if e.Anim.Current != nil && e.Anim.FrameIndex < len(e.Anim.Current.Frames) {
+		f := e.Anim.Current.Frames[e.Anim.FrameIndex]
+		cx := e.X - float32(f.OrigX)*e.Scale + float32(f.Src.Width)*e.Scale/2
+		cy := e.Y - float32(f.OrigY)*e.Scale + float32(f.Src.Height)*e.Scale/2
+		return cx, cy
+	}
+	return e.X, e.Y
+}
+
+// FireAt — выстрел в точку, перезарядка начинается заново
+func (e *Enemy) FireAt(x, y float32) {
+	e.Shots = append(e.Shots, NewSlimeBolt(e.X, e.Y, x-e.X, y-e.Y))
+	e.FireTimer = e.FirePeriod
+	playCueAt("enemy_shot", e.X, e.Y)
+}
+
+func (e *Enemy) aimPoint(t Target) (float32, float32) {
+	return ai.AimPoint(e.Aim, aimRand, e.X, e.Y, t.X, t.Y, t.VX, t.VY, EnemyBoltSpeed)
+}
+
+const hitFlashTime = 0.12
+
+// поверх спрайта — тот же кадр в аддитивном режиме: спрайт «белеет»
+func (e *Enemy) drawHitFlash() {
+	if e.HitFlash <= 0 {
+		return
+	}
+	a := e.HitFlash / hitFlashTime
+	if a > 1 {
+		a = 1
 	}
+	rl.BeginBlendMode(rl.BlendAdditive)
+	e.Anim.Draw(e.X, e.Y, e.Scale, rl.Fade(rl.White, a))
+	e.Anim.Draw(e.X, e.Y, e.Scale, rl.Fade(rl.White, a))
+	rl.EndBlendMode()
 }
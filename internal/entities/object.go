@@ -0,0 +1,291 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"example.com/my2dgame/internal/render"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Damageable — всё, что можно ранить: враги (и боссы), игрок, объекты мира
+type Damageable interface {
+	TakeDamage(dmg int)
+}
+
+var (
+	_ Damageable = (*Enemy)(nil)
+	_ Damageable = (*Player)(nil)
+	_ Damageable = (*Object)(nil)
+)
+
+// Поведения объектов мира
+const (
+	ObjBreakable = "breakable" // ящики, надгробия: ломаются, из них выпадает добыча
+	ObjExplosive = "explosive" // бочки: сломанная взрывается и ранит всех вокруг
+	ObjSpikes    = "spikes"    // шипы: то торчат, то прячутся; ранят всех, кто на них
+	ObjShrine    = "shrine"    // святилище: лечит игрока и перезаряжается
+	ObjTeleport  = "teleport"  // телепорт: переносит игрока на связанную площадку
+)
+
+// spikeTick — как часто торчащие шипы ранят стоящих на них
+const spikeTick = 0.5
+
+// ObjectDef — вид объекта мира (data/objects.json, ключ — имя)
+type ObjectDef struct {
+	Name     string     `json:"-"`
+	Behavior string     `json:"behavior"`
+	Shape    string     `json:"shape"` // crate | stone | barrel | spikes | shrine | pad
+	Size     [2]float32 `json:"size"`  // ширина и высота в мировых единицах
+	Color    [3]uint8   `json:"color"`
+	HP       int        `json:"hp"`    // 0 — не ломается
+	Solid    bool       `json:"solid"` // перекрывает путь и пули
+
+	Loot string `json:"loot"` // таблица добычи в pickups.json; пусто — по имени объекта
+	Heal int    `json:"heal"` // лечение от святилища
+
+	Damage   int     `json:"damage"`   // взрыв, шипы
+	Radius   float32 `json:"radius"`   // радиус взрыва или зоны действия
+	Cooldown float32 `json:"cooldown"` // святилище, телепорт; у шипов — период цикла
+	Active   float32 `json:"active"`   // шипы: сколько секунд из периода торчат
+}
+
+// LoadObjectDefs читает виды объектов мира
+func LoadObjectDefs(path string) (map[string]*ObjectDef, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs map[string]*ObjectDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("object defs %s: %w", path, err)
+	}
+	for name, d := range defs {
+		d.Name = name
+		switch d.Behavior {
+		case ObjBreakable, ObjExplosive:
+			if d.HP <= 0 {
+				return nil, fmt.Errorf("object %q: %s без hp", name, d.Behavior)
+			}
+		case ObjSpikes:
+			if d.Cooldown <= 0 || d.Active <= 0 || d.Active > d.Cooldown {
+				return nil, fmt.Errorf("object %q: шипам нужно 0 < active ≤ cooldown", name)
+			}
+		case ObjShrine, ObjTeleport:
+		default:
+			return nil, fmt.Errorf("object %q: неизвестное поведение %q", name, d.Behavior)
+		}
+		if d.Size[0] <= 0 || d.Size[1] <= 0 {
+			return nil, fmt.Errorf("object %q: нужен size", name)
+		}
+		if d.Loot == "" {
+			d.Loot = name
+		}
+	}
+	return defs, nil
+}
+
+// Object — объект мира. X, Y — середина основания, по ней сортируется отрисовка.
+type Object struct {
+	Def   *ObjectDef
+	X, Y  float32
+	HP    int
+	Alive bool
+
+	// телепорты связываются по ID: площадка с To переносит на площадку с этим ID
+	ID, To string
+	Link   *Object
+
+	HitFlash float32
+	cool     float32 // до готовности святилища, телепорта или удара шипов
+	phase    float32 // шипы: время в цикле
+	striking bool    // шипы бьют в этом кадре
+	inside   bool    // телепорт: игрок стоит на площадке
+	broken   bool    // сломан, добычу ещё не забрали
+}
+
+func NewObject(def *ObjectDef, x, y float32) *Object {
+	return &Object{Def: def, X: x, Y: y, HP: def.HP, Alive: true}
+}
+
+// Bounds — прямоугольник объекта (по нему — пули и проходимость)
+func (o *Object) Bounds() rl.Rectangle {
+	w, h := o.Def.Size[0], o.Def.Size[1]
+	return rl.NewRectangle(o.X-w/2, o.Y-h, w, h)
+}
+
+// Center — середина объекта и радиус попадания
+func (o *Object) Center() (float32, float32, float32) {
+	w, h := o.Def.Size[0], o.Def.Size[1]
+	return o.X, o.Y - h/2, max(w, h) / 2
+}
+
+// Breakable — объект можно сломать
+func (o *Object) Breakable() bool { return o.Def.HP > 0 }
+
+// Flat — объект лежит на полу (шипы, площадки) и никого не загораживает
+func (o *Object) Flat() bool { return o.Def.Shape == "spikes" || o.Def.Shape == "pad" }
+
+func (o *Object) TakeDamage(dmg int) {
+	if !o.Alive || !o.Breakable() || dmg <= 0 {
+		return
+	}
+	o.HP -= dmg
+	o.HitFlash = hitFlashTime
+	if o.HP <= 0 {
+		o.HP = 0
+		o.Alive = false
+		o.broken = true
+		playCueAt("prop_break", o.X, o.Y)
+	}
+}
+
+// TakeBreak — объект сломан и добычу (взрыв) ещё не выдали; второй вызов — false
+func (o *Object) TakeBreak() bool {
+	b := o.broken
+	o.broken = false
+	return b
+}
+
+func (o *Object) Update(dt float32) {
+	if o.HitFlash > 0 {
+		o.HitFlash -= dt
+	}
+	if o.cool > 0 {
+		o.cool -= dt
+	}
+	o.striking = false
+	if o.Def.Behavior == ObjSpikes {
+		o.phase = float32(math.Mod(float64(o.phase+dt), float64(o.Def.Cooldown)))
+		if o.SpikesUp() && o.cool <= 0 {
+			o.striking = true
+			o.cool = spikeTick
+		}
+	}
+}
+
+// SpikesUp — шипы сейчас торчат
+func (o *Object) SpikesUp() bool { return o.phase < o.Def.Active }
+
+// Striking — шипы бьют всех, кто на них, в этом кадре
+func (o *Object) Striking() bool { return o.striking }
+
+// Ready — святилище или телепорт перезарядились
+func (o *Object) Ready() bool { return o.cool <= 0 }
+
+// Use запускает перезарядку (святилище, телепорт)
+func (o *Object) Use() { o.cool = o.Def.Cooldown }
+
+// Recharge — до готовности осталось left секунд (объект вернулся с чанком)
+func (o *Object) Recharge(left float32) { o.cool = max(left, 0) }
+
+// Enter — телепорт: игрок только что встал на готовую площадку.
+// Пока он на ней стоит, повторно не срабатывает.
+func (o *Object) Enter(inside bool) bool {
+	was := o.inside
+	o.inside = inside
+	return inside && !was && o.Ready()
+}
+
+// Arrive — игрока перенесли сюда: обратно не отправлять, пока не сойдёт
+func (o *Object) Arrive() {
+	o.inside = true
+	o.Use()
+}
+
+// Touches — круг (x, y, r) задевает зону объекта
+func (o *Object) Touches(x, y, r float32) bool {
+	reach := o.Def.Radius
+	if reach <= 0 {
+		reach = o.Def.Size[0] / 2
+	}
+	cy := o.Y - o.Def.Size[1]/2
+	if o.Flat() {
+		cy = o.Y
+	}
+	dx, dy := x-o.X, y-cy
+	return dx*dx+dy*dy <= (reach+r)*(reach+r)
+}
+
+// Submit кладёт объект в очередь отрисовки: плоские — на пол, остальные —
+// с тенью среди персонажей
+func (o *Object) Submit(q *render.Queue) {
+	if o.Flat() {
+		q.Submit(render.LayerGround, o.Y, o.Draw)
+		return
+	}
+	q.Submit(render.LayerShadows, o.Y, func() { drawShadow(o.X, o.Y, o.Def.Size[0]*1.1) })
+	q.Submit(render.LayerActors, o.Y, o.Draw)
+}
+
+func (o *Object) Draw() {
+	c := o.Def.Color
+	base := rl.NewColor(c[0], c[1], c[2], 255)
+	dark := rl.NewColor(c[0]/2, c[1]/2, c[2]/2, 255)
+	r := o.Bounds()
+	w, h := r.Width, r.Height
+	t := float32(clock())
+
+	switch o.Def.Shape {
+	case "crate":
+		rl.DrawRectangleRec(r, base)
+		rl.DrawRectangleLinesEx(r, 3, dark)
+		rl.DrawLineEx(rl.NewVector2(r.X, r.Y), rl.NewVector2(r.X+w, r.Y+h), 3, dark)
+		rl.DrawLineEx(rl.NewVector2(r.X+w, r.Y), rl.NewVector2(r.X, r.Y+h), 3, dark)
+	case "stone":
+		// надгробие: плита со скруглённым верхом и крестом
+		rl.DrawRectangleRounded(r, 0.6, 8, base)
+		rl.DrawRectangleRoundedLines(r, 0.6, 8, dark)
+		rl.DrawRectangleRec(rl.NewRectangle(o.X-2, r.Y+h*0.2, 4, h*0.45), dark)
+		rl.DrawRectangleRec(rl.NewRectangle(o.X-w*0.2, r.Y+h*0.32, w*0.4, 4), dark)
+	case "barrel":
+		rl.DrawRectangleRounded(r, 0.4, 8, base)
+		for _, k := range []float32{0.25, 0.75} {
+			rl.DrawRectangleRec(rl.NewRectangle(r.X, r.Y+h*k-2, w, 4), dark)
+		}
+		rl.DrawCircleV(rl.NewVector2(o.X, r.Y+h/2), w*0.15, rl.NewColor(255, 220, 60, 255))
+	case "spikes":
+		plate := rl.NewRectangle(o.X-w/2, o.Y-h/2, w, h)
+		rl.DrawRectangleRec(plate, dark)
+		const n = 3
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				x := plate.X + w*(float32(i)+0.5)/n
+				y := plate.Y + h*(float32(j)+0.5)/n
+				if o.SpikesUp() {
+					s := w / n * 0.4
+					rl.DrawTriangle(rl.NewVector2(x, y-s), rl.NewVector2(x-s, y+s*0.6), rl.NewVector2(x+s, y+s*0.6), base)
+				} else {
+					rl.DrawCircleV(rl.NewVector2(x, y), 2, rl.Black)
+				}
+			}
+		}
+	case "shrine":
+		rl.DrawRectangleRec(rl.NewRectangle(o.X-w/2, o.Y-h*0.4, w, h*0.4), dark)
+		orb := rl.NewVector2(o.X, o.Y-h*0.7)
+		if o.Ready() {
+			glow := 0.5 + 0.5*float32(math.Sin(float64(t*3)))
+			rl.DrawCircleV(orb, w*0.45, rl.NewColor(c[0], c[1], c[2], uint8(60+60*glow)))
+			rl.DrawCircleV(orb, w*0.28, base)
+		} else {
+			rl.DrawCircleV(orb, w*0.28, rl.NewColor(90, 90, 90, 255))
+		}
+	case "pad":
+		centre := rl.NewVector2(o.X, o.Y)
+		rl.DrawEllipse(int32(o.X), int32(o.Y), w/2, h/2, dark)
+		if o.Ready() && o.Link != nil {
+			k := float32(math.Mod(float64(t), 1))
+			rl.DrawEllipseLines(int32(centre.X), int32(centre.Y), w/2*k, h/2*k, base)
+			rl.DrawEllipseLines(int32(centre.X), int32(centre.Y), w/2, h/2, base)
+		}
+	default:
+		rl.DrawRectangleRec(r, base)
+	}
+
+	if o.HitFlash > 0 {
+		a := uint8(200 * o.HitFlash / hitFlashTime)
+		rl.DrawRectangleRec(r, rl.NewColor(255, 255, 255, a))
+	}
+}
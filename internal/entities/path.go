@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"math"
+
+	"example.com/my2dgame/internal/nav"
+)
+
+// сетка проходимости мира и общее поле потока к игроку; nil — ходим по прямой
+var (
+	navGrid  *nav.Grid
+	navField *nav.Field
+)
+
+// SetNav — сетка проходимости и поле потока к игроку (цель поля обновляет main)
+func SetNav(g *nav.Grid, f *nav.Field) { navGrid, navField = g, f }
+
+// repathEvery — как часто враг пересчитывает свой путь A* к движущейся цели
+const repathEvery = 0.5
+
+// Toward — направление к точке в обход препятствий: напрямую, если видно;
+// по общему полю потока, если точка — его цель; иначе по своему пути A*
+func (e *Enemy) Toward(x, y float32) (float32, float32) {
+	if navGrid == nil || navGrid.Clear(e.X, e.Y, x, y) {
+		e.path, e.pathT = nil, 0
+		return unit(x-e.X, y-e.Y)
+	}
+	cell := navGrid.Size
+	if navField != nil && near(navField.Target(), x, y, cell) {
+		if dx, dy, ok := navField.Dir(e.X, e.Y); ok {
+			return dx, dy
+		}
+	}
+	if e.pathT <= 0 || !near(e.pathGoal, x, y, cell) {
+		e.path = navGrid.Path(e.X, e.Y, x, y)
+		e.pathGoal = nav.Point{X: x, Y: y}
+		e.pathT = repathEvery
+	}
+	// пройденные точки выбрасываем
+	for len(e.path) > 1 && near(e.path[0], e.X, e.Y, cell/2) {
+		e.path = e.path[1:]
+	}
+	if len(e.path) == 0 {
+		return 0, 0 // не дойти
+	}
+	return unit(e.path[0].X-e.X, e.path[0].Y-e.Y)
+}
+
+// UpdateFar — упрощённый шаг для врага далеко от игрока (вне окна навигации):
+// без мозга, стрельбы и поиска пути — просто идёт к цели по прямой
+func (e *Enemy) UpdateFar(dt float32, t Target) {
+	if e.State != EnemyActive {
+		e.updateDeath(dt)
+		e.updateShots(dt)
+		return
+	}
+	kx, ky := e.Step(dt)
+	nx, ny := unit(t.X-e.X, t.Y-e.Y)
+	e.X += kx + nx*e.Speed*dt
+	e.Y += ky + ny*e.Speed*dt
+	e.Face(nx)
+	e.path, e.pathT = nil, 0
+	// вошёл в окно навигации внутри стены — выталкиваем на ближайшую клетку
+	if navGrid != nil && navGrid.Contains(e.X, e.Y) && !navGrid.Walkable(e.X, e.Y) {
+		e.X, e.Y, _ = navGrid.Nearest(e.X, e.Y)
+	}
+	e.updateShots(dt)
+	e.updateFreeze(dt)
+}
+
+// moveBy сдвигает врага, скользя вдоль препятствий
+func (e *Enemy) moveBy(dx, dy float32) {
+	if navGrid == nil {
+		e.X += dx
+		e.Y += dy
+		return
+	}
+	e.X, e.Y = navGrid.Slide(e.X, e.Y, e.X+dx, e.Y+dy)
+}
+
+func near(p nav.Point, x, y, r float32) bool {
+	return (p.X-x)*(p.X-x)+(p.Y-y)*(p.Y-y) <= r*r
+}
+
+func unit(dx, dy float32) (float32, float32) {
+	l := float32(math.Hypot(float64(dx), float64(dy)))
+	if l < 1e-3 {
+		return 0, 0
+	}
+	return dx / l, dy / l
+}
@@ -0,0 +1,338 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"example.com/my2dgame/internal/anim"
+	"example.com/my2dgame/internal/render"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Виды подбираемого
+const (
+	PickupSoul   = "soul"   // душа: копится и заряжает ульту
+	PickupHealth = "health" // лечит
+	PickupPower  = "power"  // временное усиление (Buff*)
+	PickupMagnet = "magnet" // притягивает к игроку все души
+	PickupBomb   = "bomb"   // взрыв вокруг игрока, ранит всех, кроме него
+	PickupGold   = "gold"   // золото
+)
+
+// absorbSpeed — с какой скоростью притянутый предмет летит к игроку
+const absorbSpeed = 600
+
+// PickupDef — вид подбираемого предмета (data/pickups.json, раздел items)
+type PickupDef struct {
+	Name   string `json:"-"`
+	Kind   string `json:"kind"`
+	Amount int    `json:"amount"` // души, HP, золото
+
+	Power    string  `json:"power"`    // power: speed | rapid | damage
+	Mult     float32 `json:"mult"`     // power: множитель
+	Duration float32 `json:"duration"` // power: секунд
+
+	Damage int     `json:"damage"` // bomb
+	Radius float32 `json:"radius"` // bomb
+
+	Life  float32 `json:"life"`  // секунд до исчезновения; 0 — не исчезает
+	Blink float32 `json:"blink"` // за сколько секунд до исчезновения мигает
+
+	Motion string   `json:"motion"` // "" — выскакивает и лежит; spiral — кружит (души)
+	Sprite string   `json:"sprite"` // анимация от textures/; пусто — рисуем фигурой
+	Scale  float32  `json:"scale"`
+	Size   float32  `json:"size"` // радиус фигуры
+	Color  [3]uint8 `json:"color"`
+
+	Sound string `json:"sound"` // звук подбора
+	FX    string `json:"fx"`    // частицы подбора
+	Trail string `json:"trail"` // частицы, пока предмет лежит
+
+	clip *anim.Clip // спрайт грузится один раз на вид
+}
+
+// LootEntry — строка таблицы добычи: Item (или один из OneOf) с шансом Chance
+// в количестве от Count[0] до Count[1]
+type LootEntry struct {
+	Item   string   `json:"item"`
+	OneOf  []string `json:"one_of"`
+	Chance float32  `json:"chance"` // 0 — всегда
+	Count  [2]int   `json:"count"`  // 0 — одна штука
+}
+
+// Loot — виды предметов и таблицы добычи по архетипам (вид врага, elite,
+// boss, объекты мира)
+type Loot struct {
+	Items  map[string]*PickupDef  `json:"items"`
+	Tables map[string][]LootEntry `json:"tables"`
+}
+
+// LoadLoot читает и проверяет pickups.json
+func LoadLoot(path string) (*Loot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var l Loot
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("pickups %s: %w", path, err)
+	}
+	for name, d := range l.Items {
+		d.Name = name
+		switch d.Kind {
+		case PickupSoul, PickupHealth, PickupGold:
+			if d.Amount <= 0 {
+				d.Amount = 1
+			}
+		case PickupPower:
+			if d.Power == "" || d.Mult <= 0 || d.Duration <= 0 {
+				return nil, fmt.Errorf("pickup %q: усилению нужны power, mult и duration", name)
+			}
+		case PickupBomb:
+			if d.Damage <= 0 || d.Radius <= 0 {
+				return nil, fmt.Errorf("pickup %q: бомбе нужны damage и radius", name)
+			}
+		case PickupMagnet:
+		default:
+			return nil, fmt.Errorf("pickup %q: неизвестный вид %q", name, d.Kind)
+		}
+		if d.Scale <= 0 {
+			d.Scale = 1
+		}
+		if d.Size <= 0 {
+			d.Size = 8
+		}
+	}
+	for name, t := range l.Tables {
+		for i := range t {
+			e := &t[i]
+			items := e.OneOf
+			if e.Item != "" {
+				items = append(items, e.Item)
+			}
+			if len(items) == 0 {
+				return nil, fmt.Errorf("loot %q: строка %d без item", name, i)
+			}
+			for _, it := range items {
+				if l.Items[it] == nil {
+					return nil, fmt.Errorf("loot %q: нет предмета %q", name, it)
+				}
+			}
+			if e.Chance <= 0 {
+				e.Chance = 1
+			}
+			e.Count[0] = max(e.Count[0], 1)
+			e.Count[1] = max(e.Count[1], e.Count[0])
+		}
+	}
+	return &l, nil
+}
+
+// Roll — что выпало по таблице; нет таблицы — ничего
+func (l *Loot) Roll(rng *rand.Rand, table string) []*PickupDef {
+	var out []*PickupDef
+	for _, e := range l.Tables[table] {
+		if rng.Float32() >= e.Chance {
+			continue
+		}
+		n := e.Count[0] + rng.Intn(e.Count[1]-e.Count[0]+1)
+		for i := 0; i < n; i++ {
+			name := e.Item
+			if len(e.OneOf) > 0 {
+				name = e.OneOf[rng.Intn(len(e.OneOf))]
+			}
+			out = append(out, l.Items[name])
+		}
+	}
+	return out
+}
+
+// Pickup — предмет на земле. Притянутый летит к игроку и, долетев, считается
+// подобранным: что он даёт, решает main по Def.
+type Pickup struct {
+	Def       *PickupDef
+	X, Y      float32
+	Alive     bool
+	Absorbing bool    // летит к игроку
+	Age       float32 // сколько лежит
+	Alpha     float32
+
+	collected bool
+	vx, vy    float32 // разлёт при появлении
+
+	// кружение душ
+	baseX, baseY  float32
+	radius, angle float32
+	spin          float32
+	rotDeg        float32
+	anim          anim.Animator
+}
+
+// NewPickup кладёт предмет в (x, y); обычные разлетаются в случайную сторону
+func NewPickup(assetsRoot string, def *PickupDef, x, y float32) (*Pickup, error) {
+	if def.Sprite != "" && def.clip == nil {
+		clip, err := anim.LoadFromJSON(filepath.Join(assetsRoot, "textures", filepath.FromSlash(def.Sprite)))
+		if err != nil {
+			return nil, err
+		}
+		def.clip = clip
+	}
+	dir := rand.Float32() * 2 * math.Pi
+	p := &Pickup{
+		Def: def,
+		X:   x, Y: y,
+		Alive: true,
+		Alpha: 1,
+
+		baseX: x, baseY: y,
+		angle: dir,
+		spin:  2 + rand.Float32()*2, // вращение
+	}
+	if def.Motion == "" {
+		sp := 120 + rand.Float32()*120
+		p.vx, p.vy = float32(math.Cos(float64(dir)))*sp, float32(math.Sin(float64(dir)))*sp
+	}
+	if def.clip != nil {
+		p.anim.Play(def.clip, true)
+	}
+	return p, nil
+}
+
+// Attract — притянуть к игроку (магнит, крюк)
+func (p *Pickup) Attract() { p.Absorbing = true }
+
+// TakeCollected — предмет долетел до игрока; второй вызов — false
+func (p *Pickup) TakeCollected() bool {
+	c := p.collected
+	p.collected = false
+	return c
+}
+
+// Blinking — скоро исчезнет
+func (p *Pickup) Blinking() bool {
+	return p.Def.Life > 0 && !p.Absorbing && p.Def.Life-p.Age < p.Def.Blink
+}
+
+func (p *Pickup) Update(dt float32, playerX, playerY float32) {
+	if !p.Alive {
+		return
+	}
+	p.anim.Update(dt)
+
+	// притянутый — летит к игроку и тает
+	if p.Absorbing {
+		dx, dy := playerX-p.X, playerY-p.Y
+		dist := float32(math.Hypot(float64(dx), float64(dy)))
+		step := float32(absorbSpeed) * dt
+		if dist > step {
+			p.X += dx / dist * step
+			p.Y += dy / dist * step
+		} else {
+			p.X, p.Y = playerX, playerY
+		}
+		p.Alpha -= dt * 4 // исчезнет примерно за 0.25 с
+		if p.Alpha <= 0 || dist <= step {
+			p.Alive = false
+			p.collected = true
+		}
+		return
+	}
+
+	p.Age += dt
+	if p.Def.Life > 0 && p.Age > p.Def.Life {
+		p.Alive = false
+		return
+	}
+
+	if p.Def.Motion == "spiral" {
+		p.updateSpiral(dt)
+		return
+	}
+	// разлёт с трением
+	p.X += p.vx * dt
+	p.Y += p.vy * dt
+	k := float32(math.Exp(-6 * float64(dt)))
+	p.vx *= k
+	p.vy *= k
+}
+
+// updateSpiral — души кружат вокруг места, где появились
+func (p *Pickup) updateSpiral(dt float32) {
+	slow := float32(math.Exp(-float64(p.radius) * 0.015))
+	p.angle += p.spin * slow * dt
+
+	target := 60 + 80*float32(math.Sin(float64(p.Age*0.5)))
+	p.radius += (target - p.radius) * 0.5 * dt
+
+	p.X = p.baseX + float32(math.Cos(float64(p.angle)))*p.radius
+	p.Y = p.baseY + float32(math.Sin(float64(p.angle)))*p.radius*0.4 +
+		5*float32(math.Sin(float64(p.Age*2)))
+
+	dx := math.Cos(float64(p.angle))
+	dy := math.Sin(float64(p.angle)) * 0.4
+	wobble := math.Sin(float64(p.Age*6)) * (15 * math.Pi / 180)
+	p.rotDeg = float32((math.Atan2(dy, dx)+wobble)*180/math.Pi) + 180
+}
+
+// Submit — предметы летают и лежат среди персонажей, сортируются по своему Y
+func (p *Pickup) Submit(q *render.Queue) {
+	if p.Alive {
+		q.Submit(render.LayerActors, p.Y, p.Draw)
+	}
+}
+
+func (p *Pickup) Draw() {
+	if !p.Alive {
+		return
+	}
+	// перед исчезновением мигает, и всё чаще
+	if p.Blinking() {
+		left := p.Def.Life - p.Age
+		hz := 4 + 8*(1-left/p.Def.Blink)
+		if int(p.Age*hz)%2 == 0 {
+			return
+		}
+	}
+	a := uint8(255 * min(max(p.Alpha, 0), 1))
+	tint := rl.Color{R: 255, G: 255, B: 255, A: a}
+
+	if p.Def.clip != nil {
+		p.anim.DrawRotated(p.X, p.Y, p.Def.Scale, p.rotDeg, tint)
+		return
+	}
+
+	c := p.Def.Color
+	col := rl.NewColor(c[0], c[1], c[2], a)
+	r := p.Def.Size * p.Def.Scale
+	// лежащее покачивается
+	y := p.Y - 4 - 3*float32(math.Sin(float64(p.Age*4)))
+	centre := rl.NewVector2(p.X, y)
+	white := rl.NewColor(255, 255, 255, a)
+	switch p.Def.Kind {
+	case PickupHealth:
+		rl.DrawCircleV(centre, r, col)
+		rl.DrawRectangleRec(rl.NewRectangle(p.X-r*0.55, y-r*0.18, r*1.1, r*0.36), white)
+		rl.DrawRectangleRec(rl.NewRectangle(p.X-r*0.18, y-r*0.55, r*0.36, r*1.1), white)
+	case PickupPower:
+		rl.DrawPoly(centre, 4, r*1.2, 0, col)
+		rl.DrawPolyLinesEx(centre, 4, r*1.2, 0, 2, white)
+	case PickupMagnet:
+		rl.DrawRing(centre, r*0.5, r, 180, 360, 12, col)
+		rl.DrawRectangleRec(rl.NewRectangle(p.X-r, y, r*0.5, r*0.6), rl.NewColor(220, 40, 40, a))
+		rl.DrawRectangleRec(rl.NewRectangle(p.X+r*0.5, y, r*0.5, r*0.6), rl.NewColor(220, 40, 40, a))
+	case PickupBomb:
+		rl.DrawCircleV(centre, r, col)
+		rl.DrawLineEx(rl.NewVector2(p.X+r*0.5, y-r*0.7), rl.NewVector2(p.X+r, y-r*1.3), 2, rl.NewColor(140, 100, 60, a))
+		if int(p.Age*8)%2 == 0 {
+			rl.DrawCircleV(rl.NewVector2(p.X+r, y-r*1.3), 2.5, rl.NewColor(255, 200, 60, a))
+		}
+	case PickupGold:
+		rl.DrawEllipse(int32(p.X), int32(y), r*float32(math.Abs(math.Cos(float64(p.Age*3))))+1, r, col)
+	default:
+		rl.DrawCircleV(centre, r, col)
+	}
+}
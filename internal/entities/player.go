@@ -1,9 +1,11 @@
 package entities
 
 import (
+	"math"
 	"path/filepath"
 
 	"example.com/my2dgame/internal/anim"
+	"example.com/my2dgame/internal/render"
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
@@ -16,17 +18,25 @@ type Player struct {
 	A            anim.Animator
 	Scale        float32
 	HP           int
+	MaxHP        int
 	Radius       float32
 	InvulnTimer  float32
 	HurtFlash    float32
+	Body
 
 	// 🔫 Стрельба
 	Shots      []*Projectile
 	CanShoot   bool
 	FireTimer  float32
 	FirePeriod float32
+	Weapon     *Weapon
+	Fired      int // пуль выпущено за забег
 
 	Souls int
+	Gold  int
+
+	Magnet float32 // радиус, в котором предметы сами летят к игроку
+	Buffs  []*Buff
 
 	Crook         *Crook
 	CrookReady    bool
@@ -34,6 +44,22 @@ type Player struct {
 	CrookCooldown float32
 
 	Ult *Ultimate
+
+	// 💨 Рывок: короткий бросок с неуязвимостью
+	DashSpeed    float32
+	DashTime     float32
+	DashCooldown float32
+	DashTimer    float32 // > 0 — идёт рывок
+	DashReload   float32 // > 0 — рывок перезаряжается
+	dashX, dashY float32
+}
+
+// PlayerInput — управление игроком за кадр (собирает input.Controller)
+type PlayerInput struct {
+	MoveX, MoveY float32 // длина ≤ 1
+	AimX, AimY   float32 // точка прицела в мировых координатах
+	Fire         bool
+	Dash         bool // нажат в этом кадре
 }
 
 func NewPlayer(assetsRoot string) (*Player, error) {
@@ -50,43 +76,51 @@ func NewPlayer(assetsRoot string) (*Player, error) {
 		CrookThrow: crookThrow,
 		Scale:      1.25,
 		HP:         100,
+		MaxHP:      100,
 		Radius:     18,
+		Body:       Body{Mass: 1, Drag: 12},
 		// HurtFlash: 0, // по умолчанию
 
 		CanShoot:   true,
-		FirePeriod: 0.4,
+		FirePeriod: DefaultWeapon.Period,
+		Weapon:     DefaultWeapon,
+
+		Magnet: 100,
 
 		CrookReady:    true,
 		CrookCooldown: 3.0,
+
+		DashSpeed:    900,
+		DashTime:     0.15,
+		DashCooldown: 0.8,
 	}
-	p.Ult = NewUltimate(assetsRoot)
+	p.Ult = NewUltimate()
 
 	p.PrevX, p.PrevY = p.X, p.Y
 	p.A.Play(p.Idle, true)
 	return p, nil
 }
 
-func (p *Player) Update(dt float32, camera *rl.Camera2D) {
+func (p *Player) Update(dt float32, in PlayerInput) {
 	p.PrevX, p.PrevY = p.X, p.Y
-	moveX, moveY := float32(0), float32(0)
+	moveX, moveY := in.MoveX, in.MoveY
+	aimX, aimY := in.AimX, in.AimY
 
-	if rl.IsKeyDown(rl.KeyA) || rl.IsKeyDown(rl.KeyLeft) {
-		moveX -= 1
+	// рывок — по направлению движения, а стоя на месте — к прицелу
+	if p.DashReload > 0 {
+		p.DashReload -= dt
 	}
-	if rl.IsKeyDown(rl.KeyD) || rl.IsKeyDown(rl.KeyRight) {
-		moveX += 1
-	}
-	if rl.IsKeyDown(rl.KeyW) || rl.IsKeyDown(rl.KeyUp) {
-		moveY -= 1
-	}
-	if rl.IsKeyDown(rl.KeyS) || rl.IsKeyDown(rl.KeyDown) {
-		moveY += 1
-	}
-
-	// нормализация диагонали
-	if moveX != 0 && moveY != 0 {
-		moveX *= 0.7071
-		moveY *= 0.7071
+	if in.Dash && p.DashReload <= 0 && p.DashTimer <= 0 {
+		dx, dy := moveX, moveY
+		if dx == 0 && dy == 0 {
+			dx, dy = aimX-p.X, aimY-p.Y
+		}
+		if n := float32(math.Hypot(float64(dx), float64(dy))); n > 0 {
+			p.dashX, p.dashY = dx/n, dy/n
+			p.DashTimer = p.DashTime
+			p.DashReload = p.DashCooldown
+			p.InvulnTimer = max(p.InvulnTimer, p.DashTime)
+		}
 	}
 
 	// зеркалирование анимации
@@ -97,30 +131,40 @@ func (p *Player) Update(dt float32, camera *rl.Camera2D) {
 		p.A.FlipX = false
 	}
 
-	p.X += moveX * p.Speed * dt
-	p.Y += moveY * p.Speed * dt
+	if p.DashTimer > 0 {
+		p.DashTimer -= dt
+		p.X += p.dashX * p.DashSpeed * dt
+		p.Y += p.dashY * p.DashSpeed * dt
+	} else {
+		speed := p.Speed * p.BuffMult(BuffSpeed)
+		p.X += moveX * speed * dt
+		p.Y += moveY * speed * dt
+	}
+
+	// отбрасывание от ударов
+	kx, ky := p.Step(dt)
+	p.X += kx
+	p.Y += ky
 
 	// ⏳ таймер стрельбы
 	p.FireTimer -= dt
 
-	// 🔫 стрельба на ПКМ
+	// 🔫 стрельба (по умолчанию ПКМ)
 	p.FireTimer -= dt
-	if p.CanShoot && rl.IsMouseButtonDown(rl.MouseRightButton) && p.FireTimer <= 0 {
-		mouse := rl.GetMousePosition()
-		world := rl.GetScreenToWorld2D(mouse, *camera)
-
+	if p.CanShoot && in.Fire && p.FireTimer <= 0 {
 		// Центр игрока
 		f := p.A.Current.Frames[p.A.FrameIndex]
 		centerX := p.X - float32(f.OrigX)*p.Scale + float32(f.Src.Width)*p.Scale/2
 		centerY := p.Y - float32(f.OrigY)*p.Scale + float32(f.Src.Height)*p.Scale/2
 
 		// Вектор направления от центра снаряда к курсору
-		dx := world.X - centerX
-		dy := world.Y - centerY
+		dx := aimX - centerX
+		dy := aimY - centerY
 
-		shot := NewGhostBolt(centerX, centerY, dx, dy)
-		p.Shots = append(p.Shots, shot)
-		p.FireTimer = p.FirePeriod
+		shots := p.Weapon.fire(centerX, centerY, dx, dy)
+		p.Shots = append(p.Shots, shots...)
+		p.Fired += len(shots)
+		p.FireTimer = p.FirePeriod / p.BuffMult(BuffRapid)
 	}
 
 	// обновляем все снаряды
@@ -134,6 +178,7 @@ func (p *Player) Update(dt float32, camera *rl.Camera2D) {
 	p.Shots = out
 
 	// таймеры игрока
+	p.updateBuffs(dt)
 	p.A.Update(dt)
 	if p.InvulnTimer > 0 {
 		p.InvulnTimer -= dt
@@ -156,6 +201,14 @@ func (p *Player) Update(dt float32, camera *rl.Camera2D) {
 	}
 }
 
+// Velocity — скорость за последний кадр по PrevX/PrevY
+func (p *Player) Velocity(dt float32) (float32, float32) {
+	if dt <= 0 {
+		return 0, 0
+	}
+	return (p.X - p.PrevX) / dt, (p.Y - p.PrevY) / dt
+}
+
 func (p *Player) TakeDamage(dmg int) {
 	if dmg <= 0 || p.HP <= 0 || p.InvulnTimer > 0 {
 		return
@@ -168,13 +221,43 @@ func (p *Player) TakeDamage(dmg int) {
 	p.HurtFlash = 0.25  // 🔴 250 мс красный флэш
 }
 
-func (p *Player) Unload() {
-	if p.Ult != nil {
-		p.Ult.Unload()
+// SetWeapon — сменить оружие (вместе с темпом стрельбы)
+func (p *Player) SetWeapon(w *Weapon) {
+	p.Weapon = w
+	p.FirePeriod = w.Period
+}
+
+// Heal лечит, но не выше MaxHP
+func (p *Player) Heal(hp int) {
+	if hp <= 0 || p.HP <= 0 {
+		return
 	}
+	p.HP = min(p.HP+hp, p.MaxHP)
 }
 
-func (p *Player) Draw(camera rl.Camera2D) {
+// Foot — точка ног игрока (спрайт рисуется центром в X, Y)
+func (p *Player) Foot() (float32, float32) {
+	if p.A.Current != nil && p.A.FrameIndex < len(p.A.Current.Frames) {
+		return p.X, p.Y + p.A.Current.Frames[p.A.FrameIndex].Src.Height*p.Scale/2
+	}
+	return p.X, p.Y
+}
+
+// Submit кладёт игрока, тень, крюк и снаряды в очередь отрисовки
+func (p *Player) Submit(q *render.Queue) {
+	fx, fy := p.Foot()
+	w := p.Radius * 2
+	q.Submit(render.LayerShadows, fy, func() { drawShadow(fx, fy, w) })
+	q.Submit(render.LayerActors, fy, p.Draw)
+	if p.Crook != nil && p.Crook.Active {
+		q.Submit(render.LayerProjectiles, p.Crook.Y, func() { p.Crook.Draw(p.X, p.Y) })
+	}
+	for _, s := range p.Shots {
+		q.Submit(render.LayerProjectiles, s.Y, s.Draw)
+	}
+}
+
+func (p *Player) Draw() {
 	tint := rl.White
 	if p.HurtFlash > 0 {
 		tint = rl.NewColor(255, 64, 64, 255)
@@ -193,29 +276,4 @@ func (p *Player) Draw(camera rl.Camera2D) {
 		// запасной вариант
 		p.A.Draw(p.X, p.Y, p.Scale, tint)
 	}
-
-	if p.Crook != nil && p.Crook.Active {
-		p.Crook.Draw(p.X, p.Y)
-	}
-
-	if p.Ult != nil && p.Ult.flashActive {
-		t := p.Ult.flashTime / p.Ult.flashDur
-		if t > 1 {
-			t = 1
-		}
-
-		// плавное затухание прозрачности
-		alpha := uint8(180 * (1 - t))
-		color := rl.NewColor(50, 255, 50, alpha)
-
-		radius := 120 * p.Scale
-		rl.DrawCircleV(rl.NewVector2(p.X, p.Y), radius, color)
-	}
-
-	// отрисовка снарядов с учётом камеры (пули в мировых координатах)
-	rl.BeginMode2D(camera)
-	for _, s := range p.Shots {
-		s.Draw()
-	}
-	rl.EndMode2D()
 }
@@ -53,6 +53,12 @@ func LoadProjectileAssets(assetsRoot string) error {
 	return nil
 }
 
+// Скорости снарядов (пикс/с)
+const (
+	PlayerBoltSpeed = 400
+	EnemyBoltSpeed  = 300
+)
+
 type Projectile struct {
 	X, Y         float32
 	PrevX, PrevY float32
@@ -65,6 +71,7 @@ type Projectile struct {
 	FromPlayer   bool
 	tex          *rl.Texture2D
 	Damage       int
+	Knockback    float32 // импульс при попадании, пикс/с при массе 1
 }
 
 // Универсальный конструктор
@@ -82,10 +89,10 @@ func NewProjectile(x, y, dirX, dirY float32, fromPlayer bool) *Projectile {
 		tex = &slimeBoltTex
 	}
 
-	speed := float32(400)
+	speed := float32(PlayerBoltSpeed)
 	scale := float32(1.3)
 	if !fromPlayer {
-		speed = 300
+		speed = EnemyBoltSpeed
 		scale = 1.4
 	}
 
@@ -101,6 +108,7 @@ func NewProjectile(x, y, dirX, dirY float32, fromPlayer bool) *Projectile {
 		FromPlayer: fromPlayer,
 		tex:        tex,
 		Damage:     10,
+		Knockback:  260,
 	}
 }
 
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"example.com/my2dgame/internal/anim"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+var shadowColor = rl.NewColor(0, 0, 0, 90)
+
+// drawShadow — круглая тень-клякса под ногами шириной w
+func drawShadow(x, y, w float32) {
+	if w <= 0 {
+		return
+	}
+	rl.DrawEllipse(int32(x), int32(y), w/2, w/6, shadowColor)
+}
+
+// footprint — середина нижнего края текущего кадра и ширина тени для спрайта,
+// нарисованного через Animator.Draw в точке (x, y)
+func footprint(a *anim.Animator, x, y, scale float32) (fx, fy, w float32) {
+	if a.Current == nil || a.FrameIndex >= len(a.Current.Frames) {
+		return x, y, 0
+	}
+	f := a.Current.Frames[a.FrameIndex]
+	left := x - float32(f.OrigX)*scale
+	top := y - float32(f.OrigY)*scale
+	return left + f.Src.Width*scale/2, top + f.Src.Height*scale, f.Src.Width * scale * 0.6
+}
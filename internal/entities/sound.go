@@ -0,0 +1,17 @@
+package entities
+
+import "example.com/my2dgame/internal/audio"
+
+// звук игры; nil — тишина
+var sounds *audio.Manager
+
+// SetAudio — менеджер звука для сущностей; вызываем один раз из main
+func SetAudio(m *audio.Manager) { sounds = m }
+
+// playCueAt — именованный звук из audio.json в точке мира
+// (громкость и панорама — от слушателя)
+func playCueAt(name string, x, y float32) {
+	if sounds != nil {
+		sounds.PlayAt(name, x, y)
+	}
+}
@@ -2,45 +2,39 @@ package entities
 
 import (
 	"math"
-	"path/filepath"
 	"time"
+)
 
-	rl "github.com/gen2brain/raylib-go/raylib"
+// Виды ульты
+const (
+	UltFreeze = "freeze" // замораживает врагов вокруг
+	UltNova   = "nova"   // бьёт всех врагов вокруг
 )
 
+// novaDamage — урон ульты nova
+const novaDamage = 80
+
 type Ultimate struct {
+	Kind        string  // UltFreeze | UltNova
 	Charge      int     // 0–3 (всего 3 заряда)
 	MaxCharge   int     // всегда 3
 	Active      bool    // активна ли сейчас
 	Duration    float32 // сколько секунд длится эффект
 	Timer       float32 // таймер эффекта
-	Sound       rl.Sound
 	LastUsed    time.Time
 	FreezeRange float32
 
 	partialSouls int
-
-	flashActive bool
-	flashTime   float32
-	flashDur    float32
 }
 
 // Создание ульты
-func NewUltimate(assetsRoot string) *Ultimate {
-	snd := rl.LoadSound(filepath.Join(assetsRoot, "sounds", "stop.mp3"))
-	rl.SetSoundVolume(snd, 0.8)
+func NewUltimate() *Ultimate {
 	return &Ultimate{
+		Kind:        UltFreeze,
 		MaxCharge:   3,
 		Charge:      0,
 		Duration:    3.5,   // 3.5 сек заморозки
 		FreezeRange: 700.0, // радиус действия
-		Sound:       snd,
-	}
-}
-
-func (u *Ultimate) Unload() {
-	if u.Sound.FrameCount > 0 {
-		rl.UnloadSound(u.Sound)
 	}
 }
 
@@ -70,6 +64,9 @@ func (u *Ultimate) Update(dt float32, enemies []*Enemy) {
 	u.Timer -= dt
 	if u.Timer <= 0 {
 		u.Active = false
+		if u.Kind != UltFreeze {
+			return
+		}
 		// размораживаем врагов
 		for _, e := range enemies {
 			if !e.Alive {
@@ -79,32 +76,21 @@ func (u *Ultimate) Update(dt float32, enemies []*Enemy) {
 			e.CanShoot = e.Kind != "melee"
 		}
 	}
-
-	if u.flashActive {
-		u.flashTime += dt
-		if u.flashTime >= u.flashDur {
-			u.flashActive = false
-		}
-	}
 }
 
-// Активация ульты (при нажатии E)
-func (u *Ultimate) TryActivate(player *Player, enemies []*Enemy) {
+// Активация ульты (при нажатии E); true — ульта сработала
+func (u *Ultimate) TryActivate(player *Player, enemies []*Enemy) bool {
 	if u.Active || u.Charge < u.MaxCharge {
-		return
+		return false
 	}
 
 	u.Active = true
 	u.Timer = u.Duration
 	u.Charge = 0
 
-	u.flashActive = true
-	u.flashTime = 0
-	u.flashDur = 0.4 // длительность вспышки ~0.4 сек
-
-	rl.PlaySound(u.Sound)
+	playCueAt("ult_freeze", player.X, player.Y)
 
-	// Заморозим врагов
+	// Заморозим (или ударим) врагов
 	for _, e := range enemies {
 		if !e.Alive {
 			continue
@@ -112,13 +98,18 @@ func (u *Ultimate) TryActivate(player *Player, enemies []*Enemy) {
 		dx := e.X - player.X
 		dy := e.Y - player.Y
 		if math.Hypot(float64(dx), float64(dy)) <= float64(u.FreezeRange) {
-			e.Speed = 0
-			e.CanShoot = false
-			e.FreezeTimer = u.Duration
+			if u.Kind == UltNova {
+				e.TakeDamage(novaDamage)
+			} else {
+				e.Speed = 0
+				e.CanShoot = false
+				e.FreezeTimer = u.Duration
+			}
 		}
 		// очистим летящие снаряды
 		if len(e.Shots) > 0 {
 			e.Shots = e.Shots[:0]
 		}
 	}
+	return true
 }
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Weapon — оружие игрока (data/weapons.json, ключ — id)
+type Weapon struct {
+	Name   string  `json:"-"`
+	Damage int     `json:"damage"` // урон одной пули
+	Period float32 `json:"period"` // секунд между выстрелами
+	Shots  int     `json:"shots"`  // пуль за выстрел
+	Spread float32 `json:"spread"` // веер, градусов между крайними пулями
+	Speed  float32 `json:"speed"`  // множитель скорости пули
+	Scale  float32 `json:"scale"`  // множитель размера пули
+}
+
+// DefaultWeapon — призрачный болт, с которым игрок начинает
+var DefaultWeapon = &Weapon{Name: "bolt", Damage: 20, Period: 0.4, Shots: 1, Speed: 1, Scale: 1}
+
+// LoadWeapons читает виды оружия
+func LoadWeapons(path string) (map[string]*Weapon, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var ws map[string]*Weapon
+	if err := json.Unmarshal(data, &ws); err != nil {
+		return nil, fmt.Errorf("weapons %s: %w", path, err)
+	}
+	for name, w := range ws {
+		w.Name = name
+		if w.Damage <= 0 || w.Period <= 0 {
+			return nil, fmt.Errorf("weapon %q: нужны damage и period > 0", name)
+		}
+		w.Shots = max(w.Shots, 1)
+		if w.Speed <= 0 {
+			w.Speed = 1
+		}
+		if w.Scale <= 0 {
+			w.Scale = 1
+		}
+	}
+	return ws, nil
+}
+
+// fire — пули одного выстрела из (x, y) в сторону (dx, dy), веером
+func (w *Weapon) fire(x, y, dx, dy float32) []*Projectile {
+	base := math.Atan2(float64(dy), float64(dx))
+	step := 0.0
+	if w.Shots > 1 {
+		step = float64(w.Spread) * math.Pi / 180 / float64(w.Shots-1)
+	}
+	shots := make([]*Projectile, 0, w.Shots)
+	for i := 0; i < w.Shots; i++ {
+		a := base + step*(float64(i)-float64(w.Shots-1)/2)
+		s := NewGhostBolt(x, y, float32(math.Cos(a)), float32(math.Sin(a)))
+		s.Damage = w.Damage
+		s.Speed *= w.Speed
+		s.Scale *= w.Scale
+		s.HitRadius *= w.Scale
+		shots = append(shots, s)
+	}
+	return shots
+}
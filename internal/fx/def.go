@@ -0,0 +1,104 @@
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Формы частиц
+const (
+	ShapeCircle  = "circle"
+	ShapeSquare  = "square"
+	ShapeTexture = "texture"
+)
+
+type Color struct{ R, G, B, A uint8 }
+
+// Def — описание эмиттера в данных (assets/data/fx.json)
+type Def struct {
+	Name     string     `json:"-"`
+	Rate     float32    `json:"rate"`     // частиц/с у постоянного эмиттера
+	Burst    int        `json:"burst"`    // частиц за один Emit
+	Life     [2]float32 `json:"life"`     // мин/макс время жизни, с
+	Speed    [2]float32 `json:"speed"`    // мин/макс начальная скорость, пикс/с
+	Angle    float32    `json:"angle"`    // направление конуса, градусы (0 — вправо)
+	Cone     float32    `json:"cone"`     // ширина конуса, градусы (360 — во все стороны)
+	Spawn    float32    `json:"spawn"`    // радиус разброса точки появления
+	Gravity  float32    `json:"gravity"`  // пикс/с², вниз
+	Drag     float32    `json:"drag"`     // затухание скорости, 1/с
+	Spin     [2]float32 `json:"spin"`     // градусы/с
+	Sizes    Curve      `json:"sizes"`    // [[t, размер], ...], t — доля жизни 0..1
+	Colors   ColorCurve `json:"colors"`   // [[t, r, g, b, a], ...]
+	Shape    string     `json:"shape"`    // circle | square | texture
+	Texture  string     `json:"texture"`  // путь от assets, для shape=texture
+	Additive bool       `json:"additive"` // аддитивное смешивание
+}
+
+// Curve — кусочно-линейная кривая по ключам [t, v]
+type Curve [][2]float32
+
+func (c Curve) At(t float32) float32 {
+	if len(c) == 0 {
+		return 1
+	}
+	if t <= c[0][0] {
+		return c[0][1]
+	}
+	for i := 1; i < len(c); i++ {
+		if t <= c[i][0] {
+			a, b := c[i-1], c[i]
+			k := (t - a[0]) / (b[0] - a[0])
+			return a[1] + (b[1]-a[1])*k
+		}
+	}
+	return c[len(c)-1][1]
+}
+
+// ColorCurve — кусочно-линейная кривая цвета по ключам [t, r, g, b, a]
+type ColorCurve [][5]float32
+
+func (c ColorCurve) At(t float32) Color {
+	if len(c) == 0 {
+		return Color{255, 255, 255, 255}
+	}
+	k0, k1, k := c[0], c[0], float32(0)
+	if t > c[0][0] {
+		k0 = c[len(c)-1]
+		k1 = k0
+		for i := 1; i < len(c); i++ {
+			if t <= c[i][0] {
+				k0, k1 = c[i-1], c[i]
+				k = (t - k0[0]) / (k1[0] - k0[0])
+				break
+			}
+		}
+	}
+	lerp := func(i int) uint8 { return uint8(k0[i] + (k1[i]-k0[i])*k) }
+	return Color{lerp(1), lerp(2), lerp(3), lerp(4)}
+}
+
+// LoadDefs читает {"имя": Def, ...}
+func LoadDefs(path string) (map[string]*Def, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs map[string]*Def
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("fx %s: %w", path, err)
+	}
+	for name, d := range defs {
+		d.Name = name
+		if d.Shape == "" {
+			d.Shape = ShapeCircle
+		}
+		if d.Life[1] < d.Life[0] {
+			d.Life[1] = d.Life[0]
+		}
+		if d.Speed[1] < d.Speed[0] {
+			d.Speed[1] = d.Speed[0]
+		}
+	}
+	return defs, nil
+}
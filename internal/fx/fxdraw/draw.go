@@ -0,0 +1,83 @@
+package fxdraw
+
+import (
+	"path/filepath"
+
+	"example.com/my2dgame/internal/fx"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Renderer рисует частицы fx через raylib; сама симуляция живёт в fx
+type Renderer struct {
+	assetsRoot string
+	tex        map[string]rl.Texture2D
+}
+
+func NewRenderer(assetsRoot string) *Renderer {
+	return &Renderer{assetsRoot: assetsRoot, tex: map[string]rl.Texture2D{}}
+}
+
+func (r *Renderer) Unload() {
+	for _, t := range r.tex {
+		if t.ID != 0 {
+			rl.UnloadTexture(t)
+		}
+	}
+	r.tex = map[string]rl.Texture2D{}
+}
+
+func (r *Renderer) texture(path string) rl.Texture2D {
+	if t, ok := r.tex[path]; ok {
+		return t
+	}
+	var t rl.Texture2D
+	if img := rl.LoadImage(filepath.Join(r.assetsRoot, path)); img.Data != nil {
+		t = rl.LoadTextureFromImage(img)
+		rl.UnloadImage(img)
+		rl.SetTextureFilter(t, rl.FilterPoint)
+	}
+	r.tex[path] = t // и пустую, чтобы не грузить каждый кадр
+	return t
+}
+
+// Draw рисует все частицы в мировых координатах (внутри BeginMode2D):
+// сначала обычные, поверх — аддитивные
+func (r *Renderer) Draw(s *fx.System) {
+	parts := s.Particles()
+	for i := range parts {
+		if !parts[i].Def.Additive {
+			r.drawOne(&parts[i])
+		}
+	}
+	rl.BeginBlendMode(rl.BlendAdditive)
+	for i := range parts {
+		if parts[i].Def.Additive {
+			r.drawOne(&parts[i])
+		}
+	}
+	rl.EndBlendMode()
+}
+
+func (r *Renderer) drawOne(p *fx.Particle) {
+	t := p.T()
+	size := p.Def.Sizes.At(t)
+	c := p.Def.Colors.At(t)
+	col := rl.NewColor(c.R, c.G, c.B, c.A)
+	pos := rl.NewVector2(p.X, p.Y)
+
+	switch p.Def.Shape {
+	case fx.ShapeSquare:
+		rl.DrawRectanglePro(rl.NewRectangle(p.X, p.Y, size, size), rl.NewVector2(size/2, size/2), p.Rot, col)
+	case fx.ShapeTexture:
+		tex := r.texture(p.Def.Texture)
+		if tex.ID == 0 {
+			rl.DrawCircleV(pos, size/2, col)
+			return
+		}
+		src := rl.NewRectangle(0, 0, float32(tex.Width), float32(tex.Height))
+		dst := rl.NewRectangle(p.X, p.Y, size, size*float32(tex.Height)/float32(tex.Width))
+		rl.DrawTexturePro(tex, src, dst, rl.NewVector2(dst.Width/2, dst.Height/2), p.Rot, col)
+	default:
+		rl.DrawCircleV(pos, size/2, col)
+	}
+}
@@ -0,0 +1,173 @@
+package fx
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Particle — одна частица. Рисовальщик берёт её Def, чтобы посчитать цвет/размер.
+type Particle struct {
+	X, Y   float32
+	VX, VY float32
+	Rot    float32
+	Spin   float32
+	Age    float32
+	Life   float32
+	Def    *Def
+}
+
+// T — доля прожитой жизни 0..1
+func (p *Particle) T() float32 { return p.Age / p.Life }
+
+// Emitter — постоянный источник частиц. Позицию двигает владелец
+// (или Follow, если эмиттер привязан к сущности).
+type Emitter struct {
+	Def       *Def
+	X, Y      float32
+	RateScale float32 // множитель Rate (0 — эмиттер молчит)
+	// Follow вызывается каждый кадр; alive=false — эмиттер удаляется
+	Follow func() (x, y float32, alive bool)
+
+	acc     float32
+	stopped bool
+}
+
+// Stop удаляет эмиттер на следующем Update (уже выпущенные частицы доживают)
+func (e *Emitter) Stop() { e.stopped = true }
+
+// System — пул частиц и набор эмиттеров. Ничего не знает о рендере.
+type System struct {
+	Defs map[string]*Def
+
+	parts    []Particle // живые — в [0:n)
+	n        int
+	emitters []*Emitter
+	rng      *rand.Rand
+}
+
+func NewSystem(defs map[string]*Def, capacity int, seed int64) *System {
+	return &System{
+		Defs:  defs,
+		parts: make([]Particle, capacity),
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Particles — живые частицы (срез действителен до следующего Update)
+func (s *System) Particles() []Particle { return s.parts[:s.n] }
+
+func (s *System) Count() int { return s.n }
+
+func (s *System) Clear() {
+	s.n = 0
+	s.emitters = s.emitters[:0]
+}
+
+// Emit — разовый выброс Burst частиц в точке
+func (s *System) Emit(name string, x, y float32) {
+	if d := s.Defs[name]; d != nil {
+		s.spawn(d, x, y, d.Burst, d.Angle)
+	}
+}
+
+// EmitDir — выброс с конусом, повёрнутым по направлению (dx, dy)
+func (s *System) EmitDir(name string, x, y, dx, dy float32) {
+	if d := s.Defs[name]; d != nil {
+		ang := float32(math.Atan2(float64(dy), float64(dx)) * 180 / math.Pi)
+		s.spawn(d, x, y, d.Burst, ang)
+	}
+}
+
+// Attach создаёт постоянный эмиттер; nil — если эффекта нет в данных
+func (s *System) Attach(name string, follow func() (float32, float32, bool)) *Emitter {
+	d := s.Defs[name]
+	if d == nil {
+		return nil
+	}
+	e := &Emitter{Def: d, RateScale: 1, Follow: follow}
+	if follow != nil {
+		e.X, e.Y, _ = follow()
+	}
+	s.emitters = append(s.emitters, e)
+	return e
+}
+
+func (s *System) Update(dt float32) {
+	if dt <= 0 {
+		return
+	}
+
+	// эмиттеры
+	out := s.emitters[:0]
+	for _, e := range s.emitters {
+		if e.Follow != nil && !e.stopped {
+			x, y, alive := e.Follow()
+			if !alive {
+				e.stopped = true
+			}
+			e.X, e.Y = x, y
+		}
+		if e.stopped {
+			continue
+		}
+		e.acc += e.Def.Rate * e.RateScale * dt
+		if n := int(e.acc); n > 0 {
+			e.acc -= float32(n)
+			s.spawn(e.Def, e.X, e.Y, n, e.Def.Angle)
+		}
+		out = append(out, e)
+	}
+	s.emitters = out
+
+	// частицы: удаление перестановкой последней на место умершей
+	for i := 0; i < s.n; {
+		p := &s.parts[i]
+		p.Age += dt
+		if p.Age >= p.Life {
+			s.n--
+			s.parts[i] = s.parts[s.n]
+			continue
+		}
+		d := p.Def
+		p.VY += d.Gravity * dt
+		if d.Drag > 0 {
+			k := float32(math.Exp(float64(-d.Drag * dt)))
+			p.VX *= k
+			p.VY *= k
+		}
+		p.X += p.VX * dt
+		p.Y += p.VY * dt
+		p.Rot += p.Spin * dt
+		i++
+	}
+}
+
+func (s *System) spawn(d *Def, x, y float32, count int, angleDeg float32) {
+	for i := 0; i < count && s.n < len(s.parts); i++ {
+		ang := float64(angleDeg+(s.rng.Float32()-0.5)*d.Cone) * math.Pi / 180
+		sp := lerp(d.Speed[0], d.Speed[1], s.rng.Float32())
+		px, py := x, y
+		if d.Spawn > 0 {
+			a := s.rng.Float64() * 2 * math.Pi
+			r := d.Spawn * s.rng.Float32()
+			px += r * float32(math.Cos(a))
+			py += r * float32(math.Sin(a))
+		}
+		life := lerp(d.Life[0], d.Life[1], s.rng.Float32())
+		if life <= 0 {
+			life = 0.01
+		}
+		s.parts[s.n] = Particle{
+			X: px, Y: py,
+			VX:   sp * float32(math.Cos(ang)),
+			VY:   sp * float32(math.Sin(ang)),
+			Rot:  s.rng.Float32() * 360,
+			Spin: lerp(d.Spin[0], d.Spin[1], s.rng.Float32()),
+			Life: life,
+			Def:  d,
+		}
+		s.n++
+	}
+}
+
+func lerp(a, b, t float32) float32 { return a + (b-a)*t }
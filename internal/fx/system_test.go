@@ -0,0 +1,198 @@
+package fx
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+func testDefs() map[string]*Def {
+	return map[string]*Def{
+		"burst": {Name: "burst", Burst: 10, Life: [2]float32{0.5, 0.5}, Speed: [2]float32{100, 100}, Cone: 360},
+		"trail": {Name: "trail", Rate: 20, Life: [2]float32{1, 1}},
+		"fall":  {Name: "fall", Burst: 1, Life: [2]float32{2, 2}, Gravity: 100},
+		"slow":  {Name: "slow", Burst: 1, Life: [2]float32{2, 2}, Speed: [2]float32{100, 100}, Drag: 2},
+	}
+}
+
+func step(s *System, seconds, dt float32) {
+	for n := int(seconds/dt + 0.5); n > 0; n-- {
+		s.Update(dt)
+	}
+}
+
+func TestBurstLifetime(t *testing.T) {
+	s := NewSystem(testDefs(), 64, 1)
+	s.Emit("burst", 50, 50)
+	if s.Count() != 10 {
+		t.Fatalf("после Emit %d частиц, want 10", s.Count())
+	}
+	for _, p := range s.Particles() {
+		if v := math.Hypot(float64(p.VX), float64(p.VY)); math.Abs(v-100) > 1e-3 {
+			t.Fatalf("скорость %.3f, want 100", v)
+		}
+	}
+	step(s, 0.4, 0.1)
+	if s.Count() != 10 {
+		t.Fatalf("к 0.4 с осталось %d частиц, want 10", s.Count())
+	}
+	// все разлетелись на 40 пикс от точки выброса
+	for _, p := range s.Particles() {
+		if d := math.Hypot(float64(p.X-50), float64(p.Y-50)); math.Abs(d-40) > 0.1 {
+			t.Fatalf("частица в %.2f пикс от центра, want 40", d)
+		}
+	}
+	step(s, 0.2, 0.1)
+	if s.Count() != 0 {
+		t.Fatalf("после жизни осталось %d частиц", s.Count())
+	}
+	s.Emit("nope", 0, 0)
+	if s.Count() != 0 {
+		t.Fatal("неизвестный эффект выпустил частицы")
+	}
+}
+
+func TestPoolCapacity(t *testing.T) {
+	s := NewSystem(testDefs(), 25, 1)
+	for i := 0; i < 5; i++ {
+		s.Emit("burst", 0, 0)
+	}
+	if s.Count() != 25 {
+		t.Fatalf("%d частиц при ёмкости 25", s.Count())
+	}
+}
+
+func TestEmitterRate(t *testing.T) {
+	s := NewSystem(testDefs(), 256, 1)
+	x := float32(0)
+	alive := true
+	e := s.Attach("trail", func() (float32, float32, bool) { return x, 0, alive })
+
+	// 20 частиц/с, жизнь 1 с: за 0.5 с — 10
+	step(s, 0.5, 0.05)
+	if n := s.Count(); n < 9 || n > 10 {
+		t.Fatalf("за 0.5 с %d частиц, want ≈10", n)
+	}
+	// эмиттер следует за владельцем
+	x = 300
+	step(s, 0.05, 0.05)
+	if e.X != 300 {
+		t.Fatalf("эмиттер не сдвинулся: %.0f", e.X)
+	}
+	// RateScale 0 — молчит
+	e.RateScale = 0
+	before := s.Count()
+	step(s, 0.2, 0.05)
+	if s.Count() != before {
+		t.Fatalf("приглушённый эмиттер выпустил %d частиц", s.Count()-before)
+	}
+	// владелец умер — эмиттер удалён, частицы доживают и исчезают
+	e.RateScale = 1
+	alive = false
+	step(s, 0.05, 0.05)
+	if len(s.emitters) != 0 {
+		t.Fatal("эмиттер мёртвого владельца не удалён")
+	}
+	step(s, 1, 0.05)
+	if s.Count() != 0 {
+		t.Fatalf("осталось %d частиц", s.Count())
+	}
+}
+
+func TestStop(t *testing.T) {
+	s := NewSystem(testDefs(), 64, 1)
+	e := s.Attach("trail", nil)
+	step(s, 0.25, 0.05)
+	e.Stop()
+	n := s.Count()
+	step(s, 0.25, 0.05)
+	if s.Count() != n || len(s.emitters) != 0 {
+		t.Fatalf("после Stop: частиц %d → %d, эмиттеров %d", n, s.Count(), len(s.emitters))
+	}
+}
+
+func TestGravityDrag(t *testing.T) {
+	s := NewSystem(testDefs(), 8, 1)
+	s.Emit("fall", 0, 0)
+	step(s, 1, 0.001)
+	// y = g t² / 2
+	if p := s.Particles()[0]; math.Abs(float64(p.Y-50)) > 0.5 || math.Abs(float64(p.VY-100)) > 0.5 {
+		t.Fatalf("гравитация: y %.2f, vy %.2f; want 50, 100", p.Y, p.VY)
+	}
+
+	s = NewSystem(testDefs(), 8, 1)
+	s.Emit("slow", 0, 0)
+	step(s, 1, 0.01)
+	// v = v0 e^(-drag t)
+	p := s.Particles()[0]
+	v := math.Hypot(float64(p.VX), float64(p.VY))
+	if want := 100 * math.Exp(-2); math.Abs(v-want) > 0.5 {
+		t.Fatalf("сопротивление: скорость %.2f, want %.2f", v, want)
+	}
+}
+
+func TestEmitDirCone(t *testing.T) {
+	defs := testDefs()
+	defs["jet"] = &Def{Burst: 50, Life: [2]float32{1, 1}, Speed: [2]float32{10, 10}, Cone: 30}
+	s := NewSystem(defs, 64, 3)
+	s.EmitDir("jet", 0, 0, 0, 1) // вниз: 90°
+	for _, p := range s.Particles() {
+		deg := math.Atan2(float64(p.VY), float64(p.VX)) * 180 / math.Pi
+		if math.Abs(deg-90) > 15+1e-3 {
+			t.Fatalf("частица под %.1f°, конус 90±15°", deg)
+		}
+	}
+}
+
+func TestCurves(t *testing.T) {
+	c := Curve{{0, 10}, {0.5, 4}, {1, 0}}
+	for _, tc := range []struct{ t, want float32 }{{-1, 10}, {0, 10}, {0.25, 7}, {0.75, 2}, {2, 0}} {
+		if v := c.At(tc.t); math.Abs(float64(v-tc.want)) > 1e-4 {
+			t.Errorf("Curve.At(%.2f) = %.3f, want %.3f", tc.t, v, tc.want)
+		}
+	}
+	cc := ColorCurve{{0, 255, 0, 0, 255}, {1, 0, 0, 255, 0}}
+	if col := cc.At(0.5); col.R != 127 || col.B != 127 || col.A != 127 {
+		t.Errorf("ColorCurve.At(0.5) = %+v", col)
+	}
+}
+
+// эффекты из данных загружаются и симулируются
+func TestLoadDefs(t *testing.T) {
+	defs, err := LoadDefs(filepath.Join("..", "..", "assets", "data", "fx.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSystem(defs, 4096, 1)
+	for name, d := range defs {
+		if d.Burst > 0 {
+			s.Emit(name, 0, 0)
+		}
+		if d.Rate > 0 {
+			s.Attach(name, nil)
+		}
+	}
+	step(s, 3, 1.0/60)
+	for _, p := range s.Particles() {
+		if p.T() < 0 || p.T() >= 1 || math.IsNaN(float64(p.X)) {
+			t.Fatalf("%s: частица с T %.2f, x %v", p.Def.Name, p.T(), p.X)
+		}
+	}
+}
+
+// BenchmarkUpdate — кадр симуляции при ~4000 живых частиц, без отрисовки
+func BenchmarkUpdate(b *testing.B) {
+	defs := map[string]*Def{
+		"b": {Burst: 100, Life: [2]float32{2, 4}, Speed: [2]float32{50, 200}, Cone: 360, Gravity: 50, Drag: 2, Spin: [2]float32{-90, 90}},
+	}
+	s := NewSystem(defs, 4096, 1)
+	const dt = float32(1) / 60
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if s.Count() < 4000 {
+			s.Emit("b", 0, 0)
+		}
+		s.Update(dt)
+	}
+}
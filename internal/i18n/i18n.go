@@ -0,0 +1,217 @@
+// Package i18n — строковые таблицы интерфейса.
+//
+// Один язык — один файл <код>.json в каталоге языков: плоский объект
+// ключ → строка. Строка с числительными задаётся объектом форм:
+//
+//	"defeat.souls": {"one": "%d душа", "few": "%d души", "many": "%d душ"}
+//
+// Поиск: текущий язык → запасной → сам ключ (видно, чего не хватает).
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NameKey — ключ, под которым язык хранит своё имя для выбора в настройках
+const NameKey = "lang.name"
+
+type entry struct {
+	text  string
+	forms map[string]string // формы числительных, если строка зависит от числа
+}
+
+// Bundle — все загруженные языки и текущий выбор
+type Bundle struct {
+	langs    map[string]map[string]entry
+	current  string
+	fallback string
+}
+
+// Load читает все *.json из dir. fallback — язык, которым закрываются дыры
+// в остальных (язык, на котором пишется игра).
+func Load(dir, fallback string) (*Bundle, error) {
+	b := &Bundle{langs: map[string]map[string]entry{}, current: fallback, fallback: fallback}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return b, err
+	}
+	var errs []string
+	for _, f := range files {
+		code := strings.TrimSuffix(filepath.Base(f), ".json")
+		t, err := loadTable(f)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		b.langs[code] = t
+	}
+	if len(errs) > 0 {
+		return b, fmt.Errorf("i18n: %s", strings.Join(errs, "; "))
+	}
+	if _, ok := b.langs[fallback]; !ok {
+		return b, fmt.Errorf("i18n: нет запасного языка %q в %s", fallback, dir)
+	}
+	return b, nil
+}
+
+func loadTable(path string) (map[string]entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	t := make(map[string]entry, len(raw))
+	for k, v := range raw {
+		var e entry
+		if err := json.Unmarshal(v, &e.text); err != nil {
+			if err := json.Unmarshal(v, &e.forms); err != nil {
+				return nil, fmt.Errorf("%s: ключ %q: нужна строка или объект форм", path, k)
+			}
+		}
+		t[k] = e
+	}
+	return t, nil
+}
+
+// SetLanguage переключает язык; незнакомый код — ошибка, язык не меняется
+func (b *Bundle) SetLanguage(code string) error {
+	if _, ok := b.langs[code]; !ok {
+		return fmt.Errorf("i18n: язык %q не загружен", code)
+	}
+	b.current = code
+	return nil
+}
+
+func (b *Bundle) Language() string { return b.current }
+
+// Languages — коды загруженных языков, запасной первым
+func (b *Bundle) Languages() []string {
+	out := make([]string, 0, len(b.langs))
+	for code := range b.langs {
+		if code != b.fallback {
+			out = append(out, code)
+		}
+	}
+	sort.Strings(out)
+	if _, ok := b.langs[b.fallback]; ok {
+		out = append([]string{b.fallback}, out...)
+	}
+	return out
+}
+
+// Name — имя языка на нём самом (для списка в настройках)
+func (b *Bundle) Name(code string) string {
+	if e, ok := b.langs[code][NameKey]; ok && e.text != "" {
+		return e.text
+	}
+	return code
+}
+
+func (b *Bundle) lookup(key string) (entry, string, bool) {
+	for _, code := range []string{b.current, b.fallback} {
+		if e, ok := b.langs[code][key]; ok {
+			return e, code, true
+		}
+	}
+	return entry{}, "", false
+}
+
+// T — строка по ключу; с args — через fmt.Sprintf
+func (b *Bundle) T(key string, args ...any) string {
+	e, _, ok := b.lookup(key)
+	if !ok {
+		return key
+	}
+	s := e.text
+	if s == "" && e.forms != nil {
+		s = e.forms["other"]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(s, args...)
+	}
+	return s
+}
+
+// N — строка с числительным: форма выбирается по n правилами языка,
+// n первым аргументом уходит в Sprintf
+func (b *Bundle) N(key string, n int, args ...any) string {
+	e, code, ok := b.lookup(key)
+	if !ok {
+		return key
+	}
+	s := e.text
+	if e.forms != nil {
+		s = e.forms[plural(code, n)]
+		if s == "" {
+			s = e.forms["other"]
+		}
+	}
+	return fmt.Sprintf(s, append([]any{n}, args...)...)
+}
+
+// Missing — для каждого языка ключи, которые есть в других, но нет в нём.
+// Пусто — таблицы согласованы.
+func (b *Bundle) Missing() map[string][]string {
+	all := map[string]bool{}
+	for _, t := range b.langs {
+		for k := range t {
+			all[k] = true
+		}
+	}
+	out := map[string][]string{}
+	for code, t := range b.langs {
+		for k := range all {
+			if _, ok := t[k]; !ok {
+				out[code] = append(out[code], k)
+			}
+		}
+		sort.Strings(out[code])
+	}
+	for code, ks := range out {
+		if len(ks) == 0 {
+			delete(out, code)
+		}
+	}
+	return out
+}
+
+// Glyphs — набор кодовых точек для шрифта: печатный ASCII, все символы
+// всех загруженных языков и extra (строки не из таблиц: значки клавиш и т.п.)
+func (b *Bundle) Glyphs(extra ...string) []int32 {
+	seen := map[rune]bool{}
+	for r := rune(0x20); r <= 0x7E; r++ {
+		seen[r] = true
+	}
+	add := func(s string) {
+		for _, r := range s {
+			if r >= 0x20 {
+				seen[r] = true
+			}
+		}
+	}
+	for _, t := range b.langs {
+		for _, e := range t {
+			add(e.text)
+			for _, f := range e.forms {
+				add(f)
+			}
+		}
+	}
+	for _, s := range extra {
+		add(s)
+	}
+	out := make([]int32, 0, len(seen))
+	for r := range seen {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var shipped = filepath.Join("..", "..", "assets", "lang")
+
+// каждый ключ есть в каждом языке, что идёт с игрой
+func TestShippedLanguagesComplete(t *testing.T) {
+	b, err := Load(shipped, "ru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	langs := b.Languages()
+	if len(langs) < 2 {
+		t.Fatalf("загружены языки %v", langs)
+	}
+	for code, keys := range b.Missing() {
+		t.Errorf("%s.json: нет ключей %v", code, keys)
+	}
+	for _, code := range langs {
+		if b.Name(code) == code {
+			t.Errorf("%s.json: нет %q", code, NameKey)
+		}
+	}
+}
+
+// имена из данных (уровни, боссы и их фазы) — ключи перевода
+func TestDataNamesTranslated(t *testing.T) {
+	b, err := Load(shipped, "ru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := filepath.Join("..", "..", "assets", "data")
+	var names []string
+	// collect собирает все поля "name" на любой глубине
+	var collect func(v any)
+	collect = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if s, ok := v["name"].(string); ok {
+				names = append(names, s)
+			}
+			for _, x := range v {
+				collect(x)
+			}
+		case []any:
+			for _, x := range v {
+				collect(x)
+			}
+		}
+	}
+	for _, f := range []string{"levels.json", "bosses.json"} {
+		raw, err := os.ReadFile(filepath.Join(data, f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		collect(v)
+	}
+	if len(names) == 0 {
+		t.Fatal("в данных не нашлось имён")
+	}
+	for _, code := range b.Languages() {
+		if err := b.SetLanguage(code); err != nil {
+			t.Fatal(err)
+		}
+		for _, n := range names {
+			if b.T(n) == n {
+				t.Errorf("%s: имя %q не переведено", code, n)
+			}
+		}
+	}
+}
+
+func writeLang(t *testing.T, dir, code, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, code+".json"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMissingAndFallback(t *testing.T) {
+	dir := t.TempDir()
+	writeLang(t, dir, "ru", `{"a": "А", "b": "Б %d", "souls": {"one": "%d душа", "few": "%d души", "many": "%d душ"}}`)
+	writeLang(t, dir, "en", `{"a": "A", "c": "C", "souls": {"one": "%d soul", "other": "%d souls"}}`)
+	b, err := Load(dir, "ru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"en": {"b"}, "ru": {"c"}}
+	if got := b.Missing(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Missing = %v, want %v", got, want)
+	}
+
+	if err := b.SetLanguage("en"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct{ got, want string }{
+		{b.T("a"), "A"},
+		{b.T("b", 5), "Б 5"}, // из запасного
+		{b.T("zz"), "zz"},    // нет нигде — сам ключ
+		{b.N("souls", 1), "1 soul"},
+		{b.N("souls", 3), "3 souls"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+	if err := b.SetLanguage("de"); err == nil || b.Language() != "en" {
+		t.Fatalf("незнакомый язык: err %v, язык %s", err, b.Language())
+	}
+
+	_ = b.SetLanguage("ru")
+	for n, want := range map[int]string{1: "1 душа", 3: "3 души", 5: "5 душ", 11: "11 душ", 21: "21 душа", 112: "112 душ"} {
+		if got := b.N("souls", n); got != want {
+			t.Errorf("N(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
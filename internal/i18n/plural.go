@@ -0,0 +1,31 @@
+package i18n
+
+// Правила выбора формы числительного (имена форм — как в CLDR:
+// one, few, many, other). Языка нет в списке — всегда "other".
+var pluralRules = map[string]func(n int) string{
+	"en": func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	"ru": func(n int) string {
+		if n < 0 {
+			n = -n
+		}
+		switch d, dd := n%10, n%100; {
+		case d == 1 && dd != 11:
+			return "one"
+		case d >= 2 && d <= 4 && (dd < 12 || dd > 14):
+			return "few"
+		}
+		return "many"
+	},
+}
+
+func plural(code string, n int) string {
+	if f, ok := pluralRules[code]; ok {
+		return f(n)
+	}
+	return "other"
+}
@@ -0,0 +1,60 @@
+package input
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Deadzone — радиальная мёртвая зона: внутри dz ноль, снаружи длина
+// перемасштабирована в 0..1, чтобы не было скачка на границе
+func Deadzone(x, y, dz float32) (float32, float32) {
+	n := float32(math.Hypot(float64(x), float64(y)))
+	if n <= dz || n == 0 {
+		return 0, 0
+	}
+	k := min((n-dz)/(1-dz), 1) / n
+	return x * k, y * k
+}
+
+// assistCone — в каком угле (градусы) от направления стика ищем цель
+const assistCone = 25
+
+// Assist доворачивает единичный dir к цели, ближайшей по углу в пределах конуса.
+// strength 0 — без доводки, 1 — ровно на цель; чем дальше цель от оси, тем слабее.
+func Assist(origin, dir rl.Vector2, targets []rl.Vector2, strength float32) rl.Vector2 {
+	if strength <= 0 || len(targets) == 0 {
+		return dir
+	}
+	base := math.Atan2(float64(dir.Y), float64(dir.X))
+	best, bestDiff := 0.0, math.Inf(1)
+	for _, t := range targets {
+		dx, dy := float64(t.X-origin.X), float64(t.Y-origin.Y)
+		if dx == 0 && dy == 0 {
+			continue
+		}
+		diff := angleDiff(math.Atan2(dy, dx), base)
+		if math.Abs(diff) < math.Abs(bestDiff) {
+			best, bestDiff = diff, diff
+		}
+	}
+	cone := assistCone * math.Pi / 180
+	if math.Abs(bestDiff) > cone {
+		return dir
+	}
+	// к краю конуса доводка сходит на нет
+	pull := float64(strength) * (1 - math.Abs(best)/cone)
+	a := base + best*pull
+	return rl.NewVector2(float32(math.Cos(a)), float32(math.Sin(a)))
+}
+
+// angleDiff — a-b, приведённое к (-π, π]
+func angleDiff(a, b float64) float64 {
+	d := math.Mod(a-b, 2*math.Pi)
+	if d > math.Pi {
+		d -= 2 * math.Pi
+	} else if d <= -math.Pi {
+		d += 2 * math.Pi
+	}
+	return d
+}
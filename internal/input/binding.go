@@ -0,0 +1,217 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Binding — одна привязка действия. Всё кодируется одним int32, чтобы
+// поле назначения в UI работало с любым устройством:
+//
+//	0..999      — клавиши raylib как есть
+//	1000+btn    — кнопки мыши
+//	2000+btn    — кнопки геймпада
+//	3000+axis*2 — ось геймпада в минус, +1 — в плюс (курки, стики)
+type Binding int32
+
+const (
+	mouseBase Binding = 1000
+	padBase   Binding = 2000
+	axisBase  Binding = 3000
+	axisEnd   Binding = axisBase + 2*6
+)
+
+// Устройства привязок
+const (
+	DeviceNone = iota
+	DeviceKey
+	DeviceMouse
+	DevicePad
+	DeviceAxis
+)
+
+func Key(k int32) Binding { return Binding(k) }
+
+func Mouse(b rl.MouseButton) Binding { return mouseBase + Binding(b) }
+
+func Pad(b int32) Binding { return padBase + Binding(b) }
+
+func PadAxis(axis int32, positive bool) Binding {
+	b := axisBase + Binding(axis*2)
+	if positive {
+		b++
+	}
+	return b
+}
+
+func (b Binding) Device() int {
+	switch {
+	case b <= 0:
+		return DeviceNone
+	case b < mouseBase:
+		return DeviceKey
+	case b < padBase:
+		return DeviceMouse
+	case b < axisBase:
+		return DevicePad
+	case b < axisEnd:
+		return DeviceAxis
+	}
+	return DeviceNone
+}
+
+// Gamepad — привязка относится к геймпаду
+func (b Binding) Gamepad() bool {
+	d := b.Device()
+	return d == DevicePad || d == DeviceAxis
+}
+
+// axisThreshold — насколько отклонить ось, чтобы она считалась нажатой.
+// Курки в raylib покоятся на -1, так что для них порог тоже подходит.
+const axisThreshold = 0.5
+
+// down — зажата ли привязка прямо сейчас
+func (b Binding) down(pad int32) bool {
+	switch b.Device() {
+	case DeviceKey:
+		return rl.IsKeyDown(int32(b))
+	case DeviceMouse:
+		return rl.IsMouseButtonDown(rl.MouseButton(b - mouseBase))
+	case DevicePad:
+		return rl.IsGamepadAvailable(pad) && rl.IsGamepadButtonDown(pad, int32(b-padBase))
+	case DeviceAxis:
+		if !rl.IsGamepadAvailable(pad) {
+			return false
+		}
+		n := int32(b - axisBase)
+		v := rl.GetGamepadAxisMovement(pad, n/2)
+		if n%2 == 1 {
+			return v > axisThreshold
+		}
+		return v < -axisThreshold
+	}
+	return false
+}
+
+var mouseNames = []string{"LMB", "RMB", "MMB", "M4", "M5", "M6", "M7"}
+
+var padNames = []string{"?", "D↑", "D→", "D↓", "D←", "Y", "B", "A", "X",
+	"LB", "LT", "RB", "RT", "Back", "Guide", "Start", "LS", "RS"}
+
+var axisNames = []string{"LS←", "LS→", "LS↑", "LS↓", "RS←", "RS→", "RS↑", "RS↓", "LT", "LT", "RT", "RT"}
+
+// Index — номер клавиши/кнопки/оси внутри устройства
+func (b Binding) Index() int32 {
+	switch b.Device() {
+	case DeviceMouse:
+		return int32(b - mouseBase)
+	case DevicePad:
+		return int32(b - padBase)
+	case DeviceAxis:
+		return int32(b-axisBase) / 2
+	}
+	return int32(b)
+}
+
+// Name — подпись для мыши и геймпада; клавиши называет ui.KeyName
+func (b Binding) Name() string {
+	switch b.Device() {
+	case DeviceMouse:
+		if i := int(b - mouseBase); i < len(mouseNames) {
+			return mouseNames[i]
+		}
+	case DevicePad:
+		if i := int(b - padBase); i < len(padNames) {
+			return padNames[i]
+		}
+	case DeviceAxis:
+		return axisNames[b-axisBase]
+	}
+	return fmt.Sprintf("#%d", int32(b))
+}
+
+// MarshalText — в файле профиля привязки читаемы: "key:87", "mouse:1", "pad:7", "axis:5+"
+func (b Binding) MarshalText() ([]byte, error) {
+	var s string
+	switch b.Device() {
+	case DeviceKey:
+		s = fmt.Sprintf("key:%d", int32(b))
+	case DeviceMouse:
+		s = fmt.Sprintf("mouse:%d", int32(b-mouseBase))
+	case DevicePad:
+		s = fmt.Sprintf("pad:%d", int32(b-padBase))
+	case DeviceAxis:
+		n := int32(b - axisBase)
+		sign := "-"
+		if n%2 == 1 {
+			sign = "+"
+		}
+		s = fmt.Sprintf("axis:%d%s", n/2, sign)
+	default:
+		s = "none"
+	}
+	return []byte(s), nil
+}
+
+func (b *Binding) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s == "none" || s == "" {
+		*b = 0
+		return nil
+	}
+	dev, num, ok := strings.Cut(s, ":")
+	if !ok {
+		return fmt.Errorf("привязка %q: ожидается устройство:код", s)
+	}
+	positive := false
+	if dev == "axis" {
+		positive = strings.HasSuffix(num, "+")
+		num = strings.TrimRight(num, "+-")
+	}
+	n, err := strconv.Atoi(num)
+	if err != nil {
+		return fmt.Errorf("привязка %q: %w", s, err)
+	}
+	switch dev {
+	case "key":
+		*b = Key(int32(n))
+	case "mouse":
+		*b = Mouse(rl.MouseButton(n))
+	case "pad":
+		*b = Pad(int32(n))
+	case "axis":
+		*b = PadAxis(int32(n), positive)
+	default:
+		return fmt.Errorf("привязка %q: неизвестное устройство %q", s, dev)
+	}
+	if b.Device() == DeviceNone {
+		return fmt.Errorf("привязка %q: код вне диапазона", s)
+	}
+	return nil
+}
+
+// PollPressed — первая мышиная или геймпадная привязка, нажатая в этом кадре
+// (для захвата в поле назначения; клавиши приходят из ui.Input.Key)
+func PollPressed(pad int32) Binding {
+	for i := rl.MouseButtonLeft; i <= rl.MouseButtonBack; i++ {
+		if rl.IsMouseButtonPressed(i) {
+			return Mouse(i)
+		}
+	}
+	if !rl.IsGamepadAvailable(pad) {
+		return 0
+	}
+	if btn := rl.GetGamepadButtonPressed(); btn > 0 && rl.IsGamepadButtonPressed(pad, btn) {
+		return Pad(btn)
+	}
+	// курки: как только перешли порог; стики не назначаем — они заняты движением и прицелом
+	for _, axis := range []int32{rl.GamepadAxisLeftTrigger, rl.GamepadAxisRightTrigger} {
+		if rl.GetGamepadAxisMovement(pad, axis) > axisThreshold {
+			return PadAxis(axis, true)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package input
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Controller опрашивает устройства раз в кадр и отвечает на вопросы игры:
+// зажато ли действие, куда идти и куда целиться.
+type Controller struct {
+	Profile   *Profile
+	Pad       int32
+	AimRadius float32 // на каком расстоянии от игрока стоит прицел геймпада (вирт. экран)
+
+	down, prev map[Action]bool
+	usingPad   bool
+	aimDir     rl.Vector2
+	lastMouse  rl.Vector2
+	stickAim   float32 // отклонение правого стика в этом кадре
+}
+
+func NewController(p *Profile) *Controller {
+	return &Controller{
+		Profile:   p,
+		AimRadius: 80,
+		down:      map[Action]bool{},
+		prev:      map[Action]bool{},
+		aimDir:    rl.NewVector2(1, 0),
+	}
+}
+
+// Update — один раз за кадр, до любых Down/Pressed.
+// mouse — курсор в координатах виртуального экрана.
+func (c *Controller) Update(mouse rl.Vector2) {
+	c.prev, c.down = c.down, c.prev
+	clear(c.down)
+
+	padOn := rl.IsGamepadAvailable(c.Pad)
+	for a, bs := range c.Profile.Bindings {
+		for _, b := range bs {
+			if b.down(c.Pad) {
+				c.down[a] = true
+				if b.Gamepad() {
+					c.usingPad = true
+				}
+				break
+			}
+		}
+	}
+
+	// мышь сдвинули или нажали — прицел снова за курсором
+	if mouse != c.lastMouse || rl.IsMouseButtonDown(rl.MouseButtonLeft) || rl.IsMouseButtonDown(rl.MouseButtonRight) {
+		c.usingPad = false
+	}
+	c.lastMouse = mouse
+
+	c.stickAim = 0
+	if !padOn {
+		c.usingPad = false
+	} else {
+		lx, ly := c.stick(rl.GamepadAxisLeftX, rl.GamepadAxisLeftY)
+		rx, ry := c.stick(rl.GamepadAxisRightX, rl.GamepadAxisRightY)
+		if lx != 0 || ly != 0 {
+			c.usingPad = true
+		}
+		if n := float32(math.Hypot(float64(rx), float64(ry))); n > 0 {
+			c.usingPad = true
+			c.aimDir = rl.NewVector2(rx/n, ry/n)
+			c.stickAim = n
+		}
+	}
+	if c.Profile.AutoFire && c.stickAim > 0.5 {
+		c.down[Fire] = true
+	}
+}
+
+// stick — стик с радиальной мёртвой зоной; длина результата 0..1
+func (c *Controller) stick(ax, ay int32) (float32, float32) {
+	x := rl.GetGamepadAxisMovement(c.Pad, ax)
+	y := rl.GetGamepadAxisMovement(c.Pad, ay)
+	return Deadzone(x, y, c.Profile.Deadzone)
+}
+
+// Down — действие зажато
+func (c *Controller) Down(a Action) bool { return c.down[a] }
+
+// Pressed — действие нажато в этом кадре
+func (c *Controller) Pressed(a Action) bool { return c.down[a] && !c.prev[a] }
+
+// UsingPad — последний ввод был с геймпада (прицел от стика, а не от мыши)
+func (c *Controller) UsingPad() bool { return c.usingPad }
+
+// Move — направление движения, длина не больше 1.
+// Кнопки дают полный ход, стик — пропорциональный.
+func (c *Controller) Move() (float32, float32) {
+	var x, y float32
+	if c.down[MoveLeft] {
+		x--
+	}
+	if c.down[MoveRight] {
+		x++
+	}
+	if c.down[MoveUp] {
+		y--
+	}
+	if c.down[MoveDown] {
+		y++
+	}
+	if rl.IsGamepadAvailable(c.Pad) {
+		sx, sy := c.stick(rl.GamepadAxisLeftX, rl.GamepadAxisLeftY)
+		x += sx
+		y += sy
+	}
+	if n := float32(math.Hypot(float64(x), float64(y))); n > 1 {
+		x /= n
+		y /= n
+	}
+	return x, y
+}
+
+// Aim — точка прицела на виртуальном экране. С мышью это курсор;
+// с геймпадом — точка на AimRadius от origin (игрок на экране) по правому стику,
+// доведённая к ближайшей цели из targets. Дальше её, как и курсор,
+// переводят в мир через GetScreenToWorld2D.
+func (c *Controller) Aim(origin rl.Vector2, targets []rl.Vector2) rl.Vector2 {
+	if !c.usingPad {
+		return c.lastMouse
+	}
+	dir := Assist(origin, c.aimDir, targets, c.Profile.AimAssist)
+	return rl.NewVector2(origin.X+dir.X*c.AimRadius, origin.Y+dir.Y*c.AimRadius)
+}
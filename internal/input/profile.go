@@ -0,0 +1,162 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Action — игровое действие, на которое назначаются привязки
+type Action string
+
+const (
+	MoveUp     Action = "move_up"
+	MoveDown   Action = "move_down"
+	MoveLeft   Action = "move_left"
+	MoveRight  Action = "move_right"
+	Fire       Action = "fire"
+	Crook      Action = "crook"
+	Ult        Action = "ult"
+	Dash       Action = "dash"
+	Pause      Action = "pause"
+	Fullscreen Action = "fullscreen"
+)
+
+// Actions — все действия в порядке экрана управления
+var Actions = []Action{MoveUp, MoveDown, MoveLeft, MoveRight, Fire, Crook, Ult, Dash, Pause, Fullscreen}
+
+// Profile — набор привязок и настроек геймпада.
+// Левый стик всегда двигает, правый — целится; их не переназначаем.
+type Profile struct {
+	Name      string               `json:"name"` // пусто — стандартное имя на языке игры
+	Bindings  map[Action][]Binding `json:"bindings"`
+	Deadzone  float32              `json:"deadzone"`   // мёртвая зона стиков, 0..0.9
+	AimAssist float32              `json:"aim_assist"` // доводка прицела на геймпаде, 0..1
+	AutoFire  bool                 `json:"auto_fire"`  // стрелять, пока отклонён правый стик
+}
+
+// DefaultProfile — раскладка, с которой игра жила до переназначения
+func DefaultProfile() Profile {
+	return Profile{
+		Bindings: map[Action][]Binding{
+			MoveUp:     {Key(rl.KeyW), Key(rl.KeyUp), Pad(rl.GamepadButtonLeftFaceUp)},
+			MoveDown:   {Key(rl.KeyS), Key(rl.KeyDown), Pad(rl.GamepadButtonLeftFaceDown)},
+			MoveLeft:   {Key(rl.KeyA), Key(rl.KeyLeft), Pad(rl.GamepadButtonLeftFaceLeft)},
+			MoveRight:  {Key(rl.KeyD), Key(rl.KeyRight), Pad(rl.GamepadButtonLeftFaceRight)},
+			Fire:       {Mouse(rl.MouseButtonRight), 0, PadAxis(rl.GamepadAxisRightTrigger, true)},
+			Crook:      {Key(rl.KeyQ), 0, Pad(rl.GamepadButtonRightTrigger1)},
+			Ult:        {Key(rl.KeyE), 0, Pad(rl.GamepadButtonRightFaceUp)},
+			Dash:       {Key(rl.KeySpace), Key(rl.KeyLeftShift), Pad(rl.GamepadButtonLeftTrigger1)},
+			Pause:      {Key(rl.KeyEscape), 0, Pad(rl.GamepadButtonMiddleRight)},
+			Fullscreen: {Key(rl.KeyF11), 0, 0},
+		},
+		Deadzone:  0.2,
+		AimAssist: 0.5,
+		AutoFire:  true,
+	}
+}
+
+// Clone — глубокая копия (для нового профиля на основе текущего)
+func (p Profile) Clone() Profile {
+	c := p
+	c.Bindings = make(map[Action][]Binding, len(p.Bindings))
+	for a, bs := range p.Bindings {
+		c.Bindings[a] = append([]Binding(nil), bs...)
+	}
+	return c
+}
+
+// Slot — привязка в ячейке i (0 — если ячейки нет)
+func (p *Profile) Slot(a Action, i int) Binding {
+	if bs := p.Bindings[a]; i < len(bs) {
+		return bs[i]
+	}
+	return 0
+}
+
+func (p *Profile) SetSlot(a Action, i int, b Binding) {
+	if p.Bindings == nil {
+		p.Bindings = map[Action][]Binding{}
+	}
+	bs := p.Bindings[a]
+	for len(bs) <= i {
+		bs = append(bs, 0)
+	}
+	bs[i] = b
+	p.Bindings[a] = bs
+}
+
+func (p *Profile) sanitize() {
+	if p.Deadzone < 0 || p.Deadzone > 0.9 {
+		p.Deadzone = 0.2
+	}
+	if p.AimAssist < 0 || p.AimAssist > 1 {
+		p.AimAssist = 0
+	}
+	if p.Bindings == nil {
+		p.Bindings = DefaultProfile().Bindings
+	}
+}
+
+// ProfilesVersion — версия формата controls.json
+const ProfilesVersion = 1
+
+// Profiles — все профили игрока и выбранный
+type Profiles struct {
+	Version int       `json:"version"`
+	Active  int       `json:"active"`
+	List    []Profile `json:"profiles"`
+}
+
+func DefaultProfiles() Profiles {
+	return Profiles{Version: ProfilesVersion, List: []Profile{DefaultProfile()}}
+}
+
+// Current — активный профиль
+func (ps *Profiles) Current() *Profile {
+	if ps.Active < 0 || ps.Active >= len(ps.List) {
+		ps.Active = 0
+	}
+	return &ps.List[ps.Active]
+}
+
+// LoadProfiles — как config.Load: файла нет — умолчания без ошибки,
+// битый файл — умолчания и ошибка-предупреждение
+func LoadProfiles(path string) (Profiles, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultProfiles(), nil
+	}
+	if err != nil {
+		return DefaultProfiles(), err
+	}
+	var ps Profiles
+	if err := json.Unmarshal(data, &ps); err != nil {
+		return DefaultProfiles(), fmt.Errorf("controls %s: %w (используется стандартная раскладка)", path, err)
+	}
+	if ps.Version != ProfilesVersion || len(ps.List) == 0 {
+		return DefaultProfiles(), fmt.Errorf("controls %s: неизвестная версия %d (используется стандартная раскладка)", path, ps.Version)
+	}
+	for i := range ps.List {
+		ps.List[i].sanitize()
+	}
+	ps.Current()
+	return ps, nil
+}
+
+func SaveProfiles(path string, ps Profiles) error {
+	ps.Version = ProfilesVersion
+	data, err := json.MarshalIndent(ps, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,190 @@
+// Package level — реестр уровней (data/levels.json), сценарий волн
+// и прогресс игрока (что пройдено, лучшее время)
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+)
+
+// Виды карт уровня
+const (
+	MapBackdrop = "backdrop" // готовая картинка (+ <карта>.solid.json)
+	MapArena    = "arena"    // сгенерированная арена с краями
+	MapEndless  = "endless"  // бесконечный мир из чанков
+)
+
+// Условия победы
+const (
+	WinSurvive = "survive" // продержаться Time секунд
+	WinBoss    = "boss"    // убить босса уровня
+	WinNone    = "none"    // бесконечный забег
+)
+
+// Def — описание уровня
+type Def struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"` // ключ перевода
+	Map      MapDef     `json:"map"`
+	Music    string     `json:"music"` // дорожка из audio.json
+	Enemies  []EnemyDef `json:"enemies"`
+	Waves    []Wave     `json:"waves"`
+	Boss     string     `json:"boss"`    // id из bosses.json; "" — без босса
+	BossAt   float32    `json:"boss_at"` // секунда появления; 0 — как в описании босса
+	Win      Win        `json:"win"`
+	Requires string     `json:"requires"` // уровень, который надо пройти; "" — открыт сразу
+	Cost     int        `json:"cost"`     // цена в магазине; 0 — покупать не надо
+}
+
+// MapDef — карта уровня
+type MapDef struct {
+	Kind  string  `json:"kind"`  // backdrop | arena | endless
+	Image string  `json:"image"` // backdrop: путь от assets
+	Biome string  `json:"biome"` // arena, endless: "" — случайный
+	Scale float32 `json:"scale"` // мировых единиц на пиксель карты; 0 — по умолчанию
+}
+
+// EnemyDef — враг из пула уровня; Weight — относительная частота
+type EnemyDef struct {
+	Kind   string  `json:"kind"`
+	Speed  float32 `json:"speed"`
+	Scale  float32 `json:"scale"`
+	Weight float32 `json:"weight"`
+}
+
+// Win — условие победы
+type Win struct {
+	Kind string  `json:"kind"` // survive | boss | none
+	Time float32 `json:"time"` // survive: секунд
+}
+
+// Done — выполнено ли условие к секунде t забега
+func (w Win) Done(t float32, bossDead bool) bool {
+	switch w.Kind {
+	case WinSurvive:
+		return t >= w.Time
+	case WinBoss:
+		return bossDead
+	}
+	return false
+}
+
+// LoadDefs читает уровни в порядке файла (в нём же они идут в меню).
+// defaultScale подставляется уровням без своего масштаба.
+func LoadDefs(path string, defaultScale float32) ([]*Def, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs []*Def
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	seen := map[string]bool{}
+	for _, d := range defs {
+		if d.ID == "" || seen[d.ID] {
+			return nil, fmt.Errorf("%s: пустой или повторный id %q", path, d.ID)
+		}
+		if d.Requires != "" && !seen[d.Requires] {
+			return nil, fmt.Errorf("%s: уровень %q: requires %q должен идти раньше", path, d.ID, d.Requires)
+		}
+		seen[d.ID] = true
+		if d.Map.Scale <= 0 {
+			d.Map.Scale = defaultScale
+		}
+		if err := d.check(); err != nil {
+			return nil, fmt.Errorf("%s: уровень %q: %w", path, d.ID, err)
+		}
+	}
+	return defs, nil
+}
+
+func (d *Def) check() error {
+	switch d.Map.Kind {
+	case MapBackdrop:
+		if d.Map.Image == "" {
+			return fmt.Errorf("карте backdrop нужен image")
+		}
+	case MapArena, MapEndless:
+	default:
+		return fmt.Errorf("неизвестный вид карты %q", d.Map.Kind)
+	}
+	switch d.Win.Kind {
+	case WinSurvive:
+		if d.Win.Time <= 0 {
+			return fmt.Errorf("survive: нужно time > 0")
+		}
+	case WinBoss:
+		if d.Boss == "" {
+			return fmt.Errorf("победа по боссу без boss")
+		}
+	case "":
+		d.Win.Kind = WinNone
+	case WinNone:
+	default:
+		return fmt.Errorf("неизвестное условие победы %q", d.Win.Kind)
+	}
+	if d.Cost < 0 {
+		return fmt.Errorf("cost < 0")
+	}
+	if d.Music == "" {
+		d.Music = "game"
+	}
+	if len(d.Enemies) == 0 {
+		return fmt.Errorf("пустой пул врагов")
+	}
+	for i := range d.Enemies {
+		e := &d.Enemies[i]
+		if e.Kind == "" || e.Speed <= 0 {
+			return fmt.Errorf("враг %d: нужны kind и speed > 0", i)
+		}
+		if e.Scale <= 0 {
+			e.Scale = 1
+		}
+		if e.Weight <= 0 {
+			e.Weight = 1
+		}
+	}
+	if len(d.Waves) == 0 {
+		return fmt.Errorf("нет волн")
+	}
+	sort.SliceStable(d.Waves, func(i, j int) bool { return d.Waves[i].From < d.Waves[j].From })
+	for i := range d.Waves {
+		w := &d.Waves[i]
+		if w.Every <= 0 {
+			return fmt.Errorf("волна %d: нужно every > 0", i)
+		}
+		if w.Count <= 0 {
+			w.Count = 1
+		}
+	}
+	return nil
+}
+
+// Enemy — враг вида kind из пула уровня
+func (d *Def) Enemy(kind string) (EnemyDef, bool) {
+	for _, e := range d.Enemies {
+		if e.Kind == kind {
+			return e, true
+		}
+	}
+	return EnemyDef{}, false
+}
+
+// Pick — случайный враг из пула с учётом весов
+func (d *Def) Pick(rng *rand.Rand) EnemyDef {
+	total := float32(0)
+	for _, e := range d.Enemies {
+		total += e.Weight
+	}
+	r := rng.Float32() * total
+	for _, e := range d.Enemies {
+		if r -= e.Weight; r < 0 {
+			return e
+		}
+	}
+	return d.Enemies[len(d.Enemies)-1]
+}
@@ -0,0 +1,82 @@
+package level
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ProgressVersion — текущая версия формата progress.json
+const ProgressVersion = 1
+
+// Result — итог уровня у игрока
+type Result struct {
+	Cleared  bool    `json:"cleared"`
+	BestTime float32 `json:"best_time"` // самое быстрое прохождение, секунд
+}
+
+// Progress — пройденные уровни (progress.json рядом с config.json)
+type Progress struct {
+	Version int               `json:"version"`
+	Levels  map[string]Result `json:"levels"`
+}
+
+func NewProgress() *Progress {
+	return &Progress{Version: ProgressVersion, Levels: map[string]Result{}}
+}
+
+// LoadProgress читает прогресс. Файла нет — пустой прогресс без ошибки;
+// файл битый — пустой прогресс и ошибка-предупреждение.
+func LoadProgress(path string) (*Progress, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewProgress(), nil
+	}
+	if err != nil {
+		return NewProgress(), err
+	}
+	p := NewProgress()
+	if err := json.Unmarshal(data, p); err != nil {
+		return NewProgress(), fmt.Errorf("progress %s: %w (прогресс сброшен)", path, err)
+	}
+	if p.Version != ProgressVersion {
+		return NewProgress(), fmt.Errorf("progress %s: неизвестная версия %d (прогресс сброшен)", path, p.Version)
+	}
+	if p.Levels == nil {
+		p.Levels = map[string]Result{}
+	}
+	return p, nil
+}
+
+// SaveProgress пишет прогресс, создавая каталог при необходимости
+func SaveProgress(path string, p *Progress) error {
+	p.Version = ProgressVersion
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Unlocked — открыт ли уровень
+func (p *Progress) Unlocked(d *Def) bool {
+	return d.Requires == "" || p.Levels[d.Requires].Cleared
+}
+
+// Clear отмечает уровень пройденным за t секунд; true — новый рекорд
+func (p *Progress) Clear(id string, t float32) bool {
+	r := p.Levels[id]
+	best := !r.Cleared || t < r.BestTime
+	r.Cleared = true
+	if best {
+		r.BestTime = t
+	}
+	p.Levels[id] = r
+	return best
+}
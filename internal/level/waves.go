@@ -0,0 +1,40 @@
+package level
+
+// Wave — с секунды From каждые Every секунд появляются Count врагов,
+// пока не начнётся следующая волна
+type Wave struct {
+	From  float32 `json:"from"`
+	Every float32 `json:"every"`
+	Count int     `json:"count"`
+}
+
+// Director ведёт сценарий волн уровня
+type Director struct {
+	waves []Wave
+	t     float32 // время забега
+	next  float32 // до следующей партии
+	cur   int     // текущая волна; -1 — ещё ни одна не началась
+}
+
+func NewDirector(waves []Wave) *Director {
+	return &Director{waves: waves, cur: -1}
+}
+
+// Update продвигает время и возвращает, сколько врагов выпустить сейчас
+func (d *Director) Update(dt float32) int {
+	d.t += dt
+	// началась новая волна — её первая партия выходит сразу
+	for d.cur+1 < len(d.waves) && d.t >= d.waves[d.cur+1].From {
+		d.cur++
+		d.next = 0
+	}
+	if d.cur < 0 {
+		return 0
+	}
+	w := d.waves[d.cur]
+	n := 0
+	for d.next -= dt; d.next <= 0; d.next += w.Every {
+		n += w.Count
+	}
+	return n
+}
@@ -0,0 +1,112 @@
+package mapgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// RGB — цвет палитры биома
+type RGB [3]uint8
+
+// Palette — цвета тайлов биома
+type Palette struct {
+	Ground    RGB `json:"ground"`
+	GroundAlt RGB `json:"ground_alt"` // пятна на земле
+	Path      RGB `json:"path"`
+	Wall      RGB `json:"wall"`
+	WallEdge  RGB `json:"wall_edge"` // нижний край стены над полом
+}
+
+// PropDef — вид пропа или декорации
+type PropDef struct {
+	Name   string  `json:"name"`
+	Solid  bool    `json:"solid"` // непроходим (занимает клетку целиком)
+	Shape  string  `json:"shape"` // circle | rect | cross — как рисовать
+	Size   float32 `json:"size"`  // доля клетки
+	Color  RGB     `json:"color"`
+	Weight int     `json:"weight"` // относительная частота; 0 — 1
+}
+
+// Biome — параметры генерации и палитра одного биома (biomes.json)
+type Biome struct {
+	Name         string    `json:"-"`
+	Size         [2]int    `json:"size"`         // клеток по ширине и высоте
+	WallFill     float32   `json:"wall_fill"`    // доля стен в начальном шуме
+	Smooth       int       `json:"smooth"`       // шагов клеточного автомата
+	Arenas       [2]int    `json:"arenas"`       // сколько открытых арен (от, до)
+	ArenaRadius  [2]int    `json:"arena_radius"` // радиус арены в клетках (от, до)
+	PathWidth    int       `json:"path_width"`
+	Props        []PropDef `json:"props"`
+	Decor        []PropDef `json:"decor"`
+	PropDensity  float32   `json:"prop_density"`  // вероятность пропа на свободной клетке
+	DecorDensity float32   `json:"decor_density"` // то же для декораций
+	Palette      Palette   `json:"palette"`
+
+	Objects  []ObjectRule `json:"objects"`  // объекты мира: ящики, бочки, шипы…
+	Teleport string       `json:"teleport"` // объект-телепорт между стартом и дальней ареной; "" — без
+}
+
+// LoadBiomes читает и проверяет biomes.json
+func LoadBiomes(path string) (map[string]Biome, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var bs map[string]Biome
+	if err := json.Unmarshal(data, &bs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for name, b := range bs {
+		b.Name = name
+		if err := b.check(); err != nil {
+			return nil, fmt.Errorf("%s: биом %q: %w", path, name, err)
+		}
+		bs[name] = b
+	}
+	return bs, nil
+}
+
+// Names — имена биомов по алфавиту (для детерминированного выбора)
+func Names(bs map[string]Biome) []string {
+	out := make([]string, 0, len(bs))
+	for n := range bs {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (b *Biome) check() error {
+	if b.Size[0] < 24 || b.Size[1] < 24 {
+		return fmt.Errorf("size меньше 24×24")
+	}
+	if b.WallFill < 0 || b.WallFill >= 1 {
+		return fmt.Errorf("wall_fill вне [0, 1)")
+	}
+	if b.Arenas[0] < 1 || b.Arenas[1] < b.Arenas[0] {
+		return fmt.Errorf("arenas: нужно 1 ≤ от ≤ до")
+	}
+	if b.ArenaRadius[0] < spawnClear || b.ArenaRadius[1] < b.ArenaRadius[0] {
+		return fmt.Errorf("arena_radius: нужно %d ≤ от ≤ до", spawnClear)
+	}
+	if 2*b.ArenaRadius[1]+2*border >= min(b.Size[0], b.Size[1]) {
+		return fmt.Errorf("arena_radius не влезает в карту")
+	}
+	if b.PathWidth < 1 {
+		b.PathWidth = 1
+	}
+	for i := range b.Props {
+		if b.Props[i].Weight <= 0 {
+			b.Props[i].Weight = 1
+		}
+	}
+	for i := range b.Decor {
+		if b.Decor[i].Weight <= 0 {
+			b.Decor[i].Weight = 1
+		}
+		b.Decor[i].Solid = false
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package mapgen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+)
+
+// ChunkSize — сторона чанка бесконечного мира в клетках
+const ChunkSize = 32
+
+// roadBend — насколько тропа через чанк может уйти от середины
+const roadBend = ChunkSize / 5
+
+// chunkArenaChance — вероятность открытой арены в чанке (кроме стартового)
+const chunkArenaChance = 0.5
+
+// ChunkKey — координаты чанка (в чанках)
+type ChunkKey struct{ X, Y int }
+
+// KeyAt — чанк, в котором лежит глобальная клетка
+func KeyAt(cx, cy int) ChunkKey {
+	return ChunkKey{floorDiv(cx, ChunkSize), floorDiv(cy, ChunkSize)}
+}
+
+// ChunkSource — откуда берутся чанки. Вызывается из фонового потока,
+// так что реализация не должна трогать raylib.
+type ChunkSource interface {
+	Chunk(k ChunkKey) (*Map, error)
+}
+
+// Procedural — чанки из генератора: один сид — один и тот же бесконечный мир
+type Procedural struct {
+	Biome Biome
+	Seed  int64
+}
+
+func (p Procedural) Chunk(k ChunkKey) (*Map, error) { return GenerateChunk(p.Biome, p.Seed, k), nil }
+
+// GenerateChunk — чанк k бесконечного мира.
+//
+// Стены — из шума по глобальным координатам; автомат сглаживает окно с
+// запасом, поэтому края соседних чанков стыкуются без швов. Через каждый
+// чанк идут две тропы, приколотые к серединам сторон, — по ним связан весь
+// мир, а всё прочее проходимое внутри чанка соединяется с ними.
+// Стартовый чанк (0,0) — с ареной и пустым местом в центре.
+func GenerateChunk(b Biome, seed int64, k ChunkKey) *Map {
+	const n = ChunkSize
+	rng := rand.New(rand.NewSource(chunkSeed(seed, k)))
+	m := newMap(n, n, b, seed)
+	m.Origin = Cell{k.X * n, k.Y * n}
+	m.Spawn = Cell{n / 2, n / 2}
+
+	pad := b.Smooth + 1
+	wn := n + 2*pad
+	win := make([]Tile, wn*wn)
+	for y := 0; y < wn; y++ {
+		for x := 0; x < wn; x++ {
+			if noise(seed, m.Origin.X+x-pad, m.Origin.Y+y-pad) < b.WallFill {
+				win[y*wn+x] = Wall
+			}
+		}
+	}
+	for i := 0; i < b.Smooth; i++ {
+		win = smoothTiles(win, wn, wn)
+	}
+	for y := 0; y < n; y++ {
+		copy(m.Tiles[y*n:(y+1)*n], win[(y+pad)*wn+pad:])
+	}
+
+	m.road(rng, true)
+	m.road(rng, false)
+
+	away := 0.0
+	if k == (ChunkKey{}) {
+		m.Arenas = []Arena{{m.Spawn, b.ArenaRadius[1]}}
+		away = enemyAway
+	} else {
+		// связность считаем от тропы: середина левого края на ней всегда
+		m.Spawn = Cell{0, n / 2}
+		if rng.Float32() < chunkArenaChance {
+			r := b.ArenaRadius[0] + rng.Intn(b.ArenaRadius[1]-b.ArenaRadius[0]+1)
+			pad := min(r+1, n/2)
+			m.Arenas = []Arena{{Cell{pad + rng.Intn(n-2*pad+1), pad + rng.Intn(n-2*pad+1)}, r}}
+		}
+	}
+	for _, a := range m.Arenas {
+		m.carveDisk(a.X, a.Y, a.R, Floor)
+	}
+	if away > 0 {
+		m.carveDisk(m.Spawn.X, m.Spawn.Y, spawnClear, Floor)
+	}
+	m.ensureConnected()
+	m.placeProps(rng)
+	m.placeDecor(rng)
+	m.placeObjects(rng)
+	m.pickEnemySpawns(away)
+	return m
+}
+
+// road — тропа поперёк чанка: изгибается синусом, но на краях ровно посередине
+func (m *Map) road(rng *rand.Rand, horizontal bool) {
+	n := m.W
+	bend := float64(rng.Intn(2*roadBend+1) - roadBend)
+	wd := m.Biome.PathWidth
+	for i := 0; i < n; i++ {
+		off := n/2 + int(math.Round(bend*math.Sin(math.Pi*float64(i)/float64(n-1))))
+		for j := 0; j < wd; j++ {
+			if horizontal {
+				m.set(i, off+j-wd/2, Path)
+			} else {
+				m.set(off+j-wd/2, i, Path)
+			}
+		}
+	}
+	// изгиб может разорвать тропу по диагонали — прошиваем соседние шаги
+	prev := n / 2
+	for i := 0; i < n; i++ {
+		off := n/2 + int(math.Round(bend*math.Sin(math.Pi*float64(i)/float64(n-1))))
+		for o := min(prev, off); o <= max(prev, off); o++ {
+			if horizontal {
+				m.set(i, o, Path)
+			} else {
+				m.set(o, i, Path)
+			}
+		}
+		prev = off
+	}
+}
+
+// Files — чанки с диска: <Dir>/<x>_<y>.json, а где файла нет — из Fallback.
+// Рукотворный чанк обязан оставить проходимыми середины своих сторон:
+// туда приходят тропы соседей.
+type Files struct {
+	Dir      string
+	Biome    Biome // по именам пропов находим их описание
+	Fallback ChunkSource
+}
+
+// chunkFile — формат файла чанка: строки тайлов ('.' пол, ':' тропа, '#' стена),
+// пропы и объекты мира по имени в клетках чанка
+type chunkFile struct {
+	Tiles []string `json:"tiles"`
+	Props []struct {
+		Name string  `json:"name"`
+		X    float32 `json:"x"`
+		Y    float32 `json:"y"`
+	} `json:"props"`
+	Objects []struct {
+		Name string  `json:"name"`
+		X    float32 `json:"x"`
+		Y    float32 `json:"y"`
+		ID   string  `json:"id"`
+		To   string  `json:"to"`
+	} `json:"objects"`
+}
+
+func (f Files) Chunk(k ChunkKey) (*Map, error) {
+	path := filepath.Join(f.Dir, fmt.Sprintf("%d_%d.json", k.X, k.Y))
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && f.Fallback != nil {
+		return f.Fallback.Chunk(k)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cf chunkFile
+	if err := json.Unmarshal(data, &cf); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	const n = ChunkSize
+	if len(cf.Tiles) != n {
+		return nil, fmt.Errorf("%s: нужно %d строк тайлов, а не %d", path, n, len(cf.Tiles))
+	}
+	m := newMap(n, n, f.Biome, 0)
+	m.Origin = Cell{k.X * n, k.Y * n}
+	m.Spawn = Cell{n / 2, n / 2}
+	for y, row := range cf.Tiles {
+		if len(row) != n {
+			return nil, fmt.Errorf("%s: строка %d: нужно %d клеток", path, y, n)
+		}
+		for x, c := range []byte(row) {
+			switch c {
+			case '.':
+			case ':':
+				m.Tiles[y*n+x] = Path
+			case '#':
+				m.Tiles[y*n+x] = Wall
+			default:
+				return nil, fmt.Errorf("%s: строка %d: неизвестная клетка %q", path, y, c)
+			}
+		}
+	}
+	for _, p := range cf.Props {
+		def, ok := f.Biome.prop(p.Name)
+		if !ok {
+			return nil, fmt.Errorf("%s: в биоме %q нет пропа %q", path, f.Biome.Name, p.Name)
+		}
+		cx, cy := int(p.X), int(p.Y)
+		if cx < 0 || cy < 0 || cx >= n || cy >= n {
+			return nil, fmt.Errorf("%s: проп %q за пределами чанка", path, p.Name)
+		}
+		if def.Solid {
+			m.occupied[cy*n+cx] = true
+			p.X, p.Y = float32(cx)+0.5, float32(cy)+0.5
+		}
+		m.Props = append(m.Props, Prop{def, p.X, p.Y})
+	}
+	for _, o := range cf.Objects {
+		if o.X < 0 || o.Y < 0 || o.X >= n || o.Y >= n {
+			return nil, fmt.Errorf("%s: объект %q за пределами чанка", path, o.Name)
+		}
+		// как непроходимые пропы — ровно в середину клетки
+		m.Objects = append(m.Objects, Object{o.Name, float32(int(o.X)) + 0.5, float32(int(o.Y)) + 0.5, o.ID, o.To})
+	}
+	away := 0.0
+	if k == (ChunkKey{}) {
+		away = enemyAway
+	}
+	m.pickEnemySpawns(away)
+	return m, nil
+}
+
+// prop — описание пропа или декорации по имени
+func (b *Biome) prop(name string) (PropDef, bool) {
+	for _, d := range b.Props {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	for _, d := range b.Decor {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return PropDef{}, false
+}
+
+// chunkSeed — свой поток случайностей у каждого чанка
+func chunkSeed(seed int64, k ChunkKey) int64 {
+	return int64(mix(uint64(seed) ^ mix(uint64(int64(k.X))<<32^uint64(uint32(k.Y)))))
+}
+
+// noise — детерминированное значение 0..1 для глобальной клетки
+func noise(seed int64, x, y int) float32 {
+	h := mix(uint64(seed) ^ mix(uint64(int64(x))*0x9E3779B97F4A7C15^uint64(int64(y))))
+	return float32(h>>40) / (1 << 24)
+}
+
+// mix — финализатор splitmix64
+func mix(z uint64) uint64 {
+	z += 0x9E3779B97F4A7C15
+	z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+	z = (z ^ z>>27) * 0x94D049BB133111EB
+	return z ^ z>>31
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,458 @@
+// Package mapgen — процедурные арены: открытые площадки, связанные тропами,
+// препятствия из клеточного автомата, пропы и декорации по биому.
+//
+// Генерация детерминирована: один и тот же сид и биом дают ту же карту.
+// Пакет не знает про raylib, так что карту можно строить и проверять без окна.
+package mapgen
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Tile — содержимое клетки
+type Tile uint8
+
+const (
+	Floor Tile = iota
+	Path       // тропа между аренами (проходима)
+	Wall       // непроходимо: лес, ограда, топь — по биому
+)
+
+// Cell — координаты клетки
+type Cell struct{ X, Y int }
+
+// Arena — открытая круглая площадка
+type Arena struct {
+	Cell
+	R int
+}
+
+// Prop — проп или декорация; X, Y — в клетках (дробные: декор смещён внутри клетки)
+type Prop struct {
+	PropDef
+	X, Y float32
+}
+
+// Map — результат генерации
+type Map struct {
+	W, H    int
+	Tiles   []Tile
+	Props   []Prop   // сначала непроходимые, потом декорации
+	Objects []Object // объекты мира (ломаются, лечат, ранят, переносят)
+	Arenas  []Arena
+	Spawn   Cell   // старт игрока — центр первой арены, вокруг гарантированно пусто
+	Origin  Cell   // глобальная клетка левого верхнего угла (у чанка бесконечного мира)
+	Enemy   []Cell // точки появления врагов: центры прочих арен и свободные клетки вдали от старта
+	Biome   Biome
+	Seed    int64
+
+	occupied []bool // клетки под непроходимыми пропами
+	margin   int    // неприкосновенная рамка: border у арены, 0 у чанка
+}
+
+const (
+	border       = 2  // толщина сплошной стены по краю
+	spawnClear   = 4  // радиус гарантированно пустого места вокруг старта
+	minRegion    = 24 // меньшие отрезанные карманы заливаем стеной, большие соединяем
+	enemyAway    = 12 // точки врагов не ближе стольких клеток к старту
+	enemyLattice = 8  // шаг решётки, по которой набираем точки врагов
+)
+
+// At — клетка; всё за краем — стена
+func (m *Map) At(x, y int) Tile {
+	if x < 0 || y < 0 || x >= m.W || y >= m.H {
+		return Wall
+	}
+	return m.Tiles[y*m.W+x]
+}
+
+func (m *Map) set(x, y int, t Tile) {
+	if x >= m.margin && y >= m.margin && x < m.W-m.margin && y < m.H-m.margin {
+		m.Tiles[y*m.W+x] = t
+	}
+}
+
+// Solid — клетка непроходима (стена или непроходимый проп)
+func (m *Map) Solid(x, y int) bool {
+	return m.At(x, y) == Wall || m.occupied[y*m.W+x]
+}
+
+// Generate строит карту биома b по сиду
+func Generate(b Biome, seed int64) *Map {
+	rng := rand.New(rand.NewSource(seed))
+	w, h := b.Size[0], b.Size[1]
+	m := newMap(w, h, b, seed)
+	m.margin = border
+
+	m.noise(rng)
+	for i := 0; i < b.Smooth; i++ {
+		m.smooth()
+	}
+	m.placeArenas(rng)
+	for _, a := range m.Arenas {
+		m.carveDisk(a.X, a.Y, a.R, Floor)
+	}
+	m.connectArenas(rng)
+	m.carveDisk(m.Spawn.X, m.Spawn.Y, spawnClear, Floor)
+	m.ensureConnected()
+	m.placeProps(rng)
+	m.placeDecor(rng)
+	m.placeObjects(rng)
+	m.pickEnemySpawns(enemyAway)
+	return m
+}
+
+func newMap(w, h int, b Biome, seed int64) *Map {
+	return &Map{W: w, H: h, Tiles: make([]Tile, w*h), Biome: b, Seed: seed, occupied: make([]bool, w*h)}
+}
+
+// noise — случайные стены плюс сплошная рамка
+func (m *Map) noise(rng *rand.Rand) {
+	for y := 0; y < m.H; y++ {
+		for x := 0; x < m.W; x++ {
+			wall := x < m.margin || y < m.margin || x >= m.W-m.margin || y >= m.H-m.margin ||
+				rng.Float32() < m.Biome.WallFill
+			if wall {
+				m.Tiles[y*m.W+x] = Wall
+			}
+		}
+	}
+}
+
+// smooth — шаг клеточного автомата с сохранением рамки
+func (m *Map) smooth() {
+	m.Tiles = smoothTiles(m.Tiles, m.W, m.H)
+	// рамку автомат не трогает
+	for y := 0; y < m.H; y++ {
+		for x := 0; x < m.W; x++ {
+			if x < m.margin || y < m.margin || x >= m.W-m.margin || y >= m.H-m.margin {
+				m.Tiles[y*m.W+x] = Wall
+			}
+		}
+	}
+}
+
+// smoothTiles — шаг клеточного автомата: стена там, где вокруг больше 4 стен
+// (за краем — стена)
+func smoothTiles(tiles []Tile, w, h int) []Tile {
+	at := func(x, y int) Tile {
+		if x < 0 || y < 0 || x >= w || y >= h {
+			return Wall
+		}
+		return tiles[y*w+x]
+	}
+	next := make([]Tile, len(tiles))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			n := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if (dx != 0 || dy != 0) && at(x+dx, y+dy) == Wall {
+						n++
+					}
+				}
+			}
+			t := at(x, y)
+			switch {
+			case n > 4:
+				t = Wall
+			case n < 4:
+				t = Floor
+			}
+			next[y*w+x] = t
+		}
+	}
+	return next
+}
+
+// placeArenas — первая арена (старт) в центре, остальные — случайно, не внахлёст
+func (m *Map) placeArenas(rng *rand.Rand) {
+	b := m.Biome
+	n := b.Arenas[0] + rng.Intn(b.Arenas[1]-b.Arenas[0]+1)
+	spawn := Arena{Cell{m.W / 2, m.H / 2}, b.ArenaRadius[1]}
+	m.Arenas = []Arena{spawn}
+	m.Spawn = spawn.Cell
+	for len(m.Arenas) < n {
+		placed := false
+		for try := 0; try < 50 && !placed; try++ {
+			r := b.ArenaRadius[0] + rng.Intn(b.ArenaRadius[1]-b.ArenaRadius[0]+1)
+			pad := r + m.margin + 1
+			if m.W-2*pad <= 0 || m.H-2*pad <= 0 {
+				break
+			}
+			a := Arena{Cell{pad + rng.Intn(m.W-2*pad), pad + rng.Intn(m.H-2*pad)}, r}
+			placed = true
+			for _, o := range m.Arenas {
+				if dist(a.Cell, o.Cell) < float64(a.R+o.R+3) {
+					placed = false
+					break
+				}
+			}
+			if placed {
+				m.Arenas = append(m.Arenas, a)
+			}
+		}
+		if !placed {
+			break // места нет — обойдёмся меньшим числом арен
+		}
+	}
+}
+
+func (m *Map) carveDisk(cx, cy, r int, t Tile) {
+	for y := cy - r; y <= cy+r; y++ {
+		for x := cx - r; x <= cx+r; x++ {
+			if (x-cx)*(x-cx)+(y-cy)*(y-cy) <= r*r {
+				m.set(x, y, t)
+			}
+		}
+	}
+}
+
+// connectArenas — тропы по минимальному остову: каждая новая арена
+// соединяется с ближайшей из уже связанных
+func (m *Map) connectArenas(rng *rand.Rand) {
+	linked := []Arena{m.Arenas[0]}
+	rest := append([]Arena(nil), m.Arenas[1:]...)
+	for len(rest) > 0 {
+		bi, bj, bd := 0, 0, math.MaxFloat64
+		for i, a := range rest {
+			for j, l := range linked {
+				if d := dist(a.Cell, l.Cell); d < bd {
+					bi, bj, bd = i, j, d
+				}
+			}
+		}
+		m.carvePath(rng, rest[bi].Cell, linked[bj].Cell)
+		linked = append(linked, rest[bi])
+		rest = append(rest[:bi], rest[bi+1:]...)
+	}
+}
+
+// carvePath — «пьяная» тропа от a к b: в основном шагаем к цели, иногда вбок
+func (m *Map) carvePath(rng *rand.Rand, a, b Cell) {
+	x, y := a.X, a.Y
+	wd := m.Biome.PathWidth
+	for steps := 0; (x != b.X || y != b.Y) && steps < 4*(m.W+m.H); steps++ {
+		for dy := 0; dy < wd; dy++ {
+			for dx := 0; dx < wd; dx++ {
+				m.set(x+dx-wd/2, y+dy-wd/2, Path)
+			}
+		}
+		if rng.Float32() < 0.75 {
+			// к цели по оси с большим расстоянием
+			if abs(b.X-x) > abs(b.Y-y) {
+				x += sign(b.X - x)
+			} else {
+				y += sign(b.Y - y)
+			}
+		} else if rng.Intn(2) == 0 {
+			x = clamp(x+rng.Intn(3)-1, m.margin, m.W-m.margin-1)
+		} else {
+			y = clamp(y+rng.Intn(3)-1, m.margin, m.H-m.margin-1)
+		}
+	}
+}
+
+// ensureConnected — всё проходимое связано со стартом: большие отрезанные
+// карманы соединяем коридором к ближайшей связанной клетке, мелкие заливаем
+func (m *Map) ensureConnected() {
+	for {
+		if m.At(m.Spawn.X, m.Spawn.Y) == Wall {
+			return // не от чего считать
+		}
+		main := m.flood(m.Spawn)
+		region, size := m.firstOutside(main)
+		if region == nil {
+			return
+		}
+		if size < minRegion {
+			for i, in := range region {
+				if in {
+					m.Tiles[i] = Wall
+				}
+			}
+			continue
+		}
+		m.tunnel(region, main)
+	}
+}
+
+// flood — клетки, достижимые из c по 4 направлениям
+func (m *Map) flood(c Cell) []bool {
+	seen := make([]bool, len(m.Tiles))
+	if m.At(c.X, c.Y) == Wall {
+		return seen
+	}
+	stack := []int{c.Y*m.W + c.X}
+	seen[stack[0]] = true
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		x, y := i%m.W, i/m.W
+		for _, d := range dirs4 {
+			nx, ny := x+d[0], y+d[1]
+			if j := ny*m.W + nx; m.At(nx, ny) != Wall && !seen[j] {
+				seen[j] = true
+				stack = append(stack, j)
+			}
+		}
+	}
+	return seen
+}
+
+// firstOutside — первый (в порядке строк) проходимый карман вне main
+func (m *Map) firstOutside(main []bool) ([]bool, int) {
+	for i, t := range m.Tiles {
+		if t != Wall && !main[i] {
+			r := m.flood(Cell{i % m.W, i / m.W})
+			n := 0
+			for _, in := range r {
+				if in {
+					n++
+				}
+			}
+			return r, n
+		}
+	}
+	return nil, 0
+}
+
+// tunnel — кратчайший коридор (поиск в ширину сквозь стены) из кармана в main
+func (m *Map) tunnel(region, main []bool) {
+	prev := make([]int, len(m.Tiles))
+	for i := range prev {
+		prev[i] = -1
+	}
+	var queue []int
+	for i, in := range region {
+		if in {
+			prev[i] = i
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		if main[i] {
+			for ; !region[i]; i = prev[i] {
+				m.Tiles[i] = Floor
+			}
+			return
+		}
+		x, y := i%m.W, i/m.W
+		for _, d := range dirs4 {
+			nx, ny := x+d[0], y+d[1]
+			if nx < m.margin || ny < m.margin || nx >= m.W-m.margin || ny >= m.H-m.margin {
+				continue
+			}
+			if j := ny*m.W + nx; prev[j] < 0 {
+				prev[j] = i
+				queue = append(queue, j)
+			}
+		}
+	}
+}
+
+// placeProps — непроходимые пропы только на клетках, все 8 соседей которых
+// свободны: одиночный столбик посреди поля связность не ломает
+func (m *Map) placeProps(rng *rand.Rand) {
+	defs := m.Biome.Props
+	if len(defs) == 0 {
+		return
+	}
+	for y := m.margin; y < m.H-m.margin; y++ {
+		for x := m.margin; x < m.W-m.margin; x++ {
+			if m.At(x, y) != Floor || rng.Float32() >= m.Biome.PropDensity {
+				continue
+			}
+			if dist(Cell{x, y}, m.Spawn) <= spawnClear+1 || !m.openAround(x, y) {
+				continue
+			}
+			m.occupied[y*m.W+x] = true
+			m.Props = append(m.Props, Prop{pick(rng, defs), float32(x) + 0.5, float32(y) + 0.5})
+		}
+	}
+}
+
+func (m *Map) openAround(x, y int) bool {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if m.Solid(x+dx, y+dy) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// placeDecor — проходимые мелочи (трава, кости, кочки) с разбросом внутри клетки
+func (m *Map) placeDecor(rng *rand.Rand) {
+	defs := m.Biome.Decor
+	if len(defs) == 0 {
+		return
+	}
+	for y := m.margin; y < m.H-m.margin; y++ {
+		for x := m.margin; x < m.W-m.margin; x++ {
+			if m.Solid(x, y) || rng.Float32() >= m.Biome.DecorDensity {
+				continue
+			}
+			m.Props = append(m.Props, Prop{pick(rng, defs), float32(x) + rng.Float32(), float32(y) + rng.Float32()})
+		}
+	}
+}
+
+// pickEnemySpawns — центры арен и узлы редкой решётки не ближе away к старту
+func (m *Map) pickEnemySpawns(away float64) {
+	for _, a := range m.Arenas {
+		// в центр арены чанка мог встать проп — тогда обойдёмся решёткой
+		if !m.Solid(a.X, a.Y) && dist(a.Cell, m.Spawn) >= away {
+			m.Enemy = append(m.Enemy, a.Cell)
+		}
+	}
+	for y := m.margin + enemyLattice/2; y < m.H-m.margin; y += enemyLattice {
+		for x := m.margin + enemyLattice/2; x < m.W-m.margin; x += enemyLattice {
+			if !m.Solid(x, y) && dist(Cell{x, y}, m.Spawn) >= away {
+				m.Enemy = append(m.Enemy, Cell{x, y})
+			}
+		}
+	}
+}
+
+// pick — вид по весам
+func pick(rng *rand.Rand, defs []PropDef) PropDef {
+	total := 0
+	for _, d := range defs {
+		total += d.Weight
+	}
+	r := rng.Intn(total)
+	for _, d := range defs {
+		if r -= d.Weight; r < 0 {
+			return d
+		}
+	}
+	return defs[len(defs)-1]
+}
+
+var dirs4 = [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+func dist(a, b Cell) float64 { return math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y)) }
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func clamp(v, lo, hi int) int { return min(max(v, lo), hi) }
@@ -0,0 +1,139 @@
+package mapgen
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func shippedBiomes(t *testing.T) map[string]Biome {
+	t.Helper()
+	bs, err := LoadBiomes(filepath.Join("..", "..", "assets", "data", "biomes.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bs) == 0 {
+		t.Fatal("нет биомов")
+	}
+	return bs
+}
+
+// один сид — одна карта; другой сид — другая. Для каждого биома.
+func TestGenerateDeterministic(t *testing.T) {
+	for name, b := range shippedBiomes(t) {
+		t.Run(name, func(t *testing.T) {
+			a, again := Generate(b, 42), Generate(b, 42)
+			if !reflect.DeepEqual(a, again) {
+				t.Fatal("сид 42 дал две разные карты")
+			}
+			other := Generate(b, 43)
+			if reflect.DeepEqual(a.Tiles, other.Tiles) {
+				t.Fatal("сиды 42 и 43 дали одинаковые клетки")
+			}
+			if a.Spawn == other.Spawn && reflect.DeepEqual(a.Props, other.Props) {
+				t.Fatal("сиды 42 и 43 дали одинаковые старт и пропы")
+			}
+		})
+	}
+}
+
+// чанк бесконечного мира зависит только от сида и ключа, не от порядка генерации
+func TestGenerateChunkDeterministic(t *testing.T) {
+	for name, b := range shippedBiomes(t) {
+		t.Run(name, func(t *testing.T) {
+			k := ChunkKey{3, -2}
+			a := GenerateChunk(b, 7, k)
+			GenerateChunk(b, 7, ChunkKey{0, 0}) // соседи между делом не влияют
+			if !reflect.DeepEqual(a, GenerateChunk(b, 7, k)) {
+				t.Fatal("сид 7 дал два разных чанка")
+			}
+			if reflect.DeepEqual(a.Tiles, GenerateChunk(b, 8, k).Tiles) {
+				t.Fatal("сиды 7 и 8 дали одинаковый чанк")
+			}
+			if reflect.DeepEqual(a.Tiles, GenerateChunk(b, 7, ChunkKey{4, -2}).Tiles) {
+				t.Fatal("соседние чанки одинаковы")
+			}
+		})
+	}
+}
+
+// walkable — клетки, достижимые из старта по 4 направлениям в обход стен и пропов
+func walkable(m *Map) []bool {
+	seen := make([]bool, len(m.Tiles))
+	if m.Solid(m.Spawn.X, m.Spawn.Y) {
+		return seen
+	}
+	stack := []Cell{m.Spawn}
+	seen[m.Spawn.Y*m.W+m.Spawn.X] = true
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, d := range dirs4 {
+			x, y := c.X+d[0], c.Y+d[1]
+			if m.Solid(x, y) || seen[y*m.W+x] {
+				continue
+			}
+			seen[y*m.W+x] = true
+			stack = append(stack, Cell{x, y})
+		}
+	}
+	return seen
+}
+
+// checkConnected — из старта доходим до каждой проходимой клетки и каждой точки врагов
+func checkConnected(t *testing.T, m *Map) {
+	t.Helper()
+	seen := walkable(m)
+	for y := 0; y < m.H; y++ {
+		for x := 0; x < m.W; x++ {
+			if !m.Solid(x, y) && !seen[y*m.W+x] {
+				t.Fatalf("сид %d: клетка (%d, %d) отрезана от старта %v", m.Seed, x, y, m.Spawn)
+			}
+		}
+	}
+	for _, e := range m.Enemy {
+		if !seen[e.Y*m.W+e.X] {
+			t.Fatalf("сид %d: точка врагов %v недостижима", m.Seed, e)
+		}
+	}
+}
+
+// Generate: всё проходимое связано со стартом, вокруг старта пусто
+func TestGenerateConnectedClearSpawn(t *testing.T) {
+	for name, b := range shippedBiomes(t) {
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(1); seed <= 300; seed++ {
+				m := Generate(b, seed)
+				checkConnected(t, m)
+				r := spawnClear
+				for y := -r; y <= r; y++ {
+					for x := -r; x <= r; x++ {
+						if x*x+y*y <= r*r && m.Solid(m.Spawn.X+x, m.Spawn.Y+y) {
+							t.Fatalf("сид %d: у старта %v непроходимо (%+d, %+d)", seed, m.Spawn, x, y)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// GenerateChunk: середины всех четырёх сторон проходимы — тропы стыкуют чанки
+func TestGenerateChunkEdges(t *testing.T) {
+	const n = ChunkSize
+	for name, b := range shippedBiomes(t) {
+		t.Run(name, func(t *testing.T) {
+			for ky := -6; ky < 6; ky++ {
+				for kx := -6; kx < 6; kx++ {
+					m := GenerateChunk(b, 11, ChunkKey{kx, ky})
+					for _, c := range []Cell{{0, n / 2}, {n - 1, n / 2}, {n / 2, 0}, {n / 2, n - 1}} {
+						if m.Solid(c.X, c.Y) {
+							t.Fatalf("чанк (%d, %d): середина края %v непроходима", kx, ky, c)
+						}
+					}
+					checkConnected(t, m)
+				}
+			}
+		})
+	}
+}
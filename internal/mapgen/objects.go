@@ -0,0 +1,58 @@
+package mapgen
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ObjectRule — объекты мира одного вида, раскиданные по карте. Их поведение
+// и вид знает игра; генератор знает только имя и плотность.
+type ObjectRule struct {
+	Name    string  `json:"name"`
+	Density float32 `json:"density"` // вероятность на свободной клетке
+}
+
+// Object — объект мира на карте; X, Y — в клетках. Телепорт переносит
+// на объект, чей ID равен его To.
+type Object struct {
+	Name   string
+	X, Y   float32
+	ID, To string
+}
+
+// placeObjects — объекты по правилам биома (там же, где могли бы стоять
+// непроходимые пропы, так что связность не страдает) и пара телепортов
+// между стартом и самой дальней ареной
+func (m *Map) placeObjects(rng *rand.Rand) {
+	for _, r := range m.Biome.Objects {
+		for y := m.margin; y < m.H-m.margin; y++ {
+			for x := m.margin; x < m.W-m.margin; x++ {
+				if m.At(x, y) != Floor || rng.Float32() >= r.Density {
+					continue
+				}
+				if dist(Cell{x, y}, m.Spawn) <= spawnClear+1 || !m.openAround(x, y) {
+					continue
+				}
+				m.occupied[y*m.W+x] = true
+				m.Objects = append(m.Objects, Object{Name: r.Name, X: float32(x) + 0.5, Y: float32(y) + 0.5})
+			}
+		}
+	}
+
+	if m.Biome.Teleport == "" || len(m.Arenas) < 2 {
+		return
+	}
+	far := m.Arenas[0]
+	for _, a := range m.Arenas[1:] {
+		if dist(a.Cell, m.Spawn) > dist(far.Cell, m.Spawn) {
+			far = a
+		}
+	}
+	// площадка у старта — сбоку, чтобы игрок не появился прямо на ней
+	near := Cell{m.Spawn.X + spawnClear - 1, m.Spawn.Y}
+	a, b := fmt.Sprintf("tp%d", len(m.Objects)), fmt.Sprintf("tp%d", len(m.Objects)+1)
+	m.Objects = append(m.Objects,
+		Object{Name: m.Biome.Teleport, X: float32(near.X) + 0.5, Y: float32(near.Y) + 0.5, ID: a, To: b},
+		Object{Name: m.Biome.Teleport, X: float32(far.X) + 0.5, Y: float32(far.Y) + 0.5, ID: b, To: a},
+	)
+}
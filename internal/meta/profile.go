@@ -0,0 +1,162 @@
+// Package meta — то, что переживает забег: валюта, купленные улучшения
+// и открытия, снаряжение и общая статистика (profile.json рядом с config.json)
+package meta
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ProfileVersion — текущая версия формата profile.json
+const ProfileVersion = 1
+
+// migrations[v] переводит сырой профиль версии v в версию v+1.
+// Меняешь формат — поднимай ProfileVersion и добавляй шаг сюда.
+var migrations = map[int]func(raw map[string]any) error{
+	// 0 → 1: файл без версии (правка руками) — формат тот же, но поля
+	// не того вида сбрасываем, а не теряем из-за них весь профиль
+	0: func(raw map[string]any) error {
+		for _, k := range []string{"upgrades", "unlocks", "stats"} {
+			if _, ok := raw[k].(map[string]any); !ok {
+				delete(raw, k)
+			}
+		}
+		if _, ok := raw["gold"].(float64); !ok {
+			delete(raw, "gold")
+		}
+		return nil
+	},
+}
+
+// Stats — статистика за всё время
+type Stats struct {
+	Runs     int     `json:"runs"`
+	Wins     int     `json:"wins"`
+	Deaths   int     `json:"deaths"`
+	Kills    int     `json:"kills"`
+	Bosses   int     `json:"bosses"` // убито боссов
+	Souls    int     `json:"souls"`
+	Gold     int     `json:"gold"`      // заработано за всё время
+	PlayTime float64 `json:"play_time"` // секунд в забегах
+	BestTime float32 `json:"best_time"` // самый долгий забег, секунд
+}
+
+// Profile — профиль игрока между забегами
+type Profile struct {
+	Version  int             `json:"version"`
+	Gold     int             `json:"gold"`     // валюта магазина
+	Upgrades map[string]int  `json:"upgrades"` // id улучшения → купленный уровень
+	Unlocks  map[string]bool `json:"unlocks"`  // ключи открытий (Unlock.Key)
+	Weapon   string          `json:"weapon"`   // выбранное оружие; "" — начальное
+	Ult      string          `json:"ult"`      // выбранная ульта; "" — начальная
+	Stats    Stats           `json:"stats"`
+}
+
+func NewProfile() *Profile {
+	return &Profile{Version: ProfileVersion, Upgrades: map[string]int{}, Unlocks: map[string]bool{}}
+}
+
+// LoadProfile читает профиль и доводит старые версии до текущей.
+// Файла нет — новый профиль без ошибки; файл битый или из более новой
+// игры — новый профиль и ошибка-предупреждение.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewProfile(), nil
+	}
+	if err != nil {
+		return NewProfile(), err
+	}
+	p, err := decodeProfile(data)
+	if err != nil {
+		return NewProfile(), fmt.Errorf("profile %s: %w (профиль сброшен)", path, err)
+	}
+	return p, nil
+}
+
+func decodeProfile(data []byte) (*Profile, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	v := 0
+	if n, ok := raw["version"].(float64); ok {
+		v = int(n)
+	}
+	if v > ProfileVersion {
+		return nil, fmt.Errorf("версия %d новее игры", v)
+	}
+	for ; v < ProfileVersion; v++ {
+		step, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("нет перехода с версии %d", v)
+		}
+		if err := step(raw); err != nil {
+			return nil, fmt.Errorf("переход с версии %d: %w", v, err)
+		}
+	}
+	raw["version"] = ProfileVersion
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	p := NewProfile()
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	if p.Upgrades == nil {
+		p.Upgrades = map[string]int{}
+	}
+	if p.Unlocks == nil {
+		p.Unlocks = map[string]bool{}
+	}
+	p.Gold = max(p.Gold, 0)
+	return p, nil
+}
+
+// SaveProfile пишет профиль, создавая каталог при необходимости
+func SaveProfile(path string, p *Profile) error {
+	p.Version = ProfileVersion
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Run — итог одного забега для профиля
+type Run struct {
+	Gold   int
+	Souls  int
+	Kills  int
+	Bosses int
+	Time   float32
+	Won    bool
+	Died   bool
+}
+
+// Bank зачисляет забег: золото — в копилку, остальное — в статистику
+func (p *Profile) Bank(r Run) {
+	p.Gold += r.Gold
+	s := &p.Stats
+	s.Runs++
+	if r.Won {
+		s.Wins++
+	}
+	if r.Died {
+		s.Deaths++
+	}
+	s.Kills += r.Kills
+	s.Bosses += r.Bosses
+	s.Souls += r.Souls
+	s.Gold += r.Gold
+	s.PlayTime += float64(r.Time)
+	s.BestTime = max(s.BestTime, r.Time)
+}
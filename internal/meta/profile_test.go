@@ -0,0 +1,156 @@
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProfile(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// файл без версии с полями не того вида: они сбрасываются, остальное остаётся
+func TestMigrateUnversioned(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+		gold int
+		upg  int
+	}{
+		{"upgrades строкой", `{"gold": 120, "upgrades": "max_hp", "weapon": "spread", "unlocks": {"weapon:spread": true}, "stats": {"runs": 7}}`, 120, 0},
+		{"gold строкой", `{"gold": "много", "upgrades": {"max_hp": 2}, "weapon": "spread", "unlocks": {"weapon:spread": true}, "stats": {"runs": 7}}`, 0, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := LoadProfile(writeProfile(t, tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Version != ProfileVersion {
+				t.Fatalf("версия %d, want %d", p.Version, ProfileVersion)
+			}
+			if p.Gold != tc.gold || p.Upgrades[UpgMaxHP] != tc.upg {
+				t.Fatalf("gold %d, max_hp %d; want %d, %d", p.Gold, p.Upgrades[UpgMaxHP], tc.gold, tc.upg)
+			}
+			if p.Upgrades == nil || p.Unlocks == nil {
+				t.Fatal("карты профиля не созданы")
+			}
+			// прочие поля пережили переход
+			if p.Weapon != "spread" || !p.Unlocks["weapon:spread"] || p.Stats.Runs != 7 {
+				t.Fatalf("потеряны поля: %+v", p)
+			}
+		})
+	}
+}
+
+func TestProfileFromFuture(t *testing.T) {
+	p, err := LoadProfile(writeProfile(t, `{"version": 99, "gold": 5000}`))
+	if err == nil || !strings.Contains(err.Error(), "99") {
+		t.Fatalf("нет ошибки про версию 99: %v", err)
+	}
+	if p == nil || p.Gold != 0 || p.Version != ProfileVersion || p.Upgrades == nil {
+		t.Fatalf("вместо умолчаний %+v", p)
+	}
+}
+
+func TestProfileBrokenAndMissing(t *testing.T) {
+	if p, err := LoadProfile(writeProfile(t, `{"gold": `)); err == nil || p.Gold != 0 {
+		t.Fatalf("битый файл: err %v, %+v", err, p)
+	}
+	p, err := LoadProfile(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil || p.Version != ProfileVersion {
+		t.Fatalf("нет файла: err %v, %+v", err, p)
+	}
+	// сохранённый профиль читается обратно как есть
+	p.Gold, p.Weapon = 77, "lance"
+	p.Upgrades[UpgMagnet] = 3
+	path := filepath.Join(t.TempDir(), "sub", "profile.json")
+	if err := SaveProfile(path, p); err != nil {
+		t.Fatal(err)
+	}
+	back, err := LoadProfile(path)
+	if err != nil || back.Gold != 77 || back.Weapon != "lance" || back.Upgrades[UpgMagnet] != 3 {
+		t.Fatalf("после сохранения: err %v, %+v", err, back)
+	}
+}
+
+func testShop() *Shop {
+	return &Shop{
+		Upgrades: []*Upgrade{{ID: UpgMaxHP, Step: 20, Costs: []int{30, 60}}},
+		Unlocks: []*Unlock{
+			{Kind: UnlockWeapon, ID: "bolt"},
+			{Kind: UnlockWeapon, ID: "spread", Cost: 200},
+			{Kind: UnlockUlt, ID: "freeze"},
+			{Kind: UnlockUlt, ID: "nova", Cost: 400},
+		},
+	}
+}
+
+func TestBuy(t *testing.T) {
+	s := testShop()
+	p := NewProfile()
+	p.Gold = 100
+	hp := s.Upgrade(UpgMaxHP)
+	if !p.BuyUpgrade(hp) || !p.BuyUpgrade(hp) || p.Gold != 10 {
+		t.Fatalf("два уровня за 30+60: gold %d", p.Gold)
+	}
+	if p.BuyUpgrade(hp) || p.Gold != 10 {
+		t.Fatal("куплен уровень сверх максимума")
+	}
+	if p.Bonus(s, UpgMaxHP) != 40 {
+		t.Fatalf("бонус %.0f, want 40", p.Bonus(s, UpgMaxHP))
+	}
+
+	spread := s.Unlock(UnlockWeapon, "spread")
+	if p.BuyUnlock(spread) {
+		t.Fatal("куплено без денег")
+	}
+	p.Gold = 250
+	if !p.BuyUnlock(spread) || p.Gold != 50 || p.BuyUnlock(spread) {
+		t.Fatalf("покупка открытия: gold %d", p.Gold)
+	}
+	if p.BuyUnlock(s.Unlock(UnlockWeapon, "bolt")) {
+		t.Fatal("бесплатное открытие «куплено» повторно")
+	}
+}
+
+// выбранное снаряжение пропало из shop.json или не куплено — начальное
+func TestLoadoutFallback(t *testing.T) {
+	s := testShop()
+	p := NewProfile()
+	if w, u := p.Loadout(s); w != "bolt" || u != "freeze" {
+		t.Fatalf("пустой выбор: %s, %s", w, u)
+	}
+
+	p.Gold = 1000
+	p.BuyUnlock(s.Unlock(UnlockWeapon, "spread"))
+	p.BuyUnlock(s.Unlock(UnlockUlt, "nova"))
+	p.Equip(s.Unlock(UnlockWeapon, "spread"))
+	p.Equip(s.Unlock(UnlockUlt, "nova"))
+	if w, u := p.Loadout(s); w != "spread" || u != "nova" {
+		t.Fatalf("снаряжение: %s, %s", w, u)
+	}
+
+	// новая версия shop.json без spread и nova
+	s.Unlocks = []*Unlock{s.Unlocks[0], s.Unlocks[2]}
+	if w, u := p.Loadout(s); w != "bolt" || u != "freeze" {
+		t.Fatalf("после пропажи из магазина: %s, %s", w, u)
+	}
+	// экран магазина показывает то же, с чем начнётся забег
+	if !p.Equipped(s, s.Unlock(UnlockWeapon, "bolt")) || !p.Equipped(s, s.Unlock(UnlockUlt, "freeze")) {
+		t.Fatal("Equipped не совпадает с Loadout")
+	}
+
+	// выбрано, но не куплено (правка файла руками)
+	p = NewProfile()
+	p.Weapon = "spread"
+	if w, _ := p.Loadout(testShop()); w != "bolt" {
+		t.Fatalf("не купленное оружие: %s", w)
+	}
+}
@@ -0,0 +1,208 @@
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Постоянные улучшения, которые знает игра (data/shop.json, upgrades)
+const (
+	UpgMaxHP     = "max_hp"         // + к начальному здоровью
+	UpgCrook     = "crook_cooldown" // + к перезарядке крюка (шаг отрицательный)
+	UpgMagnet    = "magnet"         // + к радиусу притяжения предметов
+	UpgUltCharge = "ult_charge"     // зарядов ульты на старте
+)
+
+// Виды открытий
+const (
+	UnlockWeapon = "weapon"
+	UnlockUlt    = "ult"
+	UnlockLevel  = "level"
+)
+
+// Upgrade — улучшение с уровнями: Costs[i] — цена уровня i+1,
+// каждый уровень добавляет Step к своему параметру
+type Upgrade struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"` // ключ перевода
+	Step  float32 `json:"step"`
+	Costs []int   `json:"costs"`
+}
+
+// Unlock — разовая покупка: оружие, ульта или уровень. Cost 0 — открыто сразу.
+type Unlock struct {
+	Kind string `json:"kind"` // weapon | ult | level
+	ID   string `json:"id"`
+	Name string `json:"name"` // ключ перевода
+	Cost int    `json:"cost"`
+}
+
+// Key — ключ открытия в профиле: «вид:id»
+func (u *Unlock) Key() string { return UnlockKey(u.Kind, u.ID) }
+
+func UnlockKey(kind, id string) string { return kind + ":" + id }
+
+// Shop — ассортимент магазина в порядке показа
+type Shop struct {
+	Upgrades []*Upgrade `json:"upgrades"`
+	Unlocks  []*Unlock  `json:"unlocks"`
+}
+
+// LoadShop читает и проверяет shop.json
+func LoadShop(path string) (*Shop, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Shop
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("shop %s: %w", path, err)
+	}
+	for _, u := range s.Upgrades {
+		switch u.ID {
+		case UpgMaxHP, UpgCrook, UpgMagnet, UpgUltCharge:
+		default:
+			return nil, fmt.Errorf("shop: неизвестное улучшение %q", u.ID)
+		}
+		if len(u.Costs) == 0 {
+			return nil, fmt.Errorf("shop: у улучшения %q нет цен", u.ID)
+		}
+	}
+	for _, u := range s.Unlocks {
+		if err := s.checkUnlock(u); err != nil {
+			return nil, err
+		}
+	}
+	return &s, nil
+}
+
+func (s *Shop) checkUnlock(u *Unlock) error {
+	switch u.Kind {
+	case UnlockWeapon, UnlockUlt, UnlockLevel:
+	default:
+		return fmt.Errorf("shop: %q: неизвестный вид открытия %q", u.ID, u.Kind)
+	}
+	if u.ID == "" || u.Cost < 0 {
+		return fmt.Errorf("shop: открытию %s нужны id и cost ≥ 0", u.Kind)
+	}
+	return nil
+}
+
+// AddUnlock добавляет открытие не из shop.json (уровни с ценой из levels.json)
+func (s *Shop) AddUnlock(u *Unlock) error {
+	if err := s.checkUnlock(u); err != nil {
+		return err
+	}
+	s.Unlocks = append(s.Unlocks, u)
+	return nil
+}
+
+// Upgrade — улучшение по id; нет — nil
+func (s *Shop) Upgrade(id string) *Upgrade {
+	for _, u := range s.Upgrades {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+// Unlock — открытие по виду и id; нет — nil
+func (s *Shop) Unlock(kind, id string) *Unlock {
+	for _, u := range s.Unlocks {
+		if u.Kind == kind && u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+// Next — цена следующего уровня улучшения; false — уже максимум
+func (p *Profile) Next(u *Upgrade) (int, bool) {
+	lv := p.Upgrades[u.ID]
+	if lv >= len(u.Costs) {
+		return 0, false
+	}
+	return u.Costs[lv], true
+}
+
+// Bonus — сколько даёт улучшение id на купленном уровне; нет такого — 0
+func (p *Profile) Bonus(s *Shop, id string) float32 {
+	u := s.Upgrade(id)
+	if u == nil {
+		return 0
+	}
+	return u.Step * float32(min(p.Upgrades[id], len(u.Costs)))
+}
+
+// BuyUpgrade покупает следующий уровень; false — максимум или не хватает золота
+func (p *Profile) BuyUpgrade(u *Upgrade) bool {
+	cost, ok := p.Next(u)
+	if !ok || p.Gold < cost {
+		return false
+	}
+	p.Gold -= cost
+	p.Upgrades[u.ID]++
+	return true
+}
+
+// Owns — открытие доступно: бесплатное или купленное
+func (p *Profile) Owns(u *Unlock) bool { return u.Cost == 0 || p.Unlocks[u.Key()] }
+
+// BuyUnlock покупает открытие; false — уже есть или не хватает золота
+func (p *Profile) BuyUnlock(u *Unlock) bool {
+	if p.Owns(u) || p.Gold < u.Cost {
+		return false
+	}
+	p.Gold -= u.Cost
+	p.Unlocks[u.Key()] = true
+	return true
+}
+
+// Equip выбирает купленное оружие или ульту; false — не куплено или не снаряжение
+func (p *Profile) Equip(u *Unlock) bool {
+	if !p.Owns(u) {
+		return false
+	}
+	switch u.Kind {
+	case UnlockWeapon:
+		p.Weapon = u.ID
+	case UnlockUlt:
+		p.Ult = u.ID
+	default:
+		return false
+	}
+	return true
+}
+
+// Equipped — выбрано ли это оружие или ульта; то же, что отдаст Loadout
+func (p *Profile) Equipped(s *Shop, u *Unlock) bool {
+	cur, ult := p.Loadout(s)
+	if u.Kind == UnlockUlt {
+		cur = ult
+	}
+	return u.ID == cur
+}
+
+// Default — первое бесплатное открытие вида kind (начальное снаряжение)
+func (s *Shop) Default(kind string) string {
+	for _, u := range s.Unlocks {
+		if u.Kind == kind && u.Cost == 0 {
+			return u.ID
+		}
+	}
+	return ""
+}
+
+// Loadout — выбранные оружие и ульта; не куплено или пропало
+// из магазина — начальные
+func (p *Profile) Loadout(s *Shop) (weapon, ult string) {
+	pick := func(kind, id string) string {
+		if u := s.Unlock(kind, id); u != nil && p.Owns(u) {
+			return id
+		}
+		return s.Default(kind)
+	}
+	return pick(UnlockWeapon, p.Weapon), pick(UnlockUlt, p.Ult)
+}
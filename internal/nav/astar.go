@@ -0,0 +1,193 @@
+package nav
+
+// Стоимости шагов: прямо и по диагонали (≈10·√2)
+const (
+	costStraight = 10
+	costDiagonal = 14
+)
+
+// соседи: сначала 4 прямых, потом 4 диагонали
+var dirs = [8][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+
+// step — можно ли из клетки шагнуть в направлении d; по диагонали —
+// только если обе прилежащие прямые клетки свободны (углы не срезаем)
+func (g *Grid) step(cx, cy int, d [2]int) bool {
+	if g.Blocked(cx+d[0], cy+d[1]) {
+		return false
+	}
+	if d[0] != 0 && d[1] != 0 {
+		return !g.Blocked(cx+d[0], cy) && !g.Blocked(cx, cy+d[1])
+	}
+	return true
+}
+
+func stepCost(d [2]int) int32 {
+	if d[0] != 0 && d[1] != 0 {
+		return costDiagonal
+	}
+	return costStraight
+}
+
+// octile — допустимая эвристика для 8 направлений
+func octile(ax, ay, bx, by int) int32 {
+	dx, dy := int32(abs(ax-bx)), int32(abs(ay-by))
+	return costStraight*(dx+dy) + (costDiagonal-2*costStraight)*min(dx, dy)
+}
+
+// search — переиспользуемые буферы A*. Вместо очистки массивов между
+// запросами у каждой клетки отметка поколения: чужое поколение — «не видели».
+type search struct {
+	cost   []int32
+	parent []int32
+	seen   []uint32
+	closed []uint32
+	gen    uint32
+	open   heap
+}
+
+func (s *search) reset(n int) {
+	if len(s.cost) != n {
+		*s = search{
+			cost:   make([]int32, n),
+			parent: make([]int32, n),
+			seen:   make([]uint32, n),
+			closed: make([]uint32, n),
+		}
+	}
+	s.gen++
+	if s.gen == 0 { // переполнение — честно чистим
+		clear(s.seen)
+		clear(s.closed)
+		s.gen = 1
+	}
+	s.open = s.open[:0]
+}
+
+// Path — кратчайший путь A* из a в b по 8 направлениям без срезания углов.
+// Точки — центры клеток, сглаженные по прямой видимости; последняя — сама b.
+// Если a или b в стене, берётся ближайшая свободная клетка. nil — пути нет.
+func (g *Grid) Path(ax, ay, bx, by float32) []Point {
+	ax, ay, ok := g.Nearest(ax, ay)
+	if !ok {
+		return nil
+	}
+	bx, by, ok = g.Nearest(bx, by)
+	if !ok {
+		return nil
+	}
+	sx, sy := g.CellAt(ax, ay)
+	tx, ty := g.CellAt(bx, by)
+	if sx == tx && sy == ty {
+		return []Point{{bx, by}}
+	}
+
+	s := &g.search
+	s.reset(g.W * g.H)
+	start, goal := int32(sy*g.W+sx), int32(ty*g.W+tx)
+	s.cost[start], s.parent[start], s.seen[start] = 0, -1, s.gen
+	s.open.push(start, octile(sx, sy, tx, ty))
+
+	for len(s.open) > 0 {
+		cur, _ := s.open.pop()
+		if s.closed[cur] == s.gen {
+			continue // устаревшая запись в куче
+		}
+		if cur == goal {
+			return g.smooth(ax, ay, g.trace(goal), bx, by)
+		}
+		s.closed[cur] = s.gen
+		cx, cy := int(cur)%g.W, int(cur)/g.W
+		for _, d := range dirs {
+			if !g.step(cx, cy, d) {
+				continue
+			}
+			nx, ny := cx+d[0], cy+d[1]
+			n := int32(ny*g.W + nx)
+			if s.closed[n] == s.gen {
+				continue
+			}
+			c := s.cost[cur] + stepCost(d)
+			if s.seen[n] == s.gen && c >= s.cost[n] {
+				continue
+			}
+			s.cost[n], s.parent[n], s.seen[n] = c, cur, s.gen
+			s.open.push(n, c+octile(nx, ny, tx, ty))
+		}
+	}
+	return nil
+}
+
+// trace — центры клеток от старта (не включая) до цели по цепочке родителей
+func (g *Grid) trace(goal int32) []Point {
+	var rev []Point
+	for i := goal; g.search.parent[i] >= 0; i = g.search.parent[i] {
+		x, y := g.Center(int(i)%g.W, int(i)/g.W)
+		rev = append(rev, Point{x, y})
+	}
+	for l, r := 0, len(rev)-1; l < r; l, r = l+1, r-1 {
+		rev[l], rev[r] = rev[r], rev[l]
+	}
+	return rev
+}
+
+// smooth выбрасывает промежуточные точки, видимые напрямую («натягивание нити»)
+func (g *Grid) smooth(ax, ay float32, pts []Point, bx, by float32) []Point {
+	pts[len(pts)-1] = Point{bx, by} // в клетке цели идём в саму точку
+	out := pts[:0]
+	from := Point{ax, ay}
+	for i := 0; i < len(pts); {
+		j := i
+		for j+1 < len(pts) && g.Clear(from.X, from.Y, pts[j+1].X, pts[j+1].Y) {
+			j++
+		}
+		out = append(out, pts[j])
+		from = pts[j]
+		i = j + 1
+	}
+	return out
+}
+
+// heap — двоичная min-куча (клетка, приоритет)
+type heap []heapItem
+
+type heapItem struct {
+	cell int32
+	prio int32
+}
+
+func (h *heap) push(cell, prio int32) {
+	*h = append(*h, heapItem{cell, prio})
+	a := *h
+	for i := len(a) - 1; i > 0; {
+		p := (i - 1) / 2
+		if a[p].prio <= a[i].prio {
+			break
+		}
+		a[p], a[i] = a[i], a[p]
+		i = p
+	}
+}
+
+func (h *heap) pop() (cell, prio int32) {
+	a := *h
+	top := a[0]
+	last := len(a) - 1
+	a[0] = a[last]
+	a = a[:last]
+	for i := 0; ; {
+		l, r, m := 2*i+1, 2*i+2, i
+		if l < len(a) && a[l].prio < a[m].prio {
+			m = l
+		}
+		if r < len(a) && a[r].prio < a[m].prio {
+			m = r
+		}
+		if m == i {
+			break
+		}
+		a[m], a[i] = a[i], a[m]
+		i = m
+	}
+	*h = a
+	return top.cell, top.prio
+}
@@ -0,0 +1,177 @@
+package nav
+
+import "math"
+
+// unreachable — расстояние клеток, до которых от цели не дойти
+const unreachable = math.MaxInt32
+
+// Field — поле потока к одной цели: для каждой клетки — стоимость пути до
+// цели (Дейкстра от цели). Агенту достаточно шагнуть к соседу с меньшей
+// стоимостью, поэтому одно поле обслуживает сколько угодно врагов.
+type Field struct {
+	g      *Grid
+	dist   []int32
+	open   buckets
+	target Point
+	tx, ty int
+	built  uint64 // версия сетки, по которой строили
+	valid  bool
+}
+
+func NewField(g *Grid) *Field {
+	return &Field{g: g, dist: make([]int32, g.W*g.H)}
+}
+
+// Update ставит цель в точку (x, y). Поле пересчитывается, только если цель
+// сменила клетку или сетка изменилась; true — пересчитали.
+func (f *Field) Update(x, y float32) bool {
+	f.target = Point{x, y}
+	tx, ty := f.g.CellAt(x, y)
+	if f.valid && tx == f.tx && ty == f.ty && f.built == f.g.version {
+		return false
+	}
+	f.tx, f.ty = tx, ty
+	f.build()
+	return true
+}
+
+// Target — текущая цель поля
+func (f *Field) Target() Point { return f.target }
+
+func (f *Field) build() {
+	g := f.g
+	f.built, f.valid = g.version, true
+	for i := range f.dist {
+		f.dist[i] = unreachable
+	}
+	// цель в стене (прижата к препятствию) — считаем от ближайшей свободной
+	tx, ty := f.tx, f.ty
+	if g.Blocked(tx, ty) {
+		x, y, ok := g.Nearest(g.Center(tx, ty))
+		if !ok {
+			return
+		}
+		tx, ty = g.CellAt(x, y)
+	}
+	if !g.inside(tx, ty) {
+		return
+	}
+	t := int32(ty*g.W + tx)
+	f.dist[t] = 0
+	f.open.reset()
+	f.open.push(t, 0)
+	for {
+		cur, d, ok := f.open.pop()
+		if !ok {
+			break
+		}
+		if d > f.dist[cur] {
+			continue
+		}
+		cx, cy := int(cur)%g.W, int(cur)/g.W
+		for _, dir := range dirs {
+			// шаги симметричны, так что «из соседа к нам» = «от нас к соседу»
+			if !g.step(cx, cy, dir) {
+				continue
+			}
+			n := int32((cy+dir[1])*g.W + cx + dir[0])
+			if nd := d + stepCost(dir); nd < f.dist[n] {
+				f.dist[n] = nd
+				f.open.push(n, nd)
+			}
+		}
+	}
+}
+
+// Dist — стоимость пути от точки до цели (в «шагах» по 10 на клетку);
+// false — недостижимо
+func (f *Field) Dist(x, y float32) (int32, bool) {
+	cx, cy := f.g.CellAt(x, y)
+	if !f.valid || !f.g.inside(cx, cy) || f.dist[cy*f.g.W+cx] == unreachable {
+		return 0, false
+	}
+	return f.dist[cy*f.g.W+cx], true
+}
+
+// Dir — нормализованное направление, куда идти из точки (x, y) к цели.
+// false — отсюда до цели не дойти (или поле ещё не строилось).
+func (f *Field) Dir(x, y float32) (dx, dy float32, ok bool) {
+	if !f.valid {
+		return 0, 0, false
+	}
+	g := f.g
+	cx, cy := g.CellAt(x, y)
+	here := int32(unreachable)
+	if g.inside(cx, cy) {
+		here = f.dist[cy*g.W+cx]
+	}
+	if here == 0 {
+		return toward(x, y, f.target.X, f.target.Y)
+	}
+	// к соседу с наименьшей стоимостью; из стены (куда затолкали) — в любой свободный
+	best, bx, by := here, 0, 0
+	for _, d := range dirs {
+		nx, ny := cx+d[0], cy+d[1]
+		if !g.inside(nx, ny) {
+			continue
+		}
+		if !g.Blocked(cx, cy) && !g.step(cx, cy, d) {
+			continue
+		}
+		if v := f.dist[ny*g.W+nx]; v < best {
+			best, bx, by = v, nx, ny
+		}
+	}
+	if best == here {
+		return 0, 0, false
+	}
+	px, py := g.Center(bx, by)
+	return toward(x, y, px, py)
+}
+
+func toward(x, y, tx, ty float32) (float32, float32, bool) {
+	dx, dy := tx-x, ty-y
+	l := float32(math.Hypot(float64(dx), float64(dy)))
+	if l < 1e-3 {
+		return 0, 0, true
+	}
+	return dx / l, dy / l, true
+}
+
+// buckets — очередь Дейкстры с ведрами (алгоритм Дайала): шаг стоит не больше
+// costDiagonal, поэтому живые приоритеты укладываются в кольцо из 16 вёдер.
+// Заметно быстрее кучи на больших сетках.
+type buckets struct {
+	ring [16][]int32
+	at   int32 // текущий приоритет
+	n    int
+}
+
+func (b *buckets) reset() {
+	for i := range b.ring {
+		b.ring[i] = b.ring[i][:0]
+	}
+	b.at, b.n = 0, 0
+}
+
+func (b *buckets) push(cell, prio int32) {
+	k := prio & 15
+	b.ring[k] = append(b.ring[k], cell)
+	b.n++
+}
+
+func (b *buckets) pop() (cell, prio int32, ok bool) {
+	if b.n == 0 {
+		return 0, 0, false
+	}
+	for {
+		k := b.at & 15
+		if q := b.ring[k]; len(q) > 0 {
+			cell = q[len(q)-1]
+			b.ring[k] = q[:len(q)-1]
+			b.n--
+			return cell, b.at, true
+		}
+		b.at++
+	}
+}
@@ -0,0 +1,223 @@
+// Package nav — поиск пути по сетке проходимости: A* для одиночных запросов
+// и общее поле потока (flow field) к одной цели для толпы врагов.
+//
+// Пакет не знает про raylib и entities: мир описывается прямоугольниками
+// препятствий в мировых единицах.
+package nav
+
+import "math"
+
+// Rect — препятствие в мировых единицах
+type Rect struct{ X, Y, W, H float32 }
+
+// Point — точка в мировых единицах
+type Point struct{ X, Y float32 }
+
+// Grid — сетка проходимости поверх мира. Клетка непроходима, если её
+// накрывает хотя бы одно препятствие; препятствия считаются по штукам,
+// так что снять одно из двух перекрывающихся можно без пересборки.
+type Grid struct {
+	W, H   int     // размер в клетках
+	Size   float32 // сторона клетки в мировых единицах
+	OX, OY float32 // мировые координаты левого верхнего угла (окно в бесконечном мире)
+
+	solid   []uint16
+	version uint64 // растёт при каждом изменении — по нему поля видят, что устарели
+
+	search search // буферы A*, чтобы не выделять память на каждый запрос
+}
+
+// NewGrid — пустая (всюду проходимая) сетка на мир worldW×worldH
+func NewGrid(worldW, worldH, cell float32) *Grid {
+	return NewGridAt(0, 0, worldW, worldH, cell)
+}
+
+// NewGridAt — сетка на прямоугольник мира с левым верхним углом (x, y)
+func NewGridAt(x, y, w, h, cell float32) *Grid {
+	cw := max(int(math.Ceil(float64(w/cell))), 1)
+	ch := max(int(math.Ceil(float64(h/cell))), 1)
+	return &Grid{W: cw, H: ch, Size: cell, OX: x, OY: y, solid: make([]uint16, cw*ch)}
+}
+
+// Block добавляет препятствие; меняются только клетки под ним
+func (g *Grid) Block(r Rect) { g.mark(r, 1) }
+
+// Unblock снимает ранее добавленное препятствие
+func (g *Grid) Unblock(r Rect) { g.mark(r, -1) }
+
+func (g *Grid) mark(r Rect, d int) {
+	x0, y0, x1, y1, ok := g.cover(r)
+	if !ok {
+		return
+	}
+	for cy := y0; cy <= y1; cy++ {
+		for cx := x0; cx <= x1; cx++ {
+			i := cy*g.W + cx
+			switch {
+			case d > 0 && g.solid[i] < math.MaxUint16:
+				g.solid[i]++
+			case d < 0 && g.solid[i] > 0:
+				g.solid[i]--
+			}
+		}
+	}
+	g.version++
+}
+
+// cover — клетки, которые задевает прямоугольник (включительно)
+func (g *Grid) cover(r Rect) (x0, y0, x1, y1 int, ok bool) {
+	if r.W <= 0 || r.H <= 0 {
+		return
+	}
+	x, y := r.X-g.OX, r.Y-g.OY
+	x0 = max(int(math.Floor(float64(x/g.Size))), 0)
+	y0 = max(int(math.Floor(float64(y/g.Size))), 0)
+	x1 = min(int(math.Ceil(float64((x+r.W)/g.Size)))-1, g.W-1)
+	y1 = min(int(math.Ceil(float64((y+r.H)/g.Size)))-1, g.H-1)
+	return x0, y0, x1, y1, x0 <= x1 && y0 <= y1
+}
+
+// Version меняется при каждом Block/Unblock
+func (g *Grid) Version() uint64 { return g.version }
+
+// CellAt — клетка, в которой лежит точка (может быть за пределами сетки)
+func (g *Grid) CellAt(x, y float32) (cx, cy int) {
+	return int(math.Floor(float64((x - g.OX) / g.Size))), int(math.Floor(float64((y - g.OY) / g.Size)))
+}
+
+// Center — центр клетки в мировых единицах
+func (g *Grid) Center(cx, cy int) (x, y float32) {
+	return g.OX + (float32(cx)+0.5)*g.Size, g.OY + (float32(cy)+0.5)*g.Size
+}
+
+func (g *Grid) inside(cx, cy int) bool { return cx >= 0 && cy >= 0 && cx < g.W && cy < g.H }
+
+// Blocked — клетка непроходима; всё за краем сетки тоже
+func (g *Grid) Blocked(cx, cy int) bool {
+	return !g.inside(cx, cy) || g.solid[cy*g.W+cx] > 0
+}
+
+// Contains — точка внутри окна сетки
+func (g *Grid) Contains(x, y float32) bool { return g.inside(g.CellAt(x, y)) }
+
+// Walkable — точка мира в проходимой клетке
+func (g *Grid) Walkable(x, y float32) bool { return !g.Blocked(g.CellAt(x, y)) }
+
+// Nearest — ближайшая к точке проходимая клетка (поиск кольцами); сама точка,
+// если она уже проходима. false — вся сетка забита.
+func (g *Grid) Nearest(x, y float32) (float32, float32, bool) {
+	if g.Walkable(x, y) {
+		return x, y, true
+	}
+	cx, cy := g.CellAt(x, y)
+	for r := 1; r < max(g.W, g.H); r++ {
+		best, bx, by := float32(math.MaxFloat32), 0, 0
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if max(abs(dx), abs(dy)) != r || g.Blocked(cx+dx, cy+dy) {
+					continue
+				}
+				px, py := g.Center(cx+dx, cy+dy)
+				if d := (px-x)*(px-x) + (py-y)*(py-y); d < best {
+					best, bx, by = d, cx+dx, cy+dy
+				}
+			}
+		}
+		if best < math.MaxFloat32 {
+			px, py := g.Center(bx, by)
+			return px, py, true
+		}
+	}
+	return x, y, false
+}
+
+// Slide — шаг из (x0,y0) в (x1,y1) с упором в препятствия: если целиком
+// нельзя, пробуем по каждой оси отдельно (скольжение вдоль стены)
+func (g *Grid) Slide(x0, y0, x1, y1 float32) (float32, float32) {
+	switch {
+	case g.Walkable(x1, y1):
+		return x1, y1
+	case g.Walkable(x1, y0):
+		return x1, y0
+	case g.Walkable(x0, y1):
+		return x0, y1
+	}
+	return x0, y0
+}
+
+// Clear — отрезок от a до b не задевает непроходимых клеток
+// (обход клеток по Amanatides–Woo)
+func (g *Grid) Clear(ax, ay, bx, by float32) bool {
+	cx, cy := g.CellAt(ax, ay)
+	ex, ey := g.CellAt(bx, by)
+	if g.Blocked(cx, cy) {
+		return false
+	}
+	dx, dy := bx-ax, by-ay
+	stepX, stepY := sign(dx), sign(dy)
+	tMaxX, tDeltaX := boundary(ax-g.OX, dx, g.Size, cx)
+	tMaxY, tDeltaY := boundary(ay-g.OY, dy, g.Size, cy)
+	for cx != ex || cy != ey {
+		switch {
+		case tMaxX < tMaxY:
+			cx += stepX
+			tMaxX += tDeltaX
+		case tMaxY < tMaxX:
+			cy += stepY
+			tMaxY += tDeltaY
+		default:
+			// ровно через угол: обе соседние клетки должны быть свободны
+			if g.Blocked(cx+stepX, cy) || g.Blocked(cx, cy+stepY) {
+				return false
+			}
+			cx += stepX
+			cy += stepY
+			tMaxX += tDeltaX
+			tMaxY += tDeltaY
+		}
+		if g.Blocked(cx, cy) {
+			return false
+		}
+		if tMaxX > 1 && tMaxY > 1 {
+			break
+		}
+	}
+	return true
+}
+
+// boundary — параметр t (0..1 вдоль отрезка) до первой границы клетки по оси
+// и шаг t между границами
+func boundary(a, d, size float32, c int) (tMax, tDelta float32) {
+	if d == 0 {
+		return math.MaxFloat32, math.MaxFloat32
+	}
+	next := float32(c) * size
+	if d > 0 {
+		next += size
+	}
+	return (next - a) / d, abs32(size / d)
+}
+
+func sign(v float32) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
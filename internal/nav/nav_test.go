@@ -0,0 +1,212 @@
+package nav
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+const cell = 10
+
+// maze строит сетку из рисунка: '#' — стена, 'S' — старт, 'G' — цель
+func maze(t testing.TB, rows ...string) (g *Grid, s, goal Point) {
+	t.Helper()
+	g = NewGrid(float32(len(rows[0])*cell), float32(len(rows)*cell), cell)
+	for y, row := range rows {
+		for x, c := range row {
+			cx, cy := g.Center(x, y)
+			switch c {
+			case '#':
+				g.Block(Rect{float32(x * cell), float32(y * cell), cell, cell})
+			case 'S':
+				s = Point{cx, cy}
+			case 'G':
+				goal = Point{cx, cy}
+			}
+		}
+	}
+	return g, s, goal
+}
+
+func length(from Point, pts []Point) float32 {
+	var l float64
+	for _, p := range pts {
+		l += math.Hypot(float64(p.X-from.X), float64(p.Y-from.Y))
+		from = p
+	}
+	return float32(l)
+}
+
+// путь проходим: каждый отрезок сглаженного пути не задевает стен
+func checkPath(t *testing.T, g *Grid, s, goal Point, path []Point) {
+	t.Helper()
+	if len(path) == 0 {
+		t.Fatal("пути нет")
+	}
+	if last := path[len(path)-1]; last != goal {
+		t.Fatalf("путь кончается в %v, want %v", last, goal)
+	}
+	from := s
+	for i, p := range path {
+		if !g.Clear(from.X, from.Y, p.X, p.Y) {
+			t.Fatalf("отрезок %d %v→%v идёт сквозь стену", i, from, p)
+		}
+		from = p
+	}
+}
+
+var labyrinth = []string{
+	"S.#.......",
+	".##.#####.",
+	"....#...#.",
+	"###.#.#.#.",
+	"....#.#...",
+	".####.###.",
+	"......#..G",
+	"#####.##..",
+}
+
+func TestPathMaze(t *testing.T) {
+	g, s, goal := maze(t, labyrinth...)
+	path := g.Path(s.X, s.Y, goal.X, goal.Y)
+	checkPath(t, g, s, goal, path)
+
+	// A* и поле — оба кратчайшие: сглаженный путь не длиннее пути по клеткам
+	f := NewField(g)
+	f.Update(goal.X, goal.Y)
+	d, ok := f.Dist(s.X, s.Y)
+	if !ok {
+		t.Fatal("поле: старт недостижим")
+	}
+	if l := length(s, path); l > float32(d)+1e-3 || l < float32(math.Hypot(float64(goal.X-s.X), float64(goal.Y-s.Y))) {
+		t.Fatalf("длина пути %.1f, стоимость по полю %d", l, d)
+	}
+	// поворотов в этом лабиринте много — путь не может быть прямой
+	if len(path) < 4 {
+		t.Fatalf("в пути %d точек: %v", len(path), path)
+	}
+}
+
+func TestPathStraight(t *testing.T) {
+	g, s, goal := maze(t,
+		"S........G",
+	)
+	path := g.Path(s.X, s.Y, goal.X, goal.Y)
+	if len(path) != 1 || path[0] != goal {
+		t.Fatalf("на открытом поле путь %v, want сразу в цель", path)
+	}
+}
+
+// по диагонали между двумя стенами не протиснуться
+func TestNoCornerCutting(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		rows []string
+		cost int32 // -1 — недостижимо
+	}{
+		{"открытая диагональ", []string{"S.", ".G"}, costDiagonal},
+		{"одна стена у угла", []string{"S#", ".G"}, 2 * costStraight},
+		{"другая стена у угла", []string{"S.", "#G"}, 2 * costStraight},
+		{"обе стены — щели нет", []string{"S#", "#G"}, -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, s, goal := maze(t, tc.rows...)
+			f := NewField(g)
+			f.Update(goal.X, goal.Y)
+			d, ok := f.Dist(s.X, s.Y)
+			path := g.Path(s.X, s.Y, goal.X, goal.Y)
+			if tc.cost < 0 {
+				if ok || path != nil {
+					t.Fatalf("прошли сквозь угол: стоимость %d, путь %v", d, path)
+				}
+				return
+			}
+			if !ok || d != tc.cost {
+				t.Fatalf("стоимость %d (ok %v), want %d", d, ok, tc.cost)
+			}
+			checkPath(t, g, s, goal, path)
+		})
+	}
+}
+
+func TestUnreachableGoal(t *testing.T) {
+	g, s, goal := maze(t,
+		"S.....",
+		"...###",
+		"...#G#",
+		"...###",
+	)
+	if path := g.Path(s.X, s.Y, goal.X, goal.Y); path != nil {
+		t.Fatalf("путь в замурованную клетку: %v", path)
+	}
+	f := NewField(g)
+	f.Update(goal.X, goal.Y)
+	if _, ok := f.Dist(s.X, s.Y); ok {
+		t.Fatal("поле: замурованная цель достижима")
+	}
+	if _, _, ok := f.Dir(s.X, s.Y); ok {
+		t.Fatal("поле: есть направление к недостижимой цели")
+	}
+	// снимаем стену — путь появляется, поле перестраивается
+	g.Unblock(Rect{3 * cell, 2 * cell, cell, cell})
+	if !f.Update(goal.X, goal.Y) {
+		t.Fatal("поле не заметило изменение сетки")
+	}
+	if _, ok := f.Dist(s.X, s.Y); !ok {
+		t.Fatal("после Unblock цель всё ещё недостижима")
+	}
+	checkPath(t, g, s, goal, g.Path(s.X, s.Y, goal.X, goal.Y))
+}
+
+// агент, идущий по полю, доходит до цели и не заходит в стены
+func TestFieldWalk(t *testing.T) {
+	g, s, goal := maze(t, labyrinth...)
+	f := NewField(g)
+	if !f.Update(goal.X, goal.Y) || f.Update(goal.X+1, goal.Y+1) {
+		t.Fatal("перестройка: первая — да, в той же клетке — нет")
+	}
+	p := s
+	const speed = 2
+	for i := 0; i < 1000; i++ {
+		if math.Hypot(float64(p.X-goal.X), float64(p.Y-goal.Y)) < speed {
+			return
+		}
+		dx, dy, ok := f.Dir(p.X, p.Y)
+		if !ok {
+			t.Fatalf("шаг %d: нет направления из %v", i, p)
+		}
+		p = Point{p.X + dx*speed, p.Y + dy*speed}
+		if !g.Walkable(p.X, p.Y) {
+			t.Fatalf("шаг %d: агент в стене %v", i, p)
+		}
+	}
+	t.Fatalf("за 1000 шагов не дошли: %v", p)
+}
+
+func BenchmarkFlowField256(b *testing.B) {
+	const n = 256
+	rows := make([]string, n)
+	rng := rand.New(rand.NewSource(1))
+	for y := range rows {
+		var sb strings.Builder
+		for x := 0; x < n; x++ {
+			if rng.Float32() < 0.2 {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		rows[y] = sb.String()
+	}
+	g, _, _ := maze(b, rows...)
+	f := NewField(g)
+	// цель прыгает между клетками — поле строится заново каждый раз
+	targets := [2]Point{{5, 5}, {n*cell - 5, n*cell - 5}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t := targets[i&1]
+		f.Update(t.X, t.Y)
+	}
+}
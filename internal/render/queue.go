@@ -0,0 +1,78 @@
+package render
+
+import (
+	"math"
+	"sort"
+)
+
+// Layer — слой отрисовки; внутри слоя порядок задаёт ключ (обычно Y ног)
+type Layer int
+
+const (
+	LayerGround      Layer = iota // пол, трупы, телеграфы
+	LayerShadows                  // тени под персонажами
+	LayerActors                   // персонажи, сортировка по Y ног
+	LayerProjectiles              // снаряды
+	LayerOverhead                 // эффекты поверх всех
+	LayerScreen                   // экранный UI (вне BeginMode2D)
+)
+
+// Back — ключ «в самый низ слоя» (фон, карта)
+var Back = float32(math.Inf(-1))
+
+type item struct {
+	layer Layer
+	key   float32
+	seq   int
+	draw  func()
+}
+
+// Queue собирает всё, что нужно нарисовать за кадр, и рисует одним проходом.
+// Сама очередь ничего не знает о raylib — рисуют переданные функции.
+type Queue struct {
+	items []item
+}
+
+// Submit добавляет отрисовку в слой; при равных ключах сохраняется порядок вызовов
+func (q *Queue) Submit(layer Layer, key float32, draw func()) {
+	q.items = append(q.items, item{layer: layer, key: key, seq: len(q.items), draw: draw})
+}
+
+func (q *Queue) Len() int { return len(q.items) }
+
+func (q *Queue) Reset() { q.items = q.items[:0] }
+
+// Sort упорядочивает по слою, ключу и порядку добавления
+func (q *Queue) Sort() {
+	sort.Slice(q.items, func(i, j int) bool {
+		a, b := q.items[i], q.items[j]
+		if a.layer != b.layer {
+			return a.layer < b.layer
+		}
+		if a.key != b.key {
+			return a.key < b.key
+		}
+		return a.seq < b.seq
+	})
+}
+
+// Flush сортирует и рисует очередь, затем очищает её.
+// toScreen вызывается ровно один раз — перед первым экранным слоем
+// (или в конце, если его нет): там вызывающий закрывает BeginMode2D.
+func (q *Queue) Flush(toScreen func()) {
+	q.Sort()
+	switched := false
+	for _, it := range q.items {
+		if !switched && it.layer >= LayerScreen {
+			switched = true
+			if toScreen != nil {
+				toScreen()
+			}
+		}
+		it.draw()
+	}
+	if !switched && toScreen != nil {
+		toScreen()
+	}
+	q.Reset()
+}
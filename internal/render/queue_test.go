@@ -0,0 +1,98 @@
+package render
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// record — отрисовка, которая пишет своё имя в лог
+func record(log *[]string, name string) func() {
+	return func() { *log = append(*log, name) }
+}
+
+func TestFlushOrder(t *testing.T) {
+	var q Queue
+	var log []string
+	// вперемешку по слоям, ключам и порядку вызовов
+	q.Submit(LayerScreen, 0, record(&log, "hud"))
+	q.Submit(LayerActors, 200, record(&log, "enemy@200"))
+	q.Submit(LayerProjectiles, 10, record(&log, "shot"))
+	q.Submit(LayerActors, 100, record(&log, "player@100"))
+	q.Submit(LayerGround, Back, record(&log, "map"))
+	q.Submit(LayerShadows, 200, record(&log, "shadow@200"))
+	q.Submit(LayerGround, 150, record(&log, "corpse"))
+	q.Submit(LayerOverhead, -50, record(&log, "fx"))
+	q.Submit(LayerShadows, 100, record(&log, "shadow@100"))
+
+	q.Flush(record(&log, "|screen|"))
+
+	want := "map corpse shadow@100 shadow@200 player@100 enemy@200 shot fx |screen| hud"
+	if got := strings.Join(log, " "); got != want {
+		t.Fatalf("порядок:\n got  %s\n want %s", got, want)
+	}
+	if q.Len() != 0 {
+		t.Fatalf("после Flush в очереди %d", q.Len())
+	}
+}
+
+// равные ключи рисуются в порядке Submit при любом окружении
+func TestFlushStable(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var q Queue
+	var log []string
+	var want []string
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprint("same", i)
+		q.Submit(LayerActors, 100, record(&log, name))
+		want = append(want, name)
+		// шум вокруг: другие ключи и слои
+		q.Submit(LayerActors, 100+1+rng.Float32()*100, func() {})
+		q.Submit(Layer(rng.Intn(int(LayerScreen))), rng.Float32()*200, func() {})
+	}
+	q.Flush(nil)
+	if got, w := strings.Join(log, " "), strings.Join(want, " "); got != w {
+		t.Fatalf("равные ключи переставлены:\n got  %s\n want %s", got, w)
+	}
+}
+
+func TestToScreenOnce(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		layers []Layer
+		want   string
+	}{
+		{"без экранного слоя — в конце", []Layer{LayerGround, LayerActors}, "0 1 |screen|"},
+		{"перед первым экранным", []Layer{LayerScreen, LayerActors, LayerScreen}, "1 |screen| 0 2"},
+		{"пустая очередь", nil, "|screen|"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var q Queue
+			var log []string
+			for i, l := range tc.layers {
+				q.Submit(l, 0, record(&log, fmt.Sprint(i)))
+			}
+			q.Flush(record(&log, "|screen|"))
+			if got := strings.Join(log, " "); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// очередь переиспользуется между кадрами: номера Submit начинаются заново
+func TestReuseAfterFlush(t *testing.T) {
+	var q Queue
+	var log []string
+	for i := 0; i < 10; i++ {
+		q.Submit(LayerActors, 0, func() {})
+	}
+	q.Flush(nil)
+	q.Submit(LayerActors, 5, record(&log, "a"))
+	q.Submit(LayerActors, 5, record(&log, "b"))
+	q.Flush(nil)
+	if got := strings.Join(log, " "); got != "a b" {
+		t.Fatalf("got %q, want \"a b\"", got)
+	}
+}
@@ -0,0 +1,124 @@
+// Package stats — статистика одного забега: копится по событиям игры,
+// показывается на экране итогов и выгружается в JSON для разбора баланса
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Run — статистика забега. Урон — по источникам: "shot", "ult", "bomb",
+// "barrel", "spikes", "explosion" или вид врага.
+type Run struct {
+	Level   string    `json:"level"`
+	Started time.Time `json:"started"`
+	Time    float32   `json:"time"` // секунд продержался
+	Won     bool      `json:"won"`
+	Biome   string    `json:"biome,omitempty"` // сгенерированная карта: биом и сид
+	Seed    int64     `json:"seed,omitempty"`
+
+	Kills  map[string]int `json:"kills"` // по виду врага
+	Elites int            `json:"elites"`
+	Dealt  map[string]int `json:"damage_dealt"`
+	Taken  map[string]int `json:"damage_taken"`
+
+	SoulsCollected int `json:"souls_collected"`
+	SoulsExpired   int `json:"souls_expired"` // исчезли, так и не подобранные
+	Gold           int `json:"gold"`
+
+	CrookThrows int     `json:"crook_throws"`
+	CrookHits   int     `json:"crook_hits"`
+	Ults        int     `json:"ults"`
+	ShotsFired  int     `json:"shots_fired"`
+	ShotsHit    int     `json:"shots_hit"`
+	Accuracy    float32 `json:"accuracy"` // ShotsHit / ShotsFired, считает Finish
+}
+
+func New(level string) *Run {
+	return &Run{
+		Level:   level,
+		Started: time.Now(),
+		Kills:   map[string]int{},
+		Dealt:   map[string]int{},
+		Taken:   map[string]int{},
+	}
+}
+
+// Kill — убит враг вида kind
+func (r *Run) Kill(kind string, elite bool) {
+	r.Kills[kind]++
+	if elite {
+		r.Elites++
+	}
+}
+
+// Deal — врагам нанесено dmg урона из источника src (0 и меньше не считаются)
+func (r *Run) Deal(src string, dmg int) {
+	if dmg > 0 {
+		r.Dealt[src] += dmg
+	}
+}
+
+// Take — игрок получил dmg урона из источника src
+func (r *Run) Take(src string, dmg int) {
+	if dmg > 0 {
+		r.Taken[src] += dmg
+	}
+}
+
+// Finish закрывает забег: время, итог и точность
+func (r *Run) Finish(t float32, won bool) {
+	r.Time, r.Won = t, won
+	r.Accuracy = 0
+	if r.ShotsFired > 0 {
+		r.Accuracy = float32(r.ShotsHit) / float32(r.ShotsFired)
+	}
+}
+
+// Total — сумма по словарю (убийства, урон)
+func Total(m map[string]int) int {
+	n := 0
+	for _, v := range m {
+		n += v
+	}
+	return n
+}
+
+// Entry — строка разбивки
+type Entry struct {
+	Key string
+	N   int
+}
+
+// Sorted — разбивка по убыванию, при равенстве — по имени
+func Sorted(m map[string]int) []Entry {
+	out := make([]Entry, 0, len(m))
+	for k, v := range m {
+		out = append(out, Entry{k, v})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].N != out[j].N {
+			return out[i].N > out[j].N
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// Export пишет забег в dir/run-<дата>-<время>-<уровень>.json и возвращает путь
+func Export(dir string, r *Run) (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("run-%s-%s.json", r.Started.Format("20060102-150405"), r.Level)
+	path := filepath.Join(dir, name)
+	return path, os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFinishAccuracy(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		fired     int
+		hit       int
+		want      float32
+		timeAlive float32
+	}{
+		{"не стрелял — ноль, не NaN", 0, 0, 0, 12},
+		{"все мимо", 8, 0, 0, 30},
+		{"каждый второй", 10, 5, 0.5, 45.5},
+		{"все в цель", 3, 3, 1, 60},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New("test")
+			r.ShotsFired, r.ShotsHit = tc.fired, tc.hit
+			r.Accuracy = 0.9 // старое значение не должно пережить Finish
+			r.Finish(tc.timeAlive, true)
+			if r.Accuracy != tc.want {
+				t.Fatalf("точность %.3f, want %.3f", r.Accuracy, tc.want)
+			}
+			if r.Time != tc.timeAlive || !r.Won {
+				t.Fatalf("время %.1f, победа %v", r.Time, r.Won)
+			}
+		})
+	}
+}
+
+// Deal и Take не копят нули и отрицательный урон (удар целиком ушёл в броню)
+func TestDealTake(t *testing.T) {
+	r := New("test")
+	r.Deal("shot", 5)
+	r.Deal("shot", 0)
+	r.Deal("ult", -3)
+	r.Take("melee", 7)
+	r.Take("spikes", 0)
+	if !reflect.DeepEqual(r.Dealt, map[string]int{"shot": 5}) || !reflect.DeepEqual(r.Taken, map[string]int{"melee": 7}) {
+		t.Fatalf("нанесено %v, получено %v", r.Dealt, r.Taken)
+	}
+}
+
+// Sorted: по убыванию, равные — по имени, чтобы экран итогов не прыгал
+func TestSorted(t *testing.T) {
+	got := Sorted(map[string]int{"shot": 40, "ult": 90, "bomb": 40, "barrel": 40, "spikes": 5})
+	want := []Entry{{"ult", 90}, {"barrel", 40}, {"bomb", 40}, {"shot", 40}, {"spikes", 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Sorted = %v, want %v", got, want)
+	}
+	if got := Sorted(nil); len(got) != 0 {
+		t.Fatalf("пустая разбивка: %v", got)
+	}
+	if Total(map[string]int{"a": 2, "b": 3}) != 5 {
+		t.Fatal("Total не сошёлся")
+	}
+}
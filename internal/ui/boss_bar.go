@@ -0,0 +1,81 @@
+package ui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// BossBar — полоска здоровья босса вверху экрана: имя, пороги фаз и «пипсы» фаз
+type BossBar struct {
+	Font   rl.Font
+	Y      float32
+	Width  float32 // доля ширины экрана
+	Height float32
+
+	trail float32 // отстающая «белая» часть полоски
+}
+
+func NewBossBar(font rl.Font) *BossBar {
+	return &BossBar{Font: font, Y: 8, Width: 0.5, Height: 8, trail: 1}
+}
+
+// Reset вызывается при появлении нового босса
+func (b *BossBar) Reset() { b.trail = 1 }
+
+// thresholds — доли HP, на которых начинаются фазы 2..N
+func (b *BossBar) Draw(name string, hp, maxHP int, thresholds []float32, phase int, invuln bool) {
+	if maxHP <= 0 {
+		return
+	}
+	frac := float32(hp) / float32(maxHP)
+	if frac < 0 {
+		frac = 0
+	}
+
+	// белый след догоняет текущее значение
+	if b.trail < frac {
+		b.trail = frac
+	} else {
+		b.trail -= rl.GetFrameTime() * 0.35
+		if b.trail < frac {
+			b.trail = frac
+		}
+	}
+
+	sw := screenW
+	w := sw * b.Width
+	x := sw/2 - w/2
+
+	// имя
+	const nameSize float32 = 12
+	ts := rl.MeasureTextEx(b.Font, name, nameSize, 1)
+	rl.DrawTextEx(b.Font, name, rl.NewVector2(sw/2-ts.X/2, b.Y), nameSize, 1, rl.White)
+	y := b.Y + ts.Y + 2
+
+	// рамка и заливка
+	rl.DrawRectangleRec(rl.NewRectangle(x-1, y-1, w+2, b.Height+2), rl.NewColor(0, 0, 0, 200))
+	rl.DrawRectangleRec(rl.NewRectangle(x, y, w*b.trail, b.Height), rl.NewColor(255, 240, 220, 220))
+	fill := rl.NewColor(200, 30, 50, 255)
+	if invuln {
+		fill = rl.NewColor(150, 150, 170, 255)
+	}
+	rl.DrawRectangleRec(rl.NewRectangle(x, y, w*frac, b.Height), fill)
+
+	// засечки порогов фаз
+	for _, t := range thresholds {
+		tx := x + w*t
+		rl.DrawRectangleRec(rl.NewRectangle(tx, y-1, 1, b.Height+2), rl.NewColor(255, 255, 255, 200))
+	}
+
+	// пипсы фаз: пройденные и текущая закрашены
+	n := len(thresholds) + 1
+	const pip float32 = 3
+	py := y + b.Height + 6
+	px := sw/2 - float32(n-1)*pip*1.5
+	for i := 0; i < n; i++ {
+		c := rl.NewVector2(px+float32(i)*pip*3, py)
+		if i <= phase {
+			rl.DrawCircleV(c, pip, rl.NewColor(200, 30, 50, 255))
+		}
+		rl.DrawCircleLinesV(c, pip, rl.White)
+	}
+}
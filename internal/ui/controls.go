@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ---------- Label ----------
+
+type Label struct {
+	Node
+	Text     string
+	Size     float32  // 0 — Theme.FontSize
+	Color    rl.Color // нулевой — Theme.TextDim
+	Centered bool
+}
+
+func NewLabel(text string) *Label { return &Label{Text: text} }
+
+// NewTitle — крупная надпись (заголовок экрана)
+func NewTitle(text string) *Label { return &Label{Text: text, Size: -1, Centered: true} }
+
+func (l *Label) size(t *Theme) float32 {
+	switch {
+	case l.Size < 0:
+		return t.TitleSize
+	case l.Size == 0:
+		return t.FontSize
+	}
+	return l.Size
+}
+
+func (l *Label) color(t *Theme) rl.Color {
+	if l.Color.A != 0 {
+		return l.Color
+	}
+	if l.Size < 0 {
+		return t.Title.Color()
+	}
+	return t.TextDim.Color()
+}
+
+func (l *Label) measure(t *Theme) (float32, float32) {
+	s := t.Measure(l.Text, l.size(t))
+	return s.X, s.Y
+}
+
+func (l *Label) layout(_ *Theme, r rl.Rectangle) { l.Bounds = r }
+func (l *Label) update(*Ctx)                     {}
+
+func (l *Label) draw(c *Ctx) {
+	t := c.Theme
+	if l.Centered {
+		t.DrawTextCentered(l.Text, l.Bounds, l.size(t), l.color(t))
+		return
+	}
+	s := t.Measure(l.Text, l.size(t))
+	t.DrawText(l.Text, l.Bounds.X, l.Bounds.Y+l.Bounds.Height/2-s.Y/2, l.size(t), l.color(t))
+}
+
+// ---------- Button ----------
+
+type Button struct {
+	Node
+	Text    string
+	OnClick func()
+}
+
+func NewButton(text string, onClick func()) *Button {
+	return &Button{Text: text, OnClick: onClick}
+}
+
+func (b *Button) focusable() bool { return true }
+
+func (b *Button) measure(t *Theme) (float32, float32) {
+	s := t.Measure(b.Text, t.FontSize)
+	return s.X + 4*t.Padding, t.RowHeight
+}
+
+func (b *Button) layout(_ *Theme, r rl.Rectangle) { b.Bounds = r }
+
+func (b *Button) update(c *Ctx) {
+	if b.Disabled || b.OnClick == nil {
+		return
+	}
+	if (c.In.Click && c.Hover(&b.Node)) || (c.In.Accept && c.Focused(b)) {
+		playSound("ui_click")
+		b.OnClick()
+	}
+}
+
+func (b *Button) draw(c *Ctx) {
+	t := c.Theme
+	t.drawBox(b.Bounds, c.Hover(&b.Node), c.Focused(b), b.Disabled)
+	t.DrawTextCentered(b.Text, b.Bounds, t.FontSize, t.Text.Color())
+}
+
+// ---------- Slider ----------
+
+// Slider — значение в [Min, Max]; ←/→ меняют на Step, мышью — перетаскиванием
+type Slider struct {
+	Node
+	Label    string
+	Value    float32
+	Min, Max float32
+	Step     float32
+	Format   func(v float32) string // nil — проценты
+	OnChange func(v float32)
+
+	dragging bool
+}
+
+func NewSlider(label string, value, min, max, step float32, onChange func(float32)) *Slider {
+	return &Slider{Label: label, Value: value, Min: min, Max: max, Step: step, OnChange: onChange}
+}
+
+func (s *Slider) focusable() bool { return true }
+
+func (s *Slider) measure(t *Theme) (float32, float32) {
+	return t.Measure(s.Label, t.FontSize).X + 140, t.RowHeight
+}
+
+func (s *Slider) layout(_ *Theme, r rl.Rectangle) { s.Bounds = r }
+
+func (s *Slider) track(t *Theme) rl.Rectangle {
+	w := s.Bounds.Width * 0.45
+	return rl.NewRectangle(s.Bounds.X+s.Bounds.Width-w-t.Padding, s.Bounds.Y+s.Bounds.Height/2-3, w, 6)
+}
+
+func (s *Slider) set(v float32) {
+	v = rl.Clamp(v, s.Min, s.Max)
+	if s.Step > 0 {
+		v = s.Min + float32(math.Round(float64((v-s.Min)/s.Step)))*s.Step
+		v = rl.Clamp(v, s.Min, s.Max)
+	}
+	if v != s.Value {
+		s.Value = v
+		if s.OnChange != nil {
+			s.OnChange(v)
+		}
+	}
+}
+
+func (s *Slider) update(c *Ctx) {
+	if s.Disabled {
+		return
+	}
+	if c.Focused(s) {
+		step := s.Step
+		if step <= 0 {
+			step = (s.Max - s.Min) / 10
+		}
+		if c.In.Left {
+			s.set(s.Value - step)
+		}
+		if c.In.Right {
+			s.set(s.Value + step)
+		}
+	}
+	if c.In.Click && c.Hover(&s.Node) {
+		s.dragging = true
+	}
+	if !c.In.Held {
+		s.dragging = false
+	}
+	if s.dragging {
+		tr := s.track(c.Theme)
+		k := rl.Clamp((c.In.Mouse.X-tr.X)/tr.Width, 0, 1)
+		s.set(s.Min + (s.Max-s.Min)*k)
+	}
+}
+
+func (s *Slider) text() string {
+	if s.Format != nil {
+		return s.Format(s.Value)
+	}
+	if s.Max <= s.Min {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", int(math.Round(float64((s.Value-s.Min)/(s.Max-s.Min)*100))))
+}
+
+func (s *Slider) draw(c *Ctx) {
+	t := c.Theme
+	focused := c.Focused(s)
+	if focused {
+		rl.DrawRectangleRoundedLines(s.Bounds, t.Roundness, 8, t.Focus.Color())
+	}
+	ls := t.Measure(s.Label, t.FontSize)
+	t.DrawText(s.Label, s.Bounds.X+t.Padding, s.Bounds.Y+s.Bounds.Height/2-ls.Y/2, t.FontSize, t.TextDim.Color())
+
+	tr := s.track(t)
+	k := float32(0)
+	if s.Max > s.Min {
+		k = (s.Value - s.Min) / (s.Max - s.Min)
+	}
+	rl.DrawRectangleRec(tr, t.Button.Color())
+	rl.DrawRectangleRec(rl.NewRectangle(tr.X, tr.Y, tr.Width*k, tr.Height), t.Accent.Color())
+	knob := rl.NewRectangle(tr.X+tr.Width*k-3, tr.Y-3, 6, tr.Height+6)
+	rl.DrawRectangleRec(knob, t.ButtonHot.Color())
+	if focused {
+		rl.DrawRectangleLinesEx(knob, 1, t.Focus.Color())
+	}
+
+	vs := s.text()
+	vw := t.Measure(vs, t.FontSize)
+	t.DrawText(vs, tr.X-vw.X-t.Padding, s.Bounds.Y+s.Bounds.Height/2-vw.Y/2, t.FontSize, t.TextDim.Color())
+}
+
+// ---------- Toggle ----------
+
+type Toggle struct {
+	Node
+	Label    string
+	On       bool
+	OnChange func(on bool)
+}
+
+func NewToggle(label string, on bool, onChange func(bool)) *Toggle {
+	return &Toggle{Label: label, On: on, OnChange: onChange}
+}
+
+func (g *Toggle) focusable() bool { return true }
+
+func (g *Toggle) measure(t *Theme) (float32, float32) {
+	return t.Measure(g.Label, t.FontSize).X + t.RowHeight + 3*t.Padding, t.RowHeight
+}
+
+func (g *Toggle) layout(_ *Theme, r rl.Rectangle) { g.Bounds = r }
+
+func (g *Toggle) update(c *Ctx) {
+	if g.Disabled {
+		return
+	}
+	if (c.In.Click && c.Hover(&g.Node)) || (c.Focused(g) && (c.In.Accept || c.In.Left || c.In.Right)) {
+		g.On = !g.On
+		if g.OnChange != nil {
+			g.OnChange(g.On)
+		}
+	}
+}
+
+func (g *Toggle) draw(c *Ctx) {
+	t := c.Theme
+	if c.Focused(g) {
+		rl.DrawRectangleRoundedLines(g.Bounds, t.Roundness, 8, t.Focus.Color())
+	}
+	ls := t.Measure(g.Label, t.FontSize)
+	t.DrawText(g.Label, g.Bounds.X+t.Padding, g.Bounds.Y+g.Bounds.Height/2-ls.Y/2, t.FontSize, t.TextDim.Color())
+
+	box := t.RowHeight - 8
+	r := rl.NewRectangle(g.Bounds.X+g.Bounds.Width-box-t.Padding, g.Bounds.Y+4, box, box)
+	rl.DrawRectangleRec(r, t.Button.Color())
+	rl.DrawRectangleLinesEx(r, 1, t.Border.Color())
+	if g.On {
+		rl.DrawRectangleRec(rl.NewRectangle(r.X+3, r.Y+3, r.Width-6, r.Height-6), t.Accent.Color())
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Dialog — модальное окно: заголовок, текст и ряд кнопок.
+// Открывается через View.Open; пока открыто, остальной экран не получает ввод.
+type Dialog struct {
+	Node
+	OnBack func() // Esc; nil — просто закрыть
+
+	box *Box
+}
+
+func NewDialog(title, text string, buttons ...*Button) *Dialog {
+	row := HBox()
+	for _, b := range buttons {
+		row.Add(b)
+	}
+	box := VBox(NewTitle(title))
+	if text != "" {
+		box.Add(NewLabel(text))
+	}
+	box.Add(row)
+	box.Padding = 12
+	box.Gap = 8
+	box.Panel = true
+	return &Dialog{box: box}
+}
+
+// Content — вертикальный контейнер окна (можно добавить свои виджеты)
+func (d *Dialog) Content() *Box { return d.box }
+
+func (d *Dialog) children() []Widget { return []Widget{d.box} }
+
+func (d *Dialog) measure(t *Theme) (float32, float32) { return size(d.box, t) }
+
+func (d *Dialog) layout(t *Theme, r rl.Rectangle) {
+	d.Bounds = r
+	d.box.layout(t, r)
+}
+
+func (d *Dialog) update(c *Ctx) { d.box.update(c) }
+
+func (d *Dialog) draw(c *Ctx) {
+	d.box.draw(c)
+	rl.DrawRectangleRoundedLines(d.Bounds, c.Theme.Roundness*0.5, 8, c.Theme.Border.Color())
+}
@@ -0,0 +1,140 @@
+package ui
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Dropdown — выбор одного варианта. Закрытый: ←/→ листают варианты,
+// Enter/клик раскрывает список поверх экрана.
+type Dropdown struct {
+	Node
+	Label    string
+	Options  []string
+	Index    int
+	OnChange func(i int)
+
+	hl int // подсвеченный вариант в раскрытом списке
+}
+
+func NewDropdown(label string, options []string, index int, onChange func(int)) *Dropdown {
+	return &Dropdown{Label: label, Options: options, Index: index, OnChange: onChange}
+}
+
+func (d *Dropdown) focusable() bool { return true }
+
+func (d *Dropdown) Selected() string {
+	if d.Index >= 0 && d.Index < len(d.Options) {
+		return d.Options[d.Index]
+	}
+	return ""
+}
+
+func (d *Dropdown) measure(t *Theme) (float32, float32) {
+	var ow float32
+	for _, o := range d.Options {
+		ow = max(ow, t.Measure(o, t.FontSize).X)
+	}
+	return t.Measure(d.Label, t.FontSize).X + ow + 6*t.Padding, t.RowHeight
+}
+
+func (d *Dropdown) layout(_ *Theme, r rl.Rectangle) { d.Bounds = r }
+
+func (d *Dropdown) field(t *Theme) rl.Rectangle {
+	w := d.Bounds.Width * 0.45
+	return rl.NewRectangle(d.Bounds.X+d.Bounds.Width-w, d.Bounds.Y, w, d.Bounds.Height)
+}
+
+func (d *Dropdown) choose(i int) {
+	if len(d.Options) == 0 {
+		return
+	}
+	i = (i + len(d.Options)) % len(d.Options)
+	if i != d.Index {
+		d.Index = i
+		if d.OnChange != nil {
+			d.OnChange(i)
+		}
+	}
+}
+
+func (d *Dropdown) update(c *Ctx) {
+	if d.Disabled || len(d.Options) == 0 {
+		return
+	}
+	if c.Focused(d) {
+		if c.In.Left {
+			d.choose(d.Index - 1)
+		}
+		if c.In.Right {
+			d.choose(d.Index + 1)
+		}
+	}
+	if (c.In.Click && c.Hover(&d.Node)) || (c.In.Accept && c.Focused(d)) {
+		d.hl = d.Index
+		c.view.over = d
+	}
+}
+
+func (d *Dropdown) draw(c *Ctx) {
+	t := c.Theme
+	ls := t.Measure(d.Label, t.FontSize)
+	t.DrawText(d.Label, d.Bounds.X+t.Padding, d.Bounds.Y+d.Bounds.Height/2-ls.Y/2, t.FontSize, t.TextDim.Color())
+	f := d.field(t)
+	t.drawBox(f, c.Hover(&d.Node), c.Focused(d), d.Disabled)
+	t.DrawTextCentered("< "+d.Selected()+" >", f, t.FontSize, t.Text.Color())
+}
+
+func (d *Dropdown) optionRect(t *Theme, i int) rl.Rectangle {
+	f := d.field(t)
+	y := f.Y + f.Height + float32(i)*t.RowHeight
+	// не влезает вниз — раскрываемся вверх
+	if total := float32(len(d.Options)) * t.RowHeight; f.Y+f.Height+total > screenH {
+		y = f.Y - total + float32(i)*t.RowHeight
+	}
+	return rl.NewRectangle(f.X, y, f.Width, t.RowHeight)
+}
+
+func (d *Dropdown) updateOverlay(c *Ctx) {
+	in := c.In
+	close := func() { c.view.over = nil }
+	switch {
+	case in.Back:
+		close()
+		return
+	case in.Up:
+		d.hl = (d.hl - 1 + len(d.Options)) % len(d.Options)
+	case in.Down:
+		d.hl = (d.hl + 1) % len(d.Options)
+	case in.Accept:
+		d.choose(d.hl)
+		close()
+		return
+	}
+	for i := range d.Options {
+		if rl.CheckCollisionPointRec(in.Mouse, d.optionRect(c.Theme, i)) {
+			if in.MouseMoved {
+				d.hl = i
+			}
+			if in.Click {
+				d.choose(i)
+				close()
+				return
+			}
+		}
+	}
+	if in.Click {
+		close() // клик мимо списка
+	}
+}
+
+func (d *Dropdown) drawOverlay(c *Ctx) {
+	t := c.Theme
+	for i, o := range d.Options {
+		r := d.optionRect(t, i)
+		bg := t.Button.Color()
+		if i == d.hl {
+			bg = t.Focus.Color()
+		}
+		rl.DrawRectangleRec(r, bg)
+		rl.DrawRectangleLinesEx(r, 1, t.Border.Color())
+		t.DrawTextCentered(o, r, t.FontSize, t.Text.Color())
+	}
+}
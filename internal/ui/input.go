@@ -0,0 +1,53 @@
+package ui
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Input — ввод UI за кадр. Собирается PollInput или вручную (тесты, реплеи).
+type Input struct {
+	Mouse      rl.Vector2 // в координатах виртуального экрана
+	MouseMoved bool
+	Click      bool // ЛКМ нажата в этом кадре
+	Held       bool // ЛКМ зажата
+	Wheel      float32
+
+	Up, Down, Left, Right bool
+	Accept, Back          bool
+
+	Key int32 // нажатая клавиша (для захвата в KeyField), 0 — нет
+}
+
+var lastMouse rl.Vector2
+
+// PollInput читает клавиатуру, мышь и первый геймпад.
+// mouse — позиция курсора, уже переведённая в виртуальный экран.
+func PollInput(mouse rl.Vector2) Input {
+	in := Input{
+		Mouse:      mouse,
+		MouseMoved: mouse != lastMouse,
+		Click:      rl.IsMouseButtonPressed(rl.MouseLeftButton),
+		Held:       rl.IsMouseButtonDown(rl.MouseLeftButton),
+		Wheel:      rl.GetMouseWheelMove(),
+		Up:         rl.IsKeyPressed(rl.KeyUp) || rl.IsKeyPressed(rl.KeyW),
+		Down:       rl.IsKeyPressed(rl.KeyDown) || rl.IsKeyPressed(rl.KeyS) || rl.IsKeyPressed(rl.KeyTab),
+		Left:       rl.IsKeyPressed(rl.KeyLeft) || rl.IsKeyPressed(rl.KeyA),
+		Right:      rl.IsKeyPressed(rl.KeyRight) || rl.IsKeyPressed(rl.KeyD),
+		Accept:     rl.IsKeyPressed(rl.KeyEnter) || rl.IsKeyPressed(rl.KeySpace),
+		Back:       rl.IsKeyPressed(rl.KeyEscape),
+		Key:        rl.GetKeyPressed(),
+	}
+	lastMouse = mouse
+	if in.Click {
+		in.MouseMoved = true // клик тоже переводит фокус под курсор
+	}
+
+	const pad = 0
+	if rl.IsGamepadAvailable(pad) {
+		in.Up = in.Up || rl.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceUp)
+		in.Down = in.Down || rl.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceDown)
+		in.Left = in.Left || rl.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceLeft)
+		in.Right = in.Right || rl.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceRight)
+		in.Accept = in.Accept || rl.IsGamepadButtonPressed(pad, rl.GamepadButtonRightFaceDown)
+		in.Back = in.Back || rl.IsGamepadButtonPressed(pad, rl.GamepadButtonRightFaceRight)
+	}
+	return in
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// KeyField — поле назначения клавиши: Enter/клик — ждём нажатия, Esc — отмена,
+// Delete — очистить. Без Label поле занимает всю ширину.
+type KeyField struct {
+	Node
+	Label    string
+	Key      int32
+	OnChange func(key int32)
+
+	Name func(key int32) string // подпись кода; nil — KeyName
+	Poll func(in Input) int32   // что нажато при захвате; nil — in.Key (кнопки мыши/геймпада сюда)
+}
+
+func NewKeyField(label string, key int32, onChange func(int32)) *KeyField {
+	return &KeyField{Label: label, Key: key, OnChange: onChange}
+}
+
+func (k *KeyField) focusable() bool { return true }
+
+func (k *KeyField) measure(t *Theme) (float32, float32) {
+	if k.Label == "" {
+		return 80, t.RowHeight
+	}
+	return t.Measure(k.Label, t.FontSize).X + 120, t.RowHeight
+}
+
+func (k *KeyField) layout(_ *Theme, r rl.Rectangle) { k.Bounds = r }
+
+func (k *KeyField) field() rl.Rectangle {
+	if k.Label == "" {
+		return k.Bounds
+	}
+	w := k.Bounds.Width * 0.45
+	return rl.NewRectangle(k.Bounds.X+k.Bounds.Width-w, k.Bounds.Y, w, k.Bounds.Height)
+}
+
+func (k *KeyField) update(c *Ctx) {
+	if k.Disabled {
+		return
+	}
+	if (c.In.Click && c.Hover(&k.Node)) || (c.In.Accept && c.Focused(k)) {
+		c.view.capture = k
+	}
+}
+
+// captureKey вызывается экраном, пока поле ждёт клавишу
+func (k *KeyField) captureKey(c *Ctx) {
+	key := c.In.Key
+	if k.Poll != nil {
+		key = k.Poll(c.In)
+	}
+	if key == 0 {
+		return
+	}
+	c.view.capture = nil
+	switch key {
+	case rl.KeyEscape:
+		return
+	case rl.KeyDelete:
+		key = 0
+	}
+	if key != k.Key {
+		k.Key = key
+		if k.OnChange != nil {
+			k.OnChange(k.Key)
+		}
+	}
+}
+
+func (k *KeyField) draw(c *Ctx) {
+	t := c.Theme
+	if k.Label != "" {
+		ls := t.Measure(k.Label, t.FontSize)
+		t.DrawText(k.Label, k.Bounds.X+t.Padding, k.Bounds.Y+k.Bounds.Height/2-ls.Y/2, t.FontSize, t.TextDim.Color())
+	}
+	f := k.field()
+	waiting := c.view.capture == k
+	t.drawBox(f, c.Hover(&k.Node), c.Focused(k) || waiting, k.Disabled)
+	text := KeyName(k.Key)
+	if k.Name != nil {
+		text = k.Name(k.Key)
+	}
+	if waiting {
+		text = "..."
+	}
+	t.DrawTextCentered(text, f, t.FontSize, t.Text.Color())
+}
+
+var keyNames = map[int32]string{
+	rl.KeySpace: "Space", rl.KeyEscape: "Esc", rl.KeyEnter: "Enter", rl.KeyTab: "Tab",
+	rl.KeyBackspace: "Backspace", rl.KeyInsert: "Ins", rl.KeyDelete: "Del",
+	rl.KeyRight: "→", rl.KeyLeft: "←", rl.KeyDown: "↓", rl.KeyUp: "↑",
+	rl.KeyPageUp: "PgUp", rl.KeyPageDown: "PgDn", rl.KeyHome: "Home", rl.KeyEnd: "End",
+	rl.KeyLeftShift: "LShift", rl.KeyLeftControl: "LCtrl", rl.KeyLeftAlt: "LAlt",
+	rl.KeyRightShift: "RShift", rl.KeyRightControl: "RCtrl", rl.KeyRightAlt: "RAlt",
+	rl.KeyGrave: "`", rl.KeyMinus: "-", rl.KeyEqual: "=", rl.KeyComma: ",", rl.KeyPeriod: ".",
+	rl.KeySlash: "/", rl.KeySemicolon: ";", rl.KeyApostrophe: "'",
+	rl.KeyLeftBracket: "[", rl.KeyRightBracket: "]", rl.KeyBackSlash: "\\",
+}
+
+// KeyName — короткое имя клавиши для UI
+func KeyName(k int32) string {
+	switch {
+	case k == 0:
+		return "—"
+	case k >= rl.KeyA && k <= rl.KeyZ, k >= rl.KeyZero && k <= rl.KeyNine:
+		return string(rune(k))
+	case k >= rl.KeyF1 && k <= rl.KeyF12:
+		return fmt.Sprintf("F%d", k-rl.KeyF1+1)
+	}
+	if n, ok := keyNames[k]; ok {
+		return n
+	}
+	return fmt.Sprintf("#%d", k)
+}
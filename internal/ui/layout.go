@@ -0,0 +1,157 @@
+package ui
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Dir — как контейнер раскладывает детей
+type Dir int
+
+const (
+	Vertical   Dir = iota // столбиком
+	Horizontal            // в строку
+	Stack                 // друг на друге, каждый по своему Anchor
+)
+
+// Align — выравнивание детей по поперечной оси
+type Align int
+
+const (
+	AlignCenter Align = iota
+	AlignStart
+	AlignEnd
+)
+
+// Box — контейнер с раскладкой Vertical/Horizontal/Stack
+type Box struct {
+	Node
+	Dir     Dir
+	Align   Align
+	Gap     float32 // 0 — Theme.Gap
+	Padding float32
+	Panel   bool // рисовать подложку
+	Items   []Widget
+}
+
+func VBox(items ...Widget) *Box { return &Box{Dir: Vertical, Items: items} }
+
+func HBox(items ...Widget) *Box { return &Box{Dir: Horizontal, Items: items} }
+
+func StackBox(items ...Widget) *Box { return &Box{Dir: Stack, Items: items} }
+
+func (b *Box) Add(items ...Widget) *Box {
+	b.Items = append(b.Items, items...)
+	return b
+}
+
+func (b *Box) children() []Widget { return b.Items }
+
+func (b *Box) gap(t *Theme) float32 {
+	if b.Gap > 0 {
+		return b.Gap
+	}
+	return t.Gap
+}
+
+func (b *Box) measure(t *Theme) (float32, float32) {
+	var w, h float32
+	n := 0
+	for _, it := range b.Items {
+		if it.Base().Hidden {
+			continue
+		}
+		cw, ch := size(it, t)
+		switch b.Dir {
+		case Vertical:
+			w = max(w, cw)
+			h += ch
+		case Horizontal:
+			w += cw
+			h = max(h, ch)
+		default:
+			w = max(w, cw)
+			h = max(h, ch)
+		}
+		n++
+	}
+	if n > 1 {
+		g := b.gap(t) * float32(n-1)
+		switch b.Dir {
+		case Vertical:
+			h += g
+		case Horizontal:
+			w += g
+		}
+	}
+	return w + 2*b.Padding, h + 2*b.Padding
+}
+
+func (b *Box) layout(t *Theme, r rl.Rectangle) {
+	b.Bounds = r
+	in := rl.NewRectangle(r.X+b.Padding, r.Y+b.Padding, r.Width-2*b.Padding, r.Height-2*b.Padding)
+	x, y := in.X, in.Y
+	for _, it := range b.Items {
+		n := it.Base()
+		if n.Hidden {
+			continue
+		}
+		cw, ch := size(it, t)
+		switch b.Dir {
+		case Vertical:
+			if n.Fill {
+				cw = in.Width
+			}
+			it.layout(t, rl.NewRectangle(alignIn(in.X, in.Width, cw, b.Align), y, cw, ch))
+			y += ch + b.gap(t)
+		case Horizontal:
+			if n.Fill {
+				ch = in.Height
+			}
+			it.layout(t, rl.NewRectangle(x, alignIn(in.Y, in.Height, ch, b.Align), cw, ch))
+			x += cw + b.gap(t)
+		default:
+			if n.Fill {
+				it.layout(t, in)
+			} else {
+				it.layout(t, place(in, cw, ch, n.Anchor, n.Margin))
+			}
+		}
+	}
+}
+
+func alignIn(start, avail, size float32, a Align) float32 {
+	switch a {
+	case AlignStart:
+		return start
+	case AlignEnd:
+		return start + avail - size
+	}
+	return start + (avail-size)/2
+}
+
+func (b *Box) update(c *Ctx) {
+	for _, it := range b.Items {
+		if !it.Base().Hidden {
+			it.update(c)
+		}
+	}
+}
+
+func (b *Box) draw(c *Ctx) {
+	if b.Panel {
+		rl.DrawRectangleRounded(b.Bounds, c.Theme.Roundness*0.5, 8, c.Theme.Panel.Color())
+	}
+	for _, it := range b.Items {
+		if !it.Base().Hidden {
+			it.draw(c)
+		}
+	}
+}
+
+// Spacer — пустое место заданного размера
+type Spacer struct{ Node }
+
+func NewSpacer(w, h float32) *Spacer { return &Spacer{Node{Width: w, Height: h}} }
+
+func (s *Spacer) measure(*Theme) (float32, float32) { return s.Width, s.Height }
+func (s *Spacer) layout(_ *Theme, r rl.Rectangle)   { s.Bounds = r }
+func (s *Spacer) update(*Ctx)                       {}
+func (s *Spacer) draw(*Ctx)                         {}
@@ -0,0 +1,178 @@
+package ui
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Размер виртуального экрана, под который раскладывается весь UI.
+// Окно может быть любым — кадр масштабирует canvas.
+var screenW, screenH float32 = 640, 360
+
+func SetScreen(w, h float32) { screenW, screenH = w, h }
+
+// OnSound — звук интерфейса по имени ("ui_move", "ui_click"); nil — тишина
+var OnSound func(name string)
+
+func playSound(name string) {
+	if OnSound != nil {
+		OnSound(name)
+	}
+}
+
+func Screen() (float32, float32) { return screenW, screenH }
+
+// overlay — то, что рисуется поверх всего и забирает ввод (открытый Dropdown)
+type overlay interface {
+	updateOverlay(c *Ctx)
+	drawOverlay(c *Ctx)
+}
+
+// View — экран UI: дерево виджетов, фокус, модальные окна.
+// Раскладка пересчитывается каждый кадр, так что дерево можно менять на лету.
+type View struct {
+	Root   Widget
+	Theme  *Theme
+	OnBack func() // Esc / B на геймпаде, если никто не перехватил
+
+	focus   Widget
+	modals  []*Dialog
+	over    overlay
+	capture *KeyField
+}
+
+func NewView(theme *Theme, root Widget) *View {
+	return &View{Root: root, Theme: theme}
+}
+
+// Open показывает модальное окно поверх экрана; ввод идёт только в него
+func (v *View) Open(d *Dialog) {
+	v.modals = append(v.modals, d)
+	v.focus = nil
+}
+
+func (v *View) Close(d *Dialog) {
+	for i, m := range v.modals {
+		if m == d {
+			v.modals = append(v.modals[:i], v.modals[i+1:]...)
+			v.focus = nil
+			return
+		}
+	}
+}
+
+func (v *View) Modal() bool { return len(v.modals) > 0 }
+
+// SetFocus ставит фокус на виджет (например, первую кнопку при входе на экран)
+func (v *View) SetFocus(w Widget) { v.focus = w }
+
+// Reset — сброс фокуса и всплывающих элементов при повторном входе на экран
+func (v *View) Reset() {
+	v.focus = nil
+	v.modals = nil
+	v.over = nil
+	v.capture = nil
+}
+
+func (v *View) active() Widget {
+	if n := len(v.modals); n > 0 {
+		return v.modals[n-1]
+	}
+	return v.Root
+}
+
+func (v *View) layoutAll() {
+	full := rl.NewRectangle(0, 0, screenW, screenH)
+	lay := func(w Widget) {
+		if w == nil {
+			return
+		}
+		n := w.Base()
+		r := full
+		if !n.Fill {
+			ww, hh := size(w, v.Theme)
+			r = place(full, ww, hh, n.Anchor, n.Margin)
+		}
+		w.layout(v.Theme, r)
+	}
+	lay(v.Root)
+	for _, d := range v.modals {
+		lay(d)
+	}
+}
+
+func (v *View) Update(in Input) {
+	v.layoutAll()
+	c := &Ctx{Theme: v.Theme, In: in, view: v}
+
+	if v.capture != nil {
+		v.capture.captureKey(c)
+		return
+	}
+	if v.over != nil {
+		v.over.updateOverlay(c)
+		return
+	}
+
+	act := v.active()
+	var list []Widget
+	walk(act, func(w Widget) {
+		if f, ok := w.(focusable); ok && f.focusable() && !w.Base().Disabled {
+			list = append(list, w)
+		}
+	})
+	idx := -1
+	for i, w := range list {
+		if w == v.focus {
+			idx = i
+		}
+	}
+	if in.MouseMoved {
+		for i, w := range list {
+			if c.Hover(w.Base()) {
+				idx = i
+			}
+		}
+	}
+	if len(list) > 0 {
+		switch {
+		case idx < 0:
+			idx = 0
+		case in.Up:
+			idx = (idx - 1 + len(list)) % len(list)
+			playSound("ui_move")
+		case in.Down:
+			idx = (idx + 1) % len(list)
+			playSound("ui_move")
+		}
+		v.focus = list[idx]
+	} else {
+		v.focus = nil
+	}
+	c.Focus = v.focus
+
+	act.update(c)
+
+	if in.Back && v.capture == nil && v.over == nil {
+		if d, ok := act.(*Dialog); ok {
+			if d.OnBack != nil {
+				d.OnBack()
+			} else {
+				v.Close(d)
+			}
+		} else if v.OnBack != nil {
+			v.OnBack()
+		}
+	}
+}
+
+func (v *View) Draw() {
+	c := &Ctx{Theme: v.Theme, Focus: v.focus, view: v}
+	if v.Root != nil && !v.Root.Base().Hidden {
+		v.Root.draw(c)
+	}
+	for _, d := range v.modals {
+		rl.DrawRectangle(0, 0, int32(screenW), int32(screenH), v.Theme.Backdrop.Color())
+		d.draw(c)
+	}
+	if v.over != nil {
+		v.over.drawOverlay(c)
+	}
+}
@@ -0,0 +1,111 @@
+package ui
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// ScrollList — столбик виджетов в окне фиксированной высоты (Node.Height).
+// Прокрутка колесом; фокус клавиатуры сам прокручивает к себе.
+type ScrollList struct {
+	Node
+	Items []Widget
+	Gap   float32 // 0 — Theme.Gap
+
+	offset  float32
+	content float32 // высота содержимого
+}
+
+func NewScrollList(height float32, items ...Widget) *ScrollList {
+	return &ScrollList{Node: Node{Height: height}, Items: items}
+}
+
+func (s *ScrollList) children() []Widget { return s.Items }
+
+func (s *ScrollList) gap(t *Theme) float32 {
+	if s.Gap > 0 {
+		return s.Gap
+	}
+	return t.Gap
+}
+
+func (s *ScrollList) measure(t *Theme) (float32, float32) {
+	var w, h float32
+	for _, it := range s.Items {
+		cw, ch := size(it, t)
+		w = max(w, cw)
+		h += ch + s.gap(t)
+	}
+	return w + 8, h // +8 — полоса прокрутки
+}
+
+func (s *ScrollList) maxOffset() float32 {
+	return max(s.content-s.Bounds.Height, 0)
+}
+
+func (s *ScrollList) layout(t *Theme, r rl.Rectangle) {
+	s.Bounds = r
+	s.offset = rl.Clamp(s.offset, 0, s.maxOffset())
+	y := r.Y - s.offset
+	inner := r.Width - 8
+	s.content = 0
+	for _, it := range s.Items {
+		n := it.Base()
+		if n.Hidden {
+			continue
+		}
+		cw, ch := size(it, t)
+		if n.Fill {
+			cw = inner
+		}
+		it.layout(t, rl.NewRectangle(r.X, y, cw, ch))
+		y += ch + s.gap(t)
+		s.content += ch + s.gap(t)
+		walk(it, func(w Widget) {
+			w.Base().clip = r
+			w.Base().clipped = true
+		})
+	}
+}
+
+func (s *ScrollList) update(c *Ctx) {
+	if c.In.Wheel != 0 && c.Hover(&s.Node) {
+		s.offset -= c.In.Wheel * c.Theme.RowHeight
+	}
+	// фокус внутри списка — прокручиваем так, чтобы он был виден
+	for _, it := range s.Items {
+		found := false
+		walk(it, func(w Widget) { found = found || w == c.Focus })
+		if !found {
+			continue
+		}
+		b := it.Base().Bounds
+		if b.Y < s.Bounds.Y {
+			s.offset -= s.Bounds.Y - b.Y
+		} else if b.Y+b.Height > s.Bounds.Y+s.Bounds.Height {
+			s.offset += b.Y + b.Height - s.Bounds.Y - s.Bounds.Height
+		}
+	}
+	s.offset = rl.Clamp(s.offset, 0, s.maxOffset())
+	for _, it := range s.Items {
+		if !it.Base().Hidden {
+			it.update(c)
+		}
+	}
+}
+
+func (s *ScrollList) draw(c *Ctx) {
+	r := s.Bounds
+	rl.BeginScissorMode(int32(r.X), int32(r.Y), int32(r.Width), int32(r.Height))
+	for _, it := range s.Items {
+		if !it.Base().Hidden {
+			it.draw(c)
+		}
+	}
+	rl.EndScissorMode()
+
+	if s.content > r.Height {
+		bar := rl.NewRectangle(r.X+r.Width-4, r.Y, 4, r.Height)
+		rl.DrawRectangleRec(bar, c.Theme.Panel.Color())
+		h := r.Height * r.Height / s.content
+		y := r.Y + (r.Height-h)*s.offset/s.maxOffset()
+		rl.DrawRectangleRec(rl.NewRectangle(bar.X, y, bar.Width, h), c.Theme.Focus.Color())
+	}
+}
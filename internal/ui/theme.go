@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RGBA — цвет в json как [r, g, b, a]
+type RGBA [4]uint8
+
+func (c RGBA) Color() rl.Color { return rl.NewColor(c[0], c[1], c[2], c[3]) }
+
+// Theme — размеры и цвета виджетов (assets/data/theme.json). Шрифт задаёт код.
+type Theme struct {
+	Font      rl.Font `json:"-"`
+	FontSize  float32 `json:"font_size"`
+	TitleSize float32 `json:"title_size"`
+	HintSize  float32 `json:"hint_size"`
+	Spacing   float32 `json:"letter_spacing"`
+	Padding   float32 `json:"padding"`
+	Gap       float32 `json:"gap"`
+	Roundness float32 `json:"roundness"`
+	RowHeight float32 `json:"row_height"` // высота кнопок, слайдеров и т.п.
+
+	Text      RGBA `json:"text"`
+	TextDim   RGBA `json:"text_dim"`
+	Title     RGBA `json:"title"`
+	Panel     RGBA `json:"panel"`
+	Backdrop  RGBA `json:"backdrop"` // затемнение под модальным окном
+	Button    RGBA `json:"button"`
+	ButtonHot RGBA `json:"button_hot"`
+	Border    RGBA `json:"border"`
+	Focus     RGBA `json:"focus"`
+	Accent    RGBA `json:"accent"`
+	Disabled  RGBA `json:"disabled"`
+}
+
+func DefaultTheme() *Theme {
+	return &Theme{
+		FontSize:  12,
+		TitleSize: 24,
+		HintSize:  10,
+		Spacing:   1,
+		Padding:   6,
+		Gap:       4,
+		Roundness: 0.2,
+		RowHeight: 22,
+		Text:      RGBA{20, 20, 20, 255},
+		TextDim:   RGBA{230, 230, 230, 255},
+		Title:     RGBA{255, 255, 255, 255},
+		Panel:     RGBA{30, 24, 40, 230},
+		Backdrop:  RGBA{0, 0, 0, 160},
+		Button:    RGBA{255, 255, 255, 200},
+		ButtonHot: RGBA{255, 255, 255, 240},
+		Border:    RGBA{0, 0, 0, 255},
+		Focus:     RGBA{255, 200, 60, 255},
+		Accent:    RGBA{200, 30, 50, 255},
+		Disabled:  RGBA{140, 140, 140, 160},
+	}
+}
+
+// LoadTheme читает тему поверх значений по умолчанию (отсутствующие поля не трогаются)
+func LoadTheme(path string) (*Theme, error) {
+	t := DefaultTheme()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return DefaultTheme(), fmt.Errorf("theme %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Measure — размер текста шрифтом темы
+func (t *Theme) Measure(text string, size float32) rl.Vector2 {
+	return rl.MeasureTextEx(t.Font, text, size, t.Spacing)
+}
+
+func (t *Theme) DrawText(text string, x, y, size float32, c rl.Color) {
+	rl.DrawTextEx(t.Font, text, rl.NewVector2(x, y), size, t.Spacing, c)
+}
+
+// DrawTextCentered — текст по центру прямоугольника
+func (t *Theme) DrawTextCentered(text string, r rl.Rectangle, size float32, c rl.Color) {
+	ts := t.Measure(text, size)
+	t.DrawText(text, r.X+r.Width/2-ts.X/2, r.Y+r.Height/2-ts.Y/2, size, c)
+}
+
+// drawBox — плашка кнопки/поля: фон, рамка, рамка фокуса
+func (t *Theme) drawBox(r rl.Rectangle, hot, focused, disabled bool) {
+	bg := t.Button.Color()
+	if hot || focused {
+		bg = t.ButtonHot.Color()
+	}
+	if disabled {
+		bg = t.Disabled.Color()
+	}
+	rl.DrawRectangleRounded(r, t.Roundness, 8, bg)
+	rl.DrawRectangleRoundedLines(r, t.Roundness, 8, t.Border.Color())
+	if focused {
+		f := rl.NewRectangle(r.X-2, r.Y-2, r.Width+4, r.Height+4)
+		rl.DrawRectangleRoundedLines(f, t.Roundness, 8, t.Focus.Color())
+	}
+}
+
+// Scaled — копия темы с размерами, умноженными на k (настройка «масштаб UI»)
+func (t *Theme) Scaled(k float32) *Theme {
+	s := *t
+	s.FontSize *= k
+	s.TitleSize *= k
+	s.HintSize *= k
+	s.Padding *= k
+	s.Gap *= k
+	s.RowHeight *= k
+	return &s
+}
@@ -45,7 +45,7 @@ func (h *UltHUD) Draw(charge int) {
 	}
 	t := h.Textures[charge]
 	w := float32(t.Width) * h.Scale
-	x := float32(rl.GetScreenWidth()) - w - h.X
+	x := screenW - w - h.X
 	y := h.Y
 	rl.DrawTextureEx(t, rl.NewVector2(x, y), 0, h.Scale, rl.White)
 }
@@ -0,0 +1,121 @@
+package ui
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Anchor — к какому краю/углу родителя прижат виджет
+type Anchor int
+
+const (
+	AnchorCenter Anchor = iota
+	AnchorTop
+	AnchorBottom
+	AnchorLeft
+	AnchorRight
+	AnchorTopLeft
+	AnchorTopRight
+	AnchorBottomLeft
+	AnchorBottomRight
+)
+
+// Node — общие поля всех виджетов; встраивается в каждый виджет
+type Node struct {
+	Bounds   rl.Rectangle // выставляет раскладка
+	Width    float32      // желаемый размер; 0 — по содержимому
+	Height   float32
+	Fill     bool    // растянуть по поперечной оси контейнера
+	Anchor   Anchor  // положение внутри Stack/экрана
+	Margin   float32 // отступ от края при прижатии к нему
+	Hidden   bool
+	Disabled bool
+
+	clip    rl.Rectangle // видимая область (внутри ScrollList)
+	clipped bool
+}
+
+// Base — общие поля виджета (размер, якорь, видимость)
+func (n *Node) Base() *Node { return n }
+
+// Widget — элемент дерева UI. Раскладка: measure → layout; затем update и draw.
+type Widget interface {
+	Base() *Node
+	measure(t *Theme) (w, h float32)
+	layout(t *Theme, r rl.Rectangle)
+	update(c *Ctx)
+	draw(c *Ctx)
+}
+
+// focusable — виджеты, на которые может встать фокус клавиатуры/геймпада
+type focusable interface {
+	Widget
+	focusable() bool
+}
+
+// parent — контейнеры (для обхода дерева)
+type parent interface {
+	children() []Widget
+}
+
+// Ctx — состояние кадра, общее для всех виджетов экрана
+type Ctx struct {
+	Theme *Theme
+	In    Input
+	Focus Widget
+
+	view *View
+}
+
+// Hover — курсор над виджетом (и над его видимой частью, если он в прокрутке)
+func (c *Ctx) Hover(n *Node) bool {
+	if n.clipped && !rl.CheckCollisionPointRec(c.In.Mouse, n.clip) {
+		return false
+	}
+	return rl.CheckCollisionPointRec(c.In.Mouse, n.Bounds)
+}
+
+// Focused — стоит ли фокус на w
+func (c *Ctx) Focused(w Widget) bool { return c.Focus == w }
+
+// size — желаемый размер виджета с учётом явных Width/Height
+func size(w Widget, t *Theme) (float32, float32) {
+	n := w.Base()
+	mw, mh := w.measure(t)
+	if n.Width > 0 {
+		mw = n.Width
+	}
+	if n.Height > 0 {
+		mh = n.Height
+	}
+	return mw, mh
+}
+
+// place — прямоугольник w×h, прижатый к r по якорю a
+func place(r rl.Rectangle, w, h float32, a Anchor, m float32) rl.Rectangle {
+	x := r.X + (r.Width-w)/2
+	y := r.Y + (r.Height-h)/2
+	switch a {
+	case AnchorLeft, AnchorTopLeft, AnchorBottomLeft:
+		x = r.X + m
+	case AnchorRight, AnchorTopRight, AnchorBottomRight:
+		x = r.X + r.Width - w - m
+	}
+	switch a {
+	case AnchorTop, AnchorTopLeft, AnchorTopRight:
+		y = r.Y + m
+	case AnchorBottom, AnchorBottomLeft, AnchorBottomRight:
+		y = r.Y + r.Height - h - m
+	}
+	return rl.NewRectangle(x, y, w, h)
+}
+
+// walk обходит видимое дерево в порядке отрисовки
+func walk(w Widget, fn func(Widget)) {
+	if w == nil || w.Base().Hidden {
+		return
+	}
+	fn(w)
+	if p, ok := w.(parent); ok {
+		for _, ch := range p.children() {
+			walk(ch, fn)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package world
+
+import (
+	"example.com/my2dgame/internal/mapgen"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// GenTilePx — пикселей картинки на клетку сгенерированной карты
+const GenTilePx = 16
+
+// FromMap рисует сгенерированную карту в текстуру-фон (один раз, дальше это
+// обычная фон-карта) и переносит препятствия и точки появления в мир.
+// scale — как у LoadBackdrop: мировых единиц на пиксель картинки.
+func FromMap(m *mapgen.Map, scale float32) (*World, error) {
+	tex, err := mapTexture(m)
+	if err != nil {
+		return nil, err
+	}
+	cell := float32(GenTilePx) * scale
+	w := &World{
+		Backdrop:    tex,
+		UseBackdrop: true,
+		Scale:       scale,
+		WidthPx:     float32(m.W) * cell,
+		HeightPx:    float32(m.H) * cell,
+		Spawn:       cellCenter(m, m.Spawn, cell),
+		Solids:      mapSolids(m, cell),
+		objects:     mapObjects(m, cell),
+	}
+	for _, c := range m.Enemy {
+		w.EnemySpawns = append(w.EnemySpawns, cellCenter(m, c, cell))
+	}
+	return w, nil
+}
+
+// mapTexture рисует карту (или чанк) в текстуру, клетка — GenTilePx пикселей
+func mapTexture(m *mapgen.Map) (rl.Texture2D, error) {
+	const t = GenTilePx
+	pal := m.Biome.Palette
+	img := rl.GenImageColor(m.W*t, m.H*t, rgb(pal.Ground))
+	defer rl.UnloadImage(img)
+
+	for y := 0; y < m.H; y++ {
+		for x := 0; x < m.W; x++ {
+			px, py := int32(x*t), int32(y*t)
+			switch m.At(x, y) {
+			case mapgen.Wall:
+				rl.ImageDrawRectangle(img, px, py, t, t, rgb(pal.Wall))
+				// край стены над полом — псевдообъём
+				if m.At(x, y+1) != mapgen.Wall {
+					rl.ImageDrawRectangle(img, px, py+t-4, t, 4, rgb(pal.WallEdge))
+				}
+			case mapgen.Path:
+				rl.ImageDrawRectangle(img, px, py, t, t, rgb(pal.Path))
+			default:
+				if cellNoise(m.Origin.X+x, m.Origin.Y+y, m.Seed)%5 == 0 {
+					rl.ImageDrawRectangle(img, px, py, t, t, rgb(pal.GroundAlt))
+				}
+			}
+		}
+	}
+	for _, p := range m.Props {
+		drawProp(img, p)
+	}
+
+	tex := rl.LoadTextureFromImage(img)
+	if tex.ID == 0 {
+		return tex, errGenTexture
+	}
+	rl.SetTextureFilter(tex, rl.FilterPoint)
+	return tex, nil
+}
+
+// mapSolids — препятствия карты в мировых единицах (со сдвигом Origin);
+// стены — горизонтальными отрезками, чтобы прямоугольников было поменьше
+func mapSolids(m *mapgen.Map, cell float32) []rl.Rectangle {
+	var out []rl.Rectangle
+	ox, oy := float32(m.Origin.X)*cell, float32(m.Origin.Y)*cell
+	for y := 0; y < m.H; y++ {
+		for x := 0; x < m.W; {
+			if m.At(x, y) != mapgen.Wall {
+				x++
+				continue
+			}
+			x0 := x
+			for x < m.W && m.At(x, y) == mapgen.Wall {
+				x++
+			}
+			out = append(out, rl.NewRectangle(ox+float32(x0)*cell, oy+float32(y)*cell, float32(x-x0)*cell, cell))
+		}
+	}
+	for _, p := range m.Props {
+		if p.Solid {
+			out = append(out, rl.NewRectangle(ox+float32(int(p.X))*cell, oy+float32(int(p.Y))*cell, cell, cell))
+		}
+	}
+	return out
+}
+
+func drawProp(img *rl.Image, p mapgen.Prop) {
+	const t = GenTilePx
+	cx, cy := int32(p.X*t), int32(p.Y*t)
+	size := max(int32(p.Size*t), 1)
+	col := rgb(p.Color)
+	if p.Solid {
+		// тень под пропом
+		rl.ImageDrawCircle(img, cx+1, cy+size/3, size/2, rl.NewColor(0, 0, 0, 60))
+	}
+	switch p.Shape {
+	case "rect":
+		rl.ImageDrawRectangle(img, cx-size/2, cy-size/2, size, size, col)
+	case "cross":
+		bar := max(size/4, 1)
+		rl.ImageDrawRectangle(img, cx-bar/2, cy-size/2, bar, size, col)
+		rl.ImageDrawRectangle(img, cx-size/3, cy-size/4, 2*size/3, bar, col)
+	default:
+		rl.ImageDrawCircle(img, cx, cy, max(size/2, 1), col)
+		if p.Solid {
+			// блик сверху — чтобы круглое читалось объёмным
+			rl.ImageDrawCircle(img, cx-size/6, cy-size/6, max(size/5, 1), lighten(col))
+		}
+	}
+}
+
+// cellCenter — центр клетки карты в мире
+func cellCenter(m *mapgen.Map, c mapgen.Cell, cell float32) rl.Vector2 {
+	return rl.NewVector2((float32(m.Origin.X+c.X)+0.5)*cell, (float32(m.Origin.Y+c.Y)+0.5)*cell)
+}
+
+func rgb(c mapgen.RGB) rl.Color { return rl.NewColor(c[0], c[1], c[2], 255) }
+
+func lighten(c rl.Color) rl.Color {
+	return rl.NewColor(c.R+(255-c.R)/5, c.G+(255-c.G)/5, c.B+(255-c.B)/5, c.A)
+}
+
+// cellNoise — детерминированный «шум» клетки для пятен земли
+func cellNoise(x, y int, seed int64) uint32 {
+	h := uint32(x)*73856093 ^ uint32(y)*19349663 ^ uint32(seed)*83492791
+	h ^= h >> 13
+	h *= 0x5bd1e995
+	return h ^ h>>15
+}
@@ -0,0 +1,112 @@
+package world
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"example.com/my2dgame/internal/mapgen"
+)
+
+// ObjectSpawn — объект мира из слоя объектов карты: вид (имя из objects.json)
+// и середина основания в мировых единицах. Телепорт переносит на объект с ID == To.
+// У бесконечного мира Key — откуда объект, State — что с ним было до выгрузки чанка.
+type ObjectSpawn struct {
+	Type   string
+	X, Y   float32
+	ID, To string
+
+	Key   ObjectKey
+	State ObjectState
+}
+
+// ObjectKey — объект чанка: чанк и номер в его Map.Objects
+type ObjectKey struct {
+	Chunk mapgen.ChunkKey
+	Index int
+}
+
+// ObjectState — судьба объекта чанка, которую мир помнит и после выгрузки:
+// сломанный не вернётся, перезарядка продолжится
+type ObjectState struct {
+	Broken bool
+	Ready  float32 // время забега, когда объект снова готов
+}
+
+// SetObjectState запоминает состояние объекта чанка; у карт без чанков
+// объекты не выгружаются, и помнить нечего
+func (w *World) SetObjectState(k ObjectKey, st ObjectState) {
+	if w.stream == nil {
+		return
+	}
+	byIndex := w.stream.states[k.Chunk]
+	if byIndex == nil {
+		byIndex = map[int]ObjectState{}
+		w.stream.states[k.Chunk] = byIndex
+	}
+	byIndex[k.Index] = st
+}
+
+// TakeObjects — объекты, появившиеся в мире с прошлого вызова: у карт — все
+// сразу, у бесконечного мира — из только что загруженных чанков
+func (w *World) TakeObjects() []ObjectSpawn {
+	if w.stream != nil {
+		w.objects = append(w.objects, w.stream.objects...)
+		w.stream.objects = nil
+	}
+	out := w.objects
+	w.objects = nil
+	return out
+}
+
+// sidecar — файл рядом с картинкой карты: village.png → village.<kind>.json
+func sidecar(imagePath, kind string) string {
+	return strings.TrimSuffix(imagePath, filepath.Ext(imagePath)) + "." + kind + ".json"
+}
+
+// loadObjects читает слой объектов карты из <карта>.objects.json:
+// {"objects": [{"type": "crate", "x": 10, "y": 20, "id": "", "to": ""}, ...]}
+// в пикселях карты. Нет файла — нет объектов.
+func loadObjects(imagePath string, scale float32) ([]ObjectSpawn, error) {
+	path := sidecar(imagePath, "objects")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f struct {
+		Objects []struct {
+			Type string  `json:"type"`
+			X    float32 `json:"x"`
+			Y    float32 `json:"y"`
+			ID   string  `json:"id"`
+			To   string  `json:"to"`
+		} `json:"objects"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	out := make([]ObjectSpawn, 0, len(f.Objects))
+	for _, o := range f.Objects {
+		out = append(out, ObjectSpawn{Type: o.Type, X: o.X * scale, Y: o.Y * scale, ID: o.ID, To: o.To})
+	}
+	return out, nil
+}
+
+// mapObjects переводит объекты карты в мировые единицы; основание —
+// у нижнего края клетки, чтобы высокий объект не залезал в клетку ниже
+func mapObjects(m *mapgen.Map, cell float32) []ObjectSpawn {
+	out := make([]ObjectSpawn, 0, len(m.Objects))
+	for _, o := range m.Objects {
+		x := (float32(m.Origin.X) + o.X) * cell
+		y := (float32(m.Origin.Y) + o.Y + 0.4) * cell
+		out = append(out, ObjectSpawn{Type: o.Name, X: x, Y: y, ID: o.ID, To: o.To})
+	}
+	return out
+}
@@ -0,0 +1,260 @@
+package world
+
+import (
+	"fmt"
+	"math"
+
+	"example.com/my2dgame/internal/mapgen"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Сколько чанков держим вокруг видимой области: догружаем в полосе keepMargin,
+// выгружаем только за dropMargin (разрыв — чтобы не дёргать чанк на границе)
+const (
+	keepMargin = 1
+	dropMargin = 2
+
+	uploadsPerFrame = 2  // сколько готовых чанков превращаем в текстуры за кадр
+	queueSize       = 64 // очередь фоновой генерации
+)
+
+// chunk — загруженный чанк: карта, текстура и всё в мировых единицах
+type chunk struct {
+	m      *mapgen.Map
+	tex    rl.Texture2D
+	bounds rl.Rectangle
+	solids []rl.Rectangle
+	spawns []rl.Vector2
+}
+
+type loaded struct {
+	key mapgen.ChunkKey
+	m   *mapgen.Map
+	err error
+}
+
+// stream — бесконечный мир из чанков: генерация (или чтение с диска) в фоновой
+// горутине, текстуры — в основном потоке, вокруг камеры
+type stream struct {
+	src     mapgen.ChunkSource
+	cell    float32 // мировых единиц на клетку
+	chunks  map[mapgen.ChunkKey]*chunk
+	pending map[mapgen.ChunkKey]bool
+	jobs    chan mapgen.ChunkKey
+	done    chan loaded
+	quit    chan struct{} // закрывается в unload — воркер бросает работу
+	exited  chan struct{} // закрывает воркер на выходе
+	version uint64
+	objects []ObjectSpawn                           // объекты новых чанков; World.TakeObjects их забирает
+	states  map[mapgen.ChunkKey]map[int]ObjectState // что стало с объектами чанков
+}
+
+// NewStreamed — бесконечный мир из src; scale — мировых единиц на пиксель
+// картинки чанка (как у LoadBackdrop). Стартовый чанк грузится сразу.
+func NewStreamed(src mapgen.ChunkSource, scale float32) *World {
+	s := &stream{
+		src:     src,
+		cell:    GenTilePx * scale,
+		chunks:  map[mapgen.ChunkKey]*chunk{},
+		pending: map[mapgen.ChunkKey]bool{},
+		states:  map[mapgen.ChunkKey]map[int]ObjectState{},
+		jobs:    make(chan mapgen.ChunkKey, queueSize),
+		done:    make(chan loaded, queueSize),
+		quit:    make(chan struct{}),
+		exited:  make(chan struct{}),
+	}
+	go s.work()
+	w := &World{Scale: scale, stream: s}
+	half := float32(mapgen.ChunkSize) * s.cell / 2
+	w.Spawn = rl.NewVector2(half, half)
+	if m, err := src.Chunk(mapgen.ChunkKey{}); err != nil {
+		fmt.Println("chunk 0,0:", err)
+	} else {
+		if m.At(m.Spawn.X, m.Spawn.Y) != mapgen.Wall {
+			w.Spawn = cellCenter(m, m.Spawn, s.cell)
+		}
+		s.add(mapgen.ChunkKey{}, m)
+	}
+	w.collectSpawns()
+	return w
+}
+
+// work — фоновая генерация; выходит по закрытию quit, в том числе
+// посреди отправки результата, который уже никто не заберёт
+func (s *stream) work() {
+	defer close(s.exited)
+	for {
+		var k mapgen.ChunkKey
+		select {
+		case <-s.quit:
+			return
+		case k = <-s.jobs:
+		}
+		m, err := s.src.Chunk(k)
+		select {
+		case <-s.quit:
+			return
+		case s.done <- loaded{k, m, err}:
+		}
+	}
+}
+
+// chunkSpan — сторона чанка в мировых единицах
+func (s *stream) chunkSpan() float32 { return float32(mapgen.ChunkSize) * s.cell }
+
+// keysIn — чанки, задевающие прямоугольник, расширенный на margin чанков
+func (s *stream) keysIn(r rl.Rectangle, margin int) (x0, y0, x1, y1 int) {
+	span := s.chunkSpan()
+	x0 = int(math.Floor(float64(r.X/span))) - margin
+	y0 = int(math.Floor(float64(r.Y/span))) - margin
+	x1 = int(math.Floor(float64((r.X+r.Width)/span))) + margin
+	y1 = int(math.Floor(float64((r.Y+r.Height)/span))) + margin
+	return
+}
+
+// add превращает готовую карту чанка в загруженный чанк (основной поток)
+func (s *stream) add(k mapgen.ChunkKey, m *mapgen.Map) {
+	tex, err := mapTexture(m)
+	if err != nil {
+		fmt.Println("chunk", k.X, k.Y, ":", err)
+		return
+	}
+	span := s.chunkSpan()
+	c := &chunk{
+		m:      m,
+		tex:    tex,
+		bounds: rl.NewRectangle(float32(k.X)*span, float32(k.Y)*span, span, span),
+		solids: mapSolids(m, s.cell),
+	}
+	for _, e := range m.Enemy {
+		c.spawns = append(c.spawns, cellCenter(m, e, s.cell))
+	}
+	s.chunks[k] = c
+	s.version++
+	s.objects = append(s.objects, s.chunkObjects(k, m)...)
+}
+
+// chunkObjects — объекты чанка с их прошлым состоянием; сломанные не возвращаются
+func (s *stream) chunkObjects(k mapgen.ChunkKey, m *mapgen.Map) []ObjectSpawn {
+	all := mapObjects(m, s.cell)
+	st := s.states[k]
+	out := all[:0]
+	for i, o := range all {
+		o.Key, o.State = ObjectKey{k, i}, st[i]
+		if !o.State.Broken {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// Update — раз в кадр: заказывает чанки вокруг видимой области view
+// (мировые координаты), забирает готовые и выгружает дальние
+func (w *World) Update(view rl.Rectangle) {
+	s := w.stream
+	if s == nil {
+		return
+	}
+	x0, y0, x1, y1 := s.keysIn(view, keepMargin)
+	for ky := y0; ky <= y1; ky++ {
+		for kx := x0; kx <= x1; kx++ {
+			k := mapgen.ChunkKey{X: kx, Y: ky}
+			if s.chunks[k] != nil || s.pending[k] {
+				continue
+			}
+			select {
+			case s.jobs <- k:
+				s.pending[k] = true
+			default: // очередь полна — закажем в следующем кадре
+			}
+		}
+	}
+
+	dx0, dy0, dx1, dy1 := s.keysIn(view, dropMargin)
+	far := func(k mapgen.ChunkKey) bool { return k.X < dx0 || k.X > dx1 || k.Y < dy0 || k.Y > dy1 }
+	changed := false
+	for uploads := 0; uploads < uploadsPerFrame; {
+		var r loaded
+		select {
+		case r = <-s.done:
+		default:
+			uploads = uploadsPerFrame
+			continue
+		}
+		delete(s.pending, r.key)
+		if r.err != nil {
+			fmt.Println("chunk", r.key.X, r.key.Y, ":", r.err)
+			continue
+		}
+		if far(r.key) {
+			continue // пока генерировали, камера ушла
+		}
+		s.add(r.key, r.m)
+		uploads++
+		changed = true
+	}
+	for k, c := range s.chunks {
+		if far(k) {
+			rl.UnloadTexture(c.tex)
+			delete(s.chunks, k)
+			s.version++
+			changed = true
+		}
+	}
+	if changed {
+		w.collectSpawns()
+	}
+}
+
+// collectSpawns — точки врагов со всех загруженных чанков
+func (w *World) collectSpawns() {
+	w.EnemySpawns = w.EnemySpawns[:0]
+	for _, c := range w.stream.chunks {
+		w.EnemySpawns = append(w.EnemySpawns, c.spawns...)
+	}
+}
+
+// unload останавливает воркер (ждёт, пока он доделает текущий чанк и выйдет),
+// выбрасывает готовые, но не забранные результаты и выгружает текстуры
+func (s *stream) unload() {
+	close(s.quit)
+	<-s.exited
+	for len(s.done) > 0 {
+		<-s.done
+	}
+	clear(s.pending)
+	for k, c := range s.chunks {
+		rl.UnloadTexture(c.tex)
+		delete(s.chunks, k)
+	}
+}
+
+func (s *stream) draw(view rl.Rectangle) {
+	x0, y0, x1, y1 := s.keysIn(view, 0)
+	for ky := y0; ky <= y1; ky++ {
+		for kx := x0; kx <= x1; kx++ {
+			c := s.chunks[mapgen.ChunkKey{X: kx, Y: ky}]
+			if c == nil {
+				continue
+			}
+			src := rl.NewRectangle(0, 0, float32(c.tex.Width), float32(c.tex.Height))
+			rl.DrawTexturePro(c.tex, src, c.bounds, rl.NewVector2(0, 0), 0, rl.White)
+		}
+	}
+}
+
+func (s *stream) contains(x, y float32) bool {
+	span := s.chunkSpan()
+	k := mapgen.ChunkKey{X: int(math.Floor(float64(x / span))), Y: int(math.Floor(float64(y / span)))}
+	return s.chunks[k] != nil
+}
+
+func (s *stream) solidsIn(r rl.Rectangle) []rl.Rectangle {
+	var out []rl.Rectangle
+	for _, c := range s.chunks {
+		if rl.CheckCollisionRecs(c.bounds, r) {
+			out = append(out, c.solids...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"example.com/my2dgame/internal/mapgen"
+)
+
+// countingSource отдаёт пустые карты и считает запросы
+type countingSource struct{ calls chan mapgen.ChunkKey }
+
+func (c countingSource) Chunk(k mapgen.ChunkKey) (*mapgen.Map, error) {
+	c.calls <- k
+	return &mapgen.Map{}, nil
+}
+
+func newTestStream(src mapgen.ChunkSource) *stream {
+	s := &stream{
+		src:     src,
+		cell:    GenTilePx,
+		chunks:  map[mapgen.ChunkKey]*chunk{},
+		pending: map[mapgen.ChunkKey]bool{},
+		states:  map[mapgen.ChunkKey]map[int]ObjectState{},
+		jobs:    make(chan mapgen.ChunkKey, queueSize),
+		done:    make(chan loaded, queueSize),
+		quit:    make(chan struct{}),
+		exited:  make(chan struct{}),
+	}
+	go s.work()
+	return s
+}
+
+// воркер застрял на отправке в полный done — unload всё равно его останавливает
+func TestUnloadStopsBlockedWorker(t *testing.T) {
+	src := countingSource{calls: make(chan mapgen.ChunkKey, 4*queueSize)}
+	s := newTestStream(src)
+	for i := 0; i < queueSize; i++ {
+		s.jobs <- mapgen.ChunkKey{X: i}
+	}
+	// done вмещает queueSize — ещё задачи, и воркер повиснет на отправке
+	for i := 0; i < 2; i++ {
+		s.jobs <- mapgen.ChunkKey{Y: i + 1}
+	}
+	deadline := time.After(2 * time.Second)
+	for len(s.done) < queueSize {
+		select {
+		case <-deadline:
+			t.Fatalf("воркер не наполнил done: %d", len(s.done))
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.unload()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("unload завис: воркер не вышел")
+	}
+	select {
+	case <-s.exited:
+	default:
+		t.Fatal("воркер жив после unload")
+	}
+	if len(s.done) != 0 || len(s.pending) != 0 {
+		t.Fatalf("после unload: в done %d, заказано %d", len(s.done), len(s.pending))
+	}
+}
+
+func TestUnloadIdleWorker(t *testing.T) {
+	s := newTestStream(countingSource{calls: make(chan mapgen.ChunkKey, 1)})
+	s.unload()
+	select {
+	case <-s.exited:
+	default:
+		t.Fatal("воркер без работы не вышел")
+	}
+}
+
+// объекты чанка помнят, что с ними было: сломанные не возвращаются, перезарядка сохраняется
+func TestChunkObjectsRemembered(t *testing.T) {
+	s := newTestStream(countingSource{calls: make(chan mapgen.ChunkKey, 1)})
+	defer s.unload()
+	w := &World{stream: s}
+	k := mapgen.ChunkKey{X: 2, Y: -1}
+	m := &mapgen.Map{Origin: mapgen.Cell{X: 2 * mapgen.ChunkSize, Y: -mapgen.ChunkSize}, Objects: []mapgen.Object{
+		{Name: "crate", X: 1, Y: 1},
+		{Name: "shrine", X: 5, Y: 5},
+		{Name: "barrel", X: 9, Y: 9},
+	}}
+
+	first := s.chunkObjects(k, m)
+	if len(first) != 3 || first[1].Key != (ObjectKey{k, 1}) {
+		t.Fatalf("первая загрузка: %+v", first)
+	}
+	w.SetObjectState(first[0].Key, ObjectState{Broken: true})
+	w.SetObjectState(first[1].Key, ObjectState{Ready: 42})
+
+	again := s.chunkObjects(k, m)
+	if len(again) != 2 || again[0].Type != "shrine" || again[1].Type != "barrel" {
+		t.Fatalf("после возврата чанка: %+v", again)
+	}
+	if again[0].State.Ready != 42 || again[0].Key.Index != 1 || again[1].Key.Index != 2 {
+		t.Fatalf("состояние или номера съехали: %+v", again)
+	}
+	// у соседнего чанка с теми же номерами — своё состояние
+	if other := s.chunkObjects(mapgen.ChunkKey{X: 3, Y: -1}, m); len(other) != 3 {
+		t.Fatalf("соседний чанк: %d объектов, want 3", len(other))
+	}
+	// карта без чанков ничего не запоминает
+	(&World{}).SetObjectState(ObjectKey{}, ObjectState{Broken: true})
+}
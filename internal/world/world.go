@@ -1,7 +1,11 @@
 package world
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -22,8 +26,23 @@ type World struct {
 	// Общая метрика мира в пикселях
 	WidthPx  float32
 	HeightPx float32
+
+	// Solids — непроходимые области в мировых единицах
+	Solids []rl.Rectangle
+
+	// Spawn — старт игрока; EnemySpawns — где могут появляться враги
+	// (у фон-карт их нет: старт в центре, враги — вокруг игрока)
+	Spawn       rl.Vector2
+	EnemySpawns []rl.Vector2
+
+	// Режим 3: бесконечный мир из чанков (NewStreamed); WidthPx/HeightPx — 0
+	stream *stream
+
+	objects []ObjectSpawn // ещё не забранные через TakeObjects
 }
 
+var errGenTexture = errors.New("generated map texture failed")
+
 // ---------- ТАЙЛЫ ----------
 func Load(assetsRoot string, relPath string, tileSize int32, cols, rows int) (*World, error) {
 	img := rl.LoadImage(filepath.Join(assetsRoot, relPath))
@@ -68,10 +87,50 @@ func LoadBackdrop(assetsRoot string, relPath string, scale float32) (*World, err
 	}
 	w.WidthPx = float32(tex.Width) * scale
 	w.HeightPx = float32(tex.Height) * scale
+	w.Spawn = rl.NewVector2(w.WidthPx/2, w.HeightPx/2)
+
+	solids, err := loadSolids(filepath.Join(assetsRoot, relPath), scale)
+	if err != nil {
+		rl.UnloadTexture(tex)
+		return nil, err
+	}
+	w.Solids = solids
+	if w.objects, err = loadObjects(filepath.Join(assetsRoot, relPath), scale); err != nil {
+		rl.UnloadTexture(tex)
+		return nil, err
+	}
 	return w, nil
 }
 
+// loadSolids читает препятствия карты из <карта>.solid.json рядом с картинкой:
+// {"solids": [[x, y, w, h], ...]} в пикселях карты. Нет файла — нет препятствий.
+func loadSolids(imagePath string, scale float32) ([]rl.Rectangle, error) {
+	path := sidecar(imagePath, "solid")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f struct {
+		Solids [][4]float32 `json:"solids"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	out := make([]rl.Rectangle, 0, len(f.Solids))
+	for _, r := range f.Solids {
+		out = append(out, rl.NewRectangle(r[0]*scale, r[1]*scale, r[2]*scale, r[3]*scale))
+	}
+	return out, nil
+}
+
 func (w *World) Unload() {
+	if w.stream != nil {
+		w.stream.unload()
+		w.stream = nil
+	}
 	if w.TileTex.ID != 0 {
 		rl.UnloadTexture(w.TileTex)
 		w.TileTex = rl.Texture2D{}
@@ -84,7 +143,37 @@ func (w *World) Unload() {
 
 func (w *World) SizePx() (float32, float32) { return w.WidthPx, w.HeightPx }
 
+// Streamed — мир бесконечный (из чанков): у него нет краёв
+func (w *World) Streamed() bool { return w.stream != nil }
+
+// Version меняется, когда меняется набор загруженных препятствий
+func (w *World) Version() uint64 {
+	if w.stream == nil {
+		return 0
+	}
+	return w.stream.version
+}
+
+// Contains — точка внутри мира (у бесконечного — в загруженном чанке)
+func (w *World) Contains(x, y float32) bool {
+	if w.stream != nil {
+		return w.stream.contains(x, y)
+	}
+	return x >= 0 && y >= 0 && x <= w.WidthPx && y <= w.HeightPx
+}
+
+// SolidsIn — препятствия, которые могут задевать прямоугольник r
+func (w *World) SolidsIn(r rl.Rectangle) []rl.Rectangle {
+	if w.stream != nil {
+		return w.stream.solidsIn(r)
+	}
+	return w.Solids
+}
+
 func (w *World) Clamp(x, y float32) (float32, float32) {
+	if w.stream != nil {
+		return x, y
+	}
 	if x < 0 {
 		x = 0
 	}
@@ -100,8 +189,22 @@ func (w *World) Clamp(x, y float32) (float32, float32) {
 	return x, y
 }
 
-// Рисуем только видимое (для тайлов) или целиком (для фон-карты)
+// Рисуем только видимое (тайлы и чанки) или целиком (для фон-карты)
 func (w *World) Draw(cam rl.Camera2D) {
+	if w.stream != nil {
+		// камера центрирована (вьюпорт = 2×Offset) и может быть повёрнута:
+		// берём охватывающий прямоугольник всех четырёх углов
+		vw, vh := cam.Offset.X*2, cam.Offset.Y*2
+		lo := rl.GetScreenToWorld2D(rl.NewVector2(0, 0), cam)
+		hi := lo
+		for _, c := range []rl.Vector2{{X: vw}, {Y: vh}, {X: vw, Y: vh}} {
+			p := rl.GetScreenToWorld2D(c, cam)
+			lo = rl.NewVector2(min(lo.X, p.X), min(lo.Y, p.Y))
+			hi = rl.NewVector2(max(hi.X, p.X), max(hi.Y, p.Y))
+		}
+		w.stream.draw(rl.NewRectangle(lo.X, lo.Y, hi.X-lo.X, hi.Y-lo.Y))
+		return
+	}
 	if w.UseBackdrop {
 		if w.Backdrop.ID == 0 {
 			return
@@ -117,8 +220,9 @@ func (w *World) Draw(cam rl.Camera2D) {
 		return
 	}
 	t := float32(w.TileSize)
-	screenW := float32(rl.GetScreenWidth())
-	screenH := float32(rl.GetScreenHeight())
+	// камера центрирована, поэтому размер вьюпорта = 2×Offset (работает и для canvas)
+	screenW := cam.Offset.X * 2
+	screenH := cam.Offset.Y * 2
 	topLeft := rl.GetScreenToWorld2D(rl.NewVector2(0, 0), cam)
 	botRight := rl.GetScreenToWorld2D(rl.NewVector2(screenW, screenH), cam)
 	x0 := int(topLeft.X/t) - 1